@@ -19,6 +19,8 @@ type Client struct {
 	apiKey          string
 	httpClient      *http.Client
 	httpNextRequest time.Time
+	strict          bool
+	onDrift         func(Drift)
 	sync.Mutex
 }
 
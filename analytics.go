@@ -3,6 +3,8 @@ package alphavantage
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -37,6 +39,168 @@ type CalculationData struct {
 	Autocorrelation  map[string]float64 `json:"AUTOCORRELATION"`
 	Covariance       CorrelationData    `json:"COVARIANCE"`
 	Correlation      CorrelationData    `json:"CORRELATION"`
+	// Variants holds the results of parameterised calculations keyed
+	// as returned by the API, e.g. "MEAN(ANNUALIZED=TRUE)".  Only the
+	// field of the matching calculation is filled in each variant.
+	// MarshalJSON writes them back under their keys.
+	Variants map[string]CalculationData `json:"-"`
+}
+
+// UnmarshalJSON custom unmarshaller to map parameterised calculations
+// like "STDDEV(ANNUALIZED=TRUE)" to their field.  The unparameterised
+// calculation takes precedence over its variants for the field itself.
+func (cd *CalculationData) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	*cd = CalculationData{}
+	filled := make(map[AnalyticsCalculation]bool)
+	for _, key := range keys {
+		calculation := calculationOfKey(key)
+		if string(calculation) == strings.ToUpper(key) {
+			if err := cd.setCalculation(calculation, raw[key]); err != nil {
+				return err
+			}
+			filled[calculation] = true
+			continue
+		}
+		var variant CalculationData
+		if err := variant.setCalculation(calculation, raw[key]); err != nil {
+			return err
+		}
+		if cd.Variants == nil {
+			cd.Variants = make(map[string]CalculationData)
+		}
+		cd.Variants[strings.ToUpper(key)] = variant
+	}
+	// fill the fields of calculations only requested with parameters
+	for _, key := range keys {
+		calculation := calculationOfKey(key)
+		if filled[calculation] {
+			continue
+		}
+		if err := cd.setCalculation(calculation, raw[key]); err != nil {
+			return err
+		}
+		filled[calculation] = true
+	}
+	return nil
+}
+
+// calculationOfKey returns the calculation of a payload key like
+// "MEAN(ANNUALIZED=TRUE)"
+func calculationOfKey(key string) AnalyticsCalculation {
+	if i := strings.Index(key, "("); i >= 0 {
+		key = key[:i]
+	}
+	return AnalyticsCalculation(strings.ToUpper(key))
+}
+
+// analyticsCalculations are the calculations of CalculationData in the
+// order of its fields
+var analyticsCalculations = []AnalyticsCalculation{
+	AnalyticsCalculationMin,
+	AnalyticsCalculationMax,
+	AnalyticsCalculationMean,
+	AnalyticsCalculationMedian,
+	AnalyticsCalculationCumulativeReturn,
+	AnalyticsCalculationVariance,
+	AnalyticsCalculationStdDev,
+	AnalyticsCalculationMaxDrawdown,
+	AnalyticsCalculationHistogram,
+	AnalyticsCalculationAutocorrelation,
+	AnalyticsCalculationCovariance,
+	AnalyticsCalculationCorrelation,
+}
+
+// field returns a pointer to the field of the calculation, nil for
+// unknown calculations.
+func (cd *CalculationData) field(calculation AnalyticsCalculation) interface{} {
+	switch calculation {
+	case AnalyticsCalculationMin:
+		return &cd.Min
+	case AnalyticsCalculationMax:
+		return &cd.Max
+	case AnalyticsCalculationMean:
+		return &cd.Mean
+	case AnalyticsCalculationMedian:
+		return &cd.Median
+	case AnalyticsCalculationCumulativeReturn:
+		return &cd.CumulativeReturn
+	case AnalyticsCalculationVariance:
+		return &cd.Variance
+	case AnalyticsCalculationStdDev:
+		return &cd.StdDev
+	case AnalyticsCalculationMaxDrawdown:
+		return &cd.Drawdown
+	case AnalyticsCalculationHistogram:
+		return &cd.Histogram
+	case AnalyticsCalculationAutocorrelation:
+		return &cd.Autocorrelation
+	case AnalyticsCalculationCovariance:
+		return &cd.Covariance
+	case AnalyticsCalculationCorrelation:
+		return &cd.Correlation
+	}
+	return nil
+}
+
+// setCalculation unmarshals the result of the given calculation into its field.
+// Unknown calculations are ignored.
+func (cd *CalculationData) setCalculation(calculation AnalyticsCalculation, data []byte) error {
+	target := cd.field(calculation)
+	if target == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", calculation, err)
+	}
+	return nil
+}
+
+// isEmptyResult reports whether the field of a calculation has no result
+func isEmptyResult(field interface{}) bool {
+	v := reflect.ValueOf(field).Elem()
+	if v.Kind() == reflect.Map {
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// MarshalJSON writes the calculations and their variants under their
+// keys, e.g. "MEAN" and "MEAN(ANNUALIZED=TRUE)", like the API.  Fields
+// which were only filled from a variant aren't written again.
+func (cd CalculationData) MarshalJSON() ([]byte, error) {
+	payload := make(map[string]interface{})
+	for _, calculation := range analyticsCalculations {
+		field := cd.field(calculation)
+		if isEmptyResult(field) {
+			continue
+		}
+		fromVariant := false
+		for key, variant := range cd.Variants {
+			if calculationOfKey(key) == calculation && reflect.DeepEqual(variant.field(calculation), field) {
+				fromVariant = true
+				break
+			}
+		}
+		if !fromVariant {
+			payload[string(calculation)] = field
+		}
+	}
+	for key, variant := range cd.Variants {
+		if field := variant.field(calculationOfKey(key)); field != nil {
+			payload[key] = reflect.ValueOf(field).Elem().Interface()
+		}
+	}
+	return json.Marshal(payload)
 }
 
 // DrawdownRange represents the range of a drawdown.
@@ -115,6 +279,9 @@ func (c *Client) Analytics(symbols []string,
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	if err := c.checkResponse(functionName, body, analytics); err != nil {
+		return nil, err
+	}
 	return analytics, nil
 }
 
@@ -149,5 +316,8 @@ func (c *Client) Analytics2(symbols []string,
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	if err := c.checkResponse(functionName, body, analytics); err != nil {
+		return nil, err
+	}
 	return analytics, nil
 }
@@ -157,6 +157,7 @@ func TestToAnalytics(t *testing.T) {
 
 	analytics, err := toAnalytics([]byte(buf))
 	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "ANALYTICS_FIXED_WINDOW", buf, analytics)
 	assert.EqualStrings(t, "STOCK1,STOCK2", analytics.MetaData.Symbols)
 
 	expectedMinSTOCK2 := -0.048020086833708175
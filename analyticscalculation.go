@@ -0,0 +1,225 @@
+package alphavantage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CorrelationMethod represents the method used by the CORRELATION calculation.
+type CorrelationMethod string
+
+const (
+	// CorrelationMethodPearson is the Pearson correlation (API default).
+	CorrelationMethodPearson = CorrelationMethod("PEARSON")
+	// CorrelationMethodKendall is the Kendall rank correlation.
+	CorrelationMethodKendall = CorrelationMethod("KENDALL")
+	// CorrelationMethodSpearman is the Spearman rank correlation.
+	CorrelationMethodSpearman = CorrelationMethod("SPEARMAN")
+)
+
+// AnalyticsCalculationSpec is an analytics calculation together with its
+// optional parameters.  The zero value of each parameter means the API
+// default is used and the parameter is not rendered.
+//
+// Example:
+//
+//	NewAnalyticsCalculationSpec(AnalyticsCalculationStdDev).Annualize().String()
+//	// STDDEV(annualized=True)
+type AnalyticsCalculationSpec struct {
+	Calculation AnalyticsCalculation
+	Annualized  bool
+	Bins        int
+	Lag         int
+	Method      CorrelationMethod
+}
+
+// NewAnalyticsCalculationSpec creates a spec for the given calculation
+// without parameters.
+func NewAnalyticsCalculationSpec(calculation AnalyticsCalculation) AnalyticsCalculationSpec {
+	return AnalyticsCalculationSpec{Calculation: calculation}
+}
+
+// Annualize returns a copy of the spec with annualized=True.  Valid for
+// MEAN, VARIANCE, STDDEV and COVARIANCE.
+func (s AnalyticsCalculationSpec) Annualize() AnalyticsCalculationSpec {
+	s.Annualized = true
+	return s
+}
+
+// WithBins returns a copy of the spec with the number of HISTOGRAM bins.
+func (s AnalyticsCalculationSpec) WithBins(bins int) AnalyticsCalculationSpec {
+	s.Bins = bins
+	return s
+}
+
+// WithLag returns a copy of the spec with the AUTOCORRELATION lag.
+func (s AnalyticsCalculationSpec) WithLag(lag int) AnalyticsCalculationSpec {
+	s.Lag = lag
+	return s
+}
+
+// WithMethod returns a copy of the spec with the CORRELATION method.
+func (s AnalyticsCalculationSpec) WithMethod(method CorrelationMethod) AnalyticsCalculationSpec {
+	s.Method = method
+	return s
+}
+
+// params returns the rendered parameters of the spec
+func (s AnalyticsCalculationSpec) params() []string {
+	var params []string
+	if s.Annualized {
+		params = append(params, "annualized=True")
+	}
+	if s.Bins != 0 {
+		params = append(params, fmt.Sprintf("bins=%d", s.Bins))
+	}
+	if s.Lag != 0 {
+		params = append(params, fmt.Sprintf("lag=%d", s.Lag))
+	}
+	if s.Method != "" {
+		params = append(params, fmt.Sprintf("method=%s", s.Method))
+	}
+	return params
+}
+
+// String renders the spec as expected by the CALCULATIONS query parameter,
+// e.g. "HISTOGRAM(bins=20)".
+func (s AnalyticsCalculationSpec) String() string {
+	params := s.params()
+	if len(params) == 0 {
+		return string(s.Calculation)
+	}
+	return fmt.Sprintf("%s(%s)", s.Calculation, strings.Join(params, ","))
+}
+
+// Key returns the key the API uses for the result of the spec in the
+// payload, e.g. "MEAN(ANNUALIZED=TRUE)".
+func (s AnalyticsCalculationSpec) Key() string {
+	return strings.ToUpper(s.String())
+}
+
+// Validate checks the parameters of the spec against its calculation and
+// the given interval and OHLC field.
+func (s AnalyticsCalculationSpec) Validate(interval AnalyticsInterval, ohlc AnalyticsOhlc) error {
+	if !isAnalyticsCalculation(s.Calculation) {
+		return fmt.Errorf("unknown analytics calculation: %q", s.Calculation)
+	}
+	if !isAnalyticsInterval(interval) {
+		return fmt.Errorf("unknown analytics interval: %q", interval)
+	}
+	switch ohlc {
+	case AnalyticsOhlcOpen, AnalyticsOhlcHigh, AnalyticsOhlcLow, AnalyticsOhlcClose:
+	default:
+		return fmt.Errorf("unknown analytics ohlc: %q", ohlc)
+	}
+
+	if s.Annualized {
+		switch s.Calculation {
+		case AnalyticsCalculationMean, AnalyticsCalculationVariance,
+			AnalyticsCalculationStdDev, AnalyticsCalculationCovariance:
+		default:
+			return fmt.Errorf("%s: annualized is not supported", s.Calculation)
+		}
+		if !isAnalyticsIntervalDaily(interval) {
+			return fmt.Errorf("%s: annualized requires a daily, weekly or monthly interval, got %s", s.Calculation, interval)
+		}
+	}
+	if s.Bins != 0 {
+		if s.Calculation != AnalyticsCalculationHistogram {
+			return fmt.Errorf("%s: bins is not supported", s.Calculation)
+		}
+		if s.Bins < 0 {
+			return fmt.Errorf("%s: bins must be positive, got %d", s.Calculation, s.Bins)
+		}
+	}
+	if s.Lag != 0 {
+		if s.Calculation != AnalyticsCalculationAutocorrelation {
+			return fmt.Errorf("%s: lag is not supported", s.Calculation)
+		}
+		if s.Lag < 0 {
+			return fmt.Errorf("%s: lag must be positive, got %d", s.Calculation, s.Lag)
+		}
+	}
+	if s.Method != "" {
+		if s.Calculation != AnalyticsCalculationCorrelation {
+			return fmt.Errorf("%s: method is not supported", s.Calculation)
+		}
+		switch s.Method {
+		case CorrelationMethodPearson, CorrelationMethodKendall, CorrelationMethodSpearman:
+		default:
+			return fmt.Errorf("%s: unknown method %q", s.Calculation, s.Method)
+		}
+	}
+	return nil
+}
+
+// Result returns the results of the spec from the given calculation data.
+// Results of parameterised calculations are looked up in Variants, so
+// that e.g. MEAN and MEAN(annualized=True) can be requested together.
+func (s AnalyticsCalculationSpec) Result(cd CalculationData) (CalculationData, bool) {
+	key := s.Key()
+	if key == string(s.Calculation) {
+		return cd, true
+	}
+	variant, ok := cd.Variants[key]
+	return variant, ok
+}
+
+// AnalyticsCalculations validates the specs against the symbols, the
+// interval and the OHLC field and renders them for Analytics and
+// Analytics2.
+func AnalyticsCalculations(specs []AnalyticsCalculationSpec,
+	symbols []string,
+	interval AnalyticsInterval,
+	ohlc AnalyticsOhlc) ([]string, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no analytics calculations given")
+	}
+	calculations := make([]string, 0, len(specs))
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		if err := spec.Validate(interval, ohlc); err != nil {
+			return nil, err
+		}
+		if (spec.Calculation == AnalyticsCalculationCovariance ||
+			spec.Calculation == AnalyticsCalculationCorrelation) && len(symbols) < 2 {
+			return nil, fmt.Errorf("%s: requires at least 2 symbols, got %d", spec.Calculation, len(symbols))
+		}
+		key := spec.Key()
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate analytics calculation: %s", spec)
+		}
+		seen[key] = true
+		calculations = append(calculations, spec.String())
+	}
+	return calculations, nil
+}
+
+func isAnalyticsCalculation(calculation AnalyticsCalculation) bool {
+	switch calculation {
+	case AnalyticsCalculationMin, AnalyticsCalculationMax, AnalyticsCalculationMean,
+		AnalyticsCalculationMedian, AnalyticsCalculationCumulativeReturn,
+		AnalyticsCalculationVariance, AnalyticsCalculationStdDev,
+		AnalyticsCalculationMaxDrawdown, AnalyticsCalculationHistogram,
+		AnalyticsCalculationAutocorrelation, AnalyticsCalculationCovariance,
+		AnalyticsCalculationCorrelation:
+		return true
+	}
+	return false
+}
+
+func isAnalyticsInterval(interval AnalyticsInterval) bool {
+	switch interval {
+	case AnalyticsInterval1Min, AnalyticsInterval5Min, AnalyticsInterval15Min,
+		AnalyticsInterval30Min, AnalyticsInterval60Min:
+		return true
+	}
+	return isAnalyticsIntervalDaily(interval)
+}
+
+// isAnalyticsIntervalDaily reports whether the interval is daily or longer
+func isAnalyticsIntervalDaily(interval AnalyticsInterval) bool {
+	return interval == AnalyticsIntervalDaily ||
+		interval == AnalyticsIntervalWeekly ||
+		interval == AnalyticsIntervalMonthly
+}
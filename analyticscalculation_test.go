@@ -0,0 +1,154 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestAnalyticsCalculationSpecString(t *testing.T) {
+	assert.EqualStrings(t, "MEAN(annualized=True)",
+		NewAnalyticsCalculationSpec(AnalyticsCalculationMean).Annualize().String())
+	assert.EqualStrings(t, "STDDEV(annualized=True)",
+		NewAnalyticsCalculationSpec(AnalyticsCalculationStdDev).Annualize().String())
+	assert.EqualStrings(t, "HISTOGRAM(bins=20)",
+		NewAnalyticsCalculationSpec(AnalyticsCalculationHistogram).WithBins(20).String())
+	assert.EqualStrings(t, "AUTOCORRELATION(lag=2)",
+		NewAnalyticsCalculationSpec(AnalyticsCalculationAutocorrelation).WithLag(2).String())
+	assert.EqualStrings(t, "CORRELATION(method=KENDALL)",
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation).WithMethod(CorrelationMethodKendall).String())
+	assert.EqualStrings(t, "CUMULATIVE_RETURN",
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCumulativeReturn).String())
+	assert.EqualStrings(t, "MEAN(ANNUALIZED=TRUE)",
+		NewAnalyticsCalculationSpec(AnalyticsCalculationMean).Annualize().Key())
+}
+
+func TestAnalyticsCalculationSpecValidate(t *testing.T) {
+	mean := NewAnalyticsCalculationSpec(AnalyticsCalculationMean)
+	assert.NoError(t, mean.Annualize().Validate(AnalyticsIntervalDaily, AnalyticsOhlcClose))
+	assert.NoError(t, mean.Validate(AnalyticsInterval1Min, AnalyticsOhlcOpen))
+
+	// annualized intraday returns are rejected
+	assert.ErrorIncludesMessage(t, "annualized requires a daily, weekly or monthly interval",
+		mean.Annualize().Validate(AnalyticsInterval1Min, AnalyticsOhlcClose))
+	// parameters not supported by the calculation
+	assert.ErrorIncludesMessage(t, "MIN: annualized is not supported",
+		NewAnalyticsCalculationSpec(AnalyticsCalculationMin).Annualize().Validate(AnalyticsIntervalDaily, AnalyticsOhlcClose))
+	assert.ErrorIncludesMessage(t, "MEAN: bins is not supported",
+		mean.WithBins(10).Validate(AnalyticsIntervalDaily, AnalyticsOhlcClose))
+	assert.ErrorIncludesMessage(t, "MEAN: lag is not supported",
+		mean.WithLag(1).Validate(AnalyticsIntervalDaily, AnalyticsOhlcClose))
+	assert.ErrorIncludesMessage(t, "bins must be positive",
+		NewAnalyticsCalculationSpec(AnalyticsCalculationHistogram).WithBins(-1).Validate(AnalyticsIntervalDaily, AnalyticsOhlcClose))
+	assert.ErrorIncludesMessage(t, "unknown method",
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation).WithMethod("FOO").Validate(AnalyticsIntervalDaily, AnalyticsOhlcClose))
+	// unknown calculation, interval and ohlc
+	assert.ErrorIncludesMessage(t, "unknown analytics calculation",
+		NewAnalyticsCalculationSpec("FOO").Validate(AnalyticsIntervalDaily, AnalyticsOhlcClose))
+	assert.ErrorIncludesMessage(t, "unknown analytics interval", mean.Validate("2min", AnalyticsOhlcClose))
+	assert.ErrorIncludesMessage(t, "unknown analytics ohlc", mean.Validate(AnalyticsIntervalDaily, "Adjusted"))
+}
+
+func TestAnalyticsCalculations(t *testing.T) {
+	specs := []AnalyticsCalculationSpec{
+		NewAnalyticsCalculationSpec(AnalyticsCalculationMean),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationMean).Annualize(),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation),
+	}
+	calculations, err := AnalyticsCalculations(specs, []string{"STOCK1", "STOCK2"}, AnalyticsIntervalDaily, AnalyticsOhlcClose)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 3, len(calculations))
+	assert.EqualStrings(t, "MEAN(annualized=True)", calculations[1])
+
+	_, err = AnalyticsCalculations(specs, []string{"STOCK1"}, AnalyticsIntervalDaily, AnalyticsOhlcClose)
+	assert.ErrorIncludesMessage(t, "CORRELATION: requires at least 2 symbols", err)
+	_, err = AnalyticsCalculations(append(specs, specs[0]), []string{"STOCK1", "STOCK2"}, AnalyticsIntervalDaily, AnalyticsOhlcClose)
+	assert.ErrorIncludesMessage(t, "duplicate analytics calculation: MEAN", err)
+	_, err = AnalyticsCalculations(nil, []string{"STOCK1"}, AnalyticsIntervalDaily, AnalyticsOhlcClose)
+	assert.ErrorIncludesMessage(t, "no analytics calculations given", err)
+}
+
+func TestToAnalyticsCalculationVariants(t *testing.T) {
+	var buf = `{
+  "meta_data": {
+    "symbols": "STOCK1",
+    "min_dt": "2023-03-03",
+    "max_dt": "2024-03-01",
+    "ohlc": "Close",
+    "interval": "DAILY"
+  },
+  "payload": {
+    "RETURNS_CALCULATIONS": {
+      "MEAN": {
+        "STOCK1": 0.0018726725586940209
+      },
+      "MEAN(ANNUALIZED=TRUE)": {
+        "STOCK1": 0.4719135047708933
+      },
+      "STDDEV(ANNUALIZED=TRUE)": {
+        "STOCK1": 0.4819079427587014
+      },
+      "HISTOGRAM(BINS=2)": {
+        "STOCK1": {
+          "bin_count": [200, 50],
+          "bin_edges": [-0.09, 0.08, 0.26]
+        }
+      }
+    }
+  }
+}`
+	analytics, err := toAnalytics([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "ANALYTICS_FIXED_WINDOW", buf, analytics)
+	returns := analytics.Payload["RETURNS_CALCULATIONS"]
+
+	// the unparameterised calculation keeps its field
+	assert.EqualFloat64(t, 0.0018726725586940209, returns.Mean["STOCK1"])
+	// calculations only requested with parameters fill their field
+	assert.EqualFloat64(t, 0.4819079427587014, returns.StdDev["STOCK1"])
+	assert.EqualInt(t, 50, returns.Histogram["STOCK1"].BinCount[1])
+	assert.EqualInt(t, 3, len(returns.Variants))
+
+	annualized, ok := NewAnalyticsCalculationSpec(AnalyticsCalculationMean).Annualize().Result(returns)
+	assert.True(t, ok)
+	assert.EqualFloat64(t, 0.4719135047708933, annualized.Mean["STOCK1"])
+
+	plain, ok := NewAnalyticsCalculationSpec(AnalyticsCalculationMean).Result(returns)
+	assert.True(t, ok)
+	assert.EqualFloat64(t, 0.0018726725586940209, plain.Mean["STOCK1"])
+
+	_, ok = NewAnalyticsCalculationSpec(AnalyticsCalculationVariance).Annualize().Result(returns)
+	assert.False(t, ok)
+}
+
+func TestCalculationDataMarshalJSONRoundTrip(t *testing.T) {
+	var buf = `{
+  "MEAN": {"STOCK1": 0.0018},
+  "MEAN(ANNUALIZED=TRUE)": {"STOCK1": 0.47},
+  "STDDEV(ANNUALIZED=TRUE)": {"STOCK1": 0.48},
+  "CORRELATION": {"index": ["STOCK1", "STOCK2"], "correlation": [[1.0], [0.5, 1.0]]}
+}`
+	var original CalculationData
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(buf), &original))
+
+	data, err := json.Marshal(original)
+	assert.NoError(t.Fatalf, err)
+	var keys map[string]json.RawMessage
+	assert.NoError(t.Fatalf, json.Unmarshal(data, &keys))
+	assert.EqualInt(t, 4, len(keys))
+	for _, key := range []string{"MEAN", "MEAN(ANNUALIZED=TRUE)", "STDDEV(ANNUALIZED=TRUE)", "CORRELATION"} {
+		_, ok := keys[key]
+		assert.True(t, ok)
+	}
+	// STDDEV was only requested annualized
+	_, ok := keys["STDDEV"]
+	assert.False(t, ok)
+
+	var decoded CalculationData
+	assert.NoError(t.Fatalf, json.Unmarshal(data, &decoded))
+	assert.True(t, reflect.DeepEqual(original, decoded))
+	assert.EqualFloat64(t, 0.47, decoded.Variants["MEAN(ANNUALIZED=TRUE)"].Mean["STOCK1"])
+	assert.EqualFloat64(t, 0.0018, decoded.Mean["STOCK1"])
+}
@@ -0,0 +1,501 @@
+package alphavantage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// The calculations below replicate the Analytics endpoint locally.  Like
+// the API, all statistics except MAX_DRAWDOWN and CUMULATIVE_RETURN are
+// computed on the simple returns of the selected OHLC field.  Variances
+// are sample variances and annualized results are scaled by the number
+// of periods per year of the interval.
+
+const (
+	analyticsReturnsCalculations = "RETURNS_CALCULATIONS"
+	analyticsDefaultBins         = 10
+	analyticsDefaultLag          = 1
+)
+
+// LocalAnalytics computes the given calculations for the price series
+// without calling the API and returns them in the same shape as
+// Analytics.  All series must have the same interval.  COVARIANCE and
+// CORRELATION are computed on the returns of the dates all series have
+// in common.  Correlations and autocorrelations of constant returns are 0.
+// Series too short for a calculation are an error, e.g. VARIANCE needs 3
+// prices.
+func LocalAnalytics(series []*PriceSeries,
+	specs []AnalyticsCalculationSpec,
+	ohlc AnalyticsOhlc) (*Analytics, error) {
+	if len(series) == 0 {
+		return nil, fmt.Errorf("no price series given")
+	}
+	interval := toAnalyticsInterval(series[0].Interval)
+	symbols := make([]string, len(series))
+	for i, ps := range series {
+		if ps.Interval != series[0].Interval {
+			return nil, fmt.Errorf("%s: interval %s differs from %s", ps.Symbol, ps.Interval, series[0].Interval)
+		}
+		symbols[i] = ps.Symbol
+	}
+	if _, err := AnalyticsCalculations(specs, symbols, interval, ohlc); err != nil {
+		return nil, err
+	}
+
+	prices := make([][]float64, len(series))
+	returns := make([][]float64, len(series))
+	for i, ps := range series {
+		if ps.Len() < 2 {
+			return nil, fmt.Errorf("%s: not enough data points: need 2, got %d", ps.Symbol, ps.Len())
+		}
+		values, err := ps.Values(SeriesType(ohlc))
+		if err != nil {
+			return nil, err
+		}
+		prices[i] = values
+		returns[i] = simpleReturns(values)
+	}
+
+	cd := CalculationData{}
+	keys := make([]string, 0, len(specs))
+	computed := make(map[string]CalculationData, len(specs))
+	for _, spec := range specs {
+		var result CalculationData
+		switch spec.Calculation {
+		case AnalyticsCalculationCovariance, AnalyticsCalculationCorrelation:
+			matrix, err := localCorrelationMatrix(series, ohlc, spec, interval)
+			if err != nil {
+				return nil, err
+			}
+			if spec.Calculation == AnalyticsCalculationCovariance {
+				result.Covariance = matrix
+			} else {
+				result.Correlation = matrix
+			}
+		default:
+			for i, ps := range series {
+				if needed := localDataPoints(spec); ps.Len() < needed {
+					return nil, fmt.Errorf("%s: %s: not enough data points: need %d, got %d", ps.Symbol, spec, needed, ps.Len())
+				}
+				result.setLocal(spec, interval, ps, prices[i], returns[i])
+			}
+		}
+		key := spec.Key()
+		keys = append(keys, key)
+		computed[key] = result
+	}
+
+	// parameterised calculations end up in the same fields and variants
+	// as the API results, see CalculationData.UnmarshalJSON
+	sort.Strings(keys)
+	filled := make(map[AnalyticsCalculation]bool)
+	for _, key := range keys {
+		if AnalyticsCalculation(key) == calculationOfKey(key) {
+			cd.copyCalculation(calculationOfKey(key), computed[key])
+			filled[calculationOfKey(key)] = true
+			continue
+		}
+		if cd.Variants == nil {
+			cd.Variants = make(map[string]CalculationData)
+		}
+		cd.Variants[key] = computed[key]
+	}
+	for _, key := range keys {
+		calculation := calculationOfKey(key)
+		if filled[calculation] {
+			continue
+		}
+		cd.copyCalculation(calculation, computed[key])
+		filled[calculation] = true
+	}
+
+	minDt, maxDt := series[0].Bars[0].Date, series[0].Bars[series[0].Len()-1].Date
+	for _, ps := range series[1:] {
+		if d := ps.Bars[0].Date; d < minDt {
+			minDt = d
+		}
+		if d := ps.Bars[ps.Len()-1].Date; d > maxDt {
+			maxDt = d
+		}
+	}
+	return &Analytics{
+		MetaData: MetaData{
+			Symbols:  strings.Join(symbols, ","),
+			MinDt:    minDt,
+			MaxDt:    maxDt,
+			OHLC:     strings.ToUpper(string(ohlc[:1])) + string(ohlc[1:]),
+			Interval: string(interval),
+		},
+		Payload: map[string]CalculationData{analyticsReturnsCalculations: cd},
+	}, nil
+}
+
+// setLocal computes a single symbol calculation and stores it in the
+// field of the calculation.
+func (cd *CalculationData) setLocal(spec AnalyticsCalculationSpec,
+	interval AnalyticsInterval,
+	ps *PriceSeries,
+	prices []float64,
+	returns []float64) {
+	factor := 1.0
+	if spec.Annualized {
+		factor = periodsPerYear(interval)
+	}
+	setValue := func(m *map[string]float64, value float64) {
+		if *m == nil {
+			*m = make(map[string]float64)
+		}
+		(*m)[ps.Symbol] = value
+	}
+	switch spec.Calculation {
+	case AnalyticsCalculationMin:
+		setValue(&cd.Min, minValue(returns))
+	case AnalyticsCalculationMax:
+		setValue(&cd.Max, maxValue(returns))
+	case AnalyticsCalculationMean:
+		setValue(&cd.Mean, meanValue(returns)*factor)
+	case AnalyticsCalculationMedian:
+		setValue(&cd.Median, medianValue(returns))
+	case AnalyticsCalculationCumulativeReturn:
+		setValue(&cd.CumulativeReturn, prices[len(prices)-1]/prices[0]-1)
+	case AnalyticsCalculationVariance:
+		setValue(&cd.Variance, varianceValue(returns)*factor)
+	case AnalyticsCalculationStdDev:
+		setValue(&cd.StdDev, math.Sqrt(varianceValue(returns)*factor))
+	case AnalyticsCalculationAutocorrelation:
+		lag := spec.Lag
+		if lag == 0 {
+			lag = analyticsDefaultLag
+		}
+		setValue(&cd.Autocorrelation, pearson(returns[lag:], returns[:len(returns)-lag]))
+	case AnalyticsCalculationMaxDrawdown:
+		if cd.Drawdown == nil {
+			cd.Drawdown = make(Drawdown)
+		}
+		cd.Drawdown[ps.Symbol] = maxDrawdown(ps.Dates(), prices)
+	case AnalyticsCalculationHistogram:
+		bins := spec.Bins
+		if bins == 0 {
+			bins = analyticsDefaultBins
+		}
+		if cd.Histogram == nil {
+			cd.Histogram = make(Histogram)
+		}
+		cd.Histogram[ps.Symbol] = histogram(returns, bins)
+	}
+}
+
+// localDataPoints returns the number of prices a single symbol
+// calculation needs, e.g. the sample variance needs 2 returns.
+func localDataPoints(spec AnalyticsCalculationSpec) int {
+	switch spec.Calculation {
+	case AnalyticsCalculationVariance, AnalyticsCalculationStdDev:
+		return 3
+	case AnalyticsCalculationAutocorrelation:
+		lag := spec.Lag
+		if lag == 0 {
+			lag = analyticsDefaultLag
+		}
+		return lag + 3
+	}
+	return 2
+}
+
+// copyCalculation copies the field of the calculation from src
+func (cd *CalculationData) copyCalculation(calculation AnalyticsCalculation, src CalculationData) {
+	switch calculation {
+	case AnalyticsCalculationMin:
+		cd.Min = src.Min
+	case AnalyticsCalculationMax:
+		cd.Max = src.Max
+	case AnalyticsCalculationMean:
+		cd.Mean = src.Mean
+	case AnalyticsCalculationMedian:
+		cd.Median = src.Median
+	case AnalyticsCalculationCumulativeReturn:
+		cd.CumulativeReturn = src.CumulativeReturn
+	case AnalyticsCalculationVariance:
+		cd.Variance = src.Variance
+	case AnalyticsCalculationStdDev:
+		cd.StdDev = src.StdDev
+	case AnalyticsCalculationMaxDrawdown:
+		cd.Drawdown = src.Drawdown
+	case AnalyticsCalculationHistogram:
+		cd.Histogram = src.Histogram
+	case AnalyticsCalculationAutocorrelation:
+		cd.Autocorrelation = src.Autocorrelation
+	case AnalyticsCalculationCovariance:
+		cd.Covariance = src.Covariance
+	case AnalyticsCalculationCorrelation:
+		cd.Correlation = src.Correlation
+	}
+}
+
+// localCorrelationMatrix computes the lower triangular covariance or
+// correlation matrix of the returns on the common dates of all series.
+func localCorrelationMatrix(series []*PriceSeries,
+	ohlc AnalyticsOhlc,
+	spec AnalyticsCalculationSpec,
+	interval AnalyticsInterval) (CorrelationData, error) {
+	aligned, err := alignedReturns(series, ohlc)
+	if err != nil {
+		return CorrelationData{}, err
+	}
+	factor := 1.0
+	if spec.Annualized {
+		factor = periodsPerYear(interval)
+	}
+	data := CorrelationData{
+		Index:       make([]string, len(series)),
+		Correlation: make([][]float64, len(series)),
+	}
+	for i, ps := range series {
+		data.Index[i] = ps.Symbol
+		data.Correlation[i] = make([]float64, i+1)
+		for j := 0; j <= i; j++ {
+			if spec.Calculation == AnalyticsCalculationCovariance {
+				data.Correlation[i][j] = covarianceValue(aligned[i], aligned[j]) * factor
+				continue
+			}
+			switch spec.Method {
+			case CorrelationMethodKendall:
+				data.Correlation[i][j] = kendall(aligned[i], aligned[j])
+			case CorrelationMethodSpearman:
+				data.Correlation[i][j] = pearson(ranks(aligned[i]), ranks(aligned[j]))
+			default:
+				data.Correlation[i][j] = pearson(aligned[i], aligned[j])
+			}
+		}
+	}
+	return data, nil
+}
+
+// alignedReturns returns the simple returns of each series restricted to
+// the dates all series have in common.
+func alignedReturns(series []*PriceSeries, ohlc AnalyticsOhlc) ([][]float64, error) {
+	counts := make(map[string]int)
+	for _, ps := range series {
+		for _, bar := range ps.Bars {
+			counts[bar.Date]++
+		}
+	}
+	aligned := make([][]float64, len(series))
+	for i, ps := range series {
+		var prices []float64
+		for _, bar := range ps.Bars {
+			if counts[bar.Date] != len(series) {
+				continue
+			}
+			switch SeriesType(ohlc) {
+			case SeriesTypeOpen:
+				prices = append(prices, bar.Open)
+			case SeriesTypeHigh:
+				prices = append(prices, bar.High)
+			case SeriesTypeLow:
+				prices = append(prices, bar.Low)
+			default:
+				prices = append(prices, bar.Close)
+			}
+		}
+		if len(prices) < 3 {
+			return nil, fmt.Errorf("not enough common data points: need 3, got %d", len(prices))
+		}
+		aligned[i] = simpleReturns(prices)
+	}
+	return aligned, nil
+}
+
+// toAnalyticsInterval converts the interval of a price series to the
+// interval notation of the Analytics endpoint.
+func toAnalyticsInterval(interval Interval) AnalyticsInterval {
+	switch interval {
+	case IntervalDaily, IntervalWeekly, IntervalMonthly:
+		return AnalyticsInterval(strings.ToUpper(string(interval)))
+	}
+	return AnalyticsInterval(interval)
+}
+
+// periodsPerYear returns the annualization factor of the interval
+func periodsPerYear(interval AnalyticsInterval) float64 {
+	switch interval {
+	case AnalyticsIntervalWeekly:
+		return 52
+	case AnalyticsIntervalMonthly:
+		return 12
+	}
+	return 252
+}
+
+func simpleReturns(prices []float64) []float64 {
+	returns := make([]float64, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		returns[i-1] = prices[i]/prices[i-1] - 1
+	}
+	return returns
+}
+
+func minValue(values []float64) float64 {
+	m := values[0]
+	for _, v := range values[1:] {
+		m = math.Min(m, v)
+	}
+	return m
+}
+
+func maxValue(values []float64) float64 {
+	m := values[0]
+	for _, v := range values[1:] {
+		m = math.Max(m, v)
+	}
+	return m
+}
+
+func meanValue(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func medianValue(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// varianceValue returns the sample variance
+func varianceValue(values []float64) float64 {
+	return covarianceValue(values, values)
+}
+
+// covarianceValue returns the sample covariance of x and y of equal length,
+// NaN for fewer than 2 values, which LocalAnalytics rejects beforehand
+func covarianceValue(x, y []float64) float64 {
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	mx, my := meanValue(x), meanValue(y)
+	sum := 0.0
+	for i := range x {
+		sum += (x[i] - mx) * (y[i] - my)
+	}
+	return sum / float64(len(x)-1)
+}
+
+// pearson returns the Pearson correlation of x and y of equal length, 0 if
+// x or y is constant
+func pearson(x, y []float64) float64 {
+	denominator := math.Sqrt(varianceValue(x) * varianceValue(y))
+	if denominator == 0 {
+		return 0
+	}
+	return covarianceValue(x, y) / denominator
+}
+
+// ranks returns the ranks of values starting at 1, ties get their average rank
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] < values[order[j]]
+	})
+	out := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && values[order[j+1]] == values[order[i]] {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			out[order[k]] = rank
+		}
+		i = j + 1
+	}
+	return out
+}
+
+// kendall returns Kendall's tau-b of x and y of equal length, 0 if x or y
+// is constant
+func kendall(x, y []float64) float64 {
+	var concordant, discordant, tiesX, tiesY float64
+	for i := 0; i < len(x); i++ {
+		for j := i + 1; j < len(x); j++ {
+			dx := x[i] - x[j]
+			dy := y[i] - y[j]
+			switch {
+			case dx == 0 && dy == 0:
+			case dx == 0:
+				tiesX++
+			case dy == 0:
+				tiesY++
+			case dx*dy > 0:
+				concordant++
+			default:
+				discordant++
+			}
+		}
+	}
+	denominator := math.Sqrt((concordant + discordant + tiesX) * (concordant + discordant + tiesY))
+	if denominator == 0 {
+		return 0
+	}
+	return (concordant - discordant) / denominator
+}
+
+// maxDrawdown returns the largest peak to trough decline of the prices
+// and the dates of the peak and the trough.
+func maxDrawdown(dates []string, prices []float64) DrawdownData {
+	var data DrawdownData
+	peak := 0
+	for i := range prices {
+		if prices[i] > prices[peak] {
+			peak = i
+		}
+		drawdown := prices[i]/prices[peak] - 1
+		if drawdown < data.MaxDrawdown {
+			data.MaxDrawdown = drawdown
+			data.DrawdownRange = DrawdownRange{
+				StartDrawdown: dates[peak],
+				EndDrawdown:   dates[i],
+			}
+		}
+	}
+	return data
+}
+
+// histogram counts the values in equal width bins between the minimum
+// and the maximum value.  The last bin includes its right edge.
+func histogram(values []float64, bins int) HistogramData {
+	lo, hi := minValue(values), maxValue(values)
+	if lo == hi {
+		lo -= 0.5
+		hi += 0.5
+	}
+	width := (hi - lo) / float64(bins)
+	data := HistogramData{
+		BinCount: make([]int, bins),
+		BinEdges: make([]float64, bins+1),
+	}
+	for i := range data.BinEdges {
+		data.BinEdges[i] = lo + float64(i)*width
+	}
+	data.BinEdges[bins] = hi
+	for _, v := range values {
+		bin := int((v - lo) / width)
+		if bin >= bins {
+			bin = bins - 1
+		}
+		data.BinCount[bin]++
+	}
+	return data
+}
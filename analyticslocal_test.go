@@ -0,0 +1,163 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+// localAnalyticsPriceSeries returns STOCK1 of the indicator fixture and
+// STOCK2 made of its open prices with two dates missing.
+func localAnalyticsPriceSeries(t *testing.T) []*PriceSeries {
+	stock1 := localIndicatorPriceSeries(t)
+	var bars []Bar
+	for i, bar := range stock1.Bars {
+		if i == 5 || i == 20 {
+			continue
+		}
+		bars = append(bars, Bar{Date: bar.Date, Open: bar.Open, High: bar.Open, Low: bar.Open, Close: bar.Open})
+	}
+	stock2 := NewPriceSeries("STOCK2", stock1.Interval, bars)
+	return []*PriceSeries{stock1, stock2}
+}
+
+func TestLocalAnalytics(t *testing.T) {
+	series := localAnalyticsPriceSeries(t)
+	mean := NewAnalyticsCalculationSpec(AnalyticsCalculationMean)
+	annualizedMean := mean.Annualize()
+	analytics, err := LocalAnalytics(series, []AnalyticsCalculationSpec{
+		NewAnalyticsCalculationSpec(AnalyticsCalculationMin),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationMax),
+		mean,
+		annualizedMean,
+		NewAnalyticsCalculationSpec(AnalyticsCalculationMedian),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCumulativeReturn),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationVariance),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationStdDev).Annualize(),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationMaxDrawdown),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationHistogram).WithBins(4),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationAutocorrelation).WithLag(2),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCovariance),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation).WithMethod(CorrelationMethodSpearman),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation).WithMethod(CorrelationMethodKendall),
+	}, AnalyticsOhlcClose)
+	assert.NoError(t.Fatalf, err)
+
+	assert.EqualStrings(t, "STOCK1,STOCK2", analytics.MetaData.Symbols)
+	assert.EqualStrings(t, "2024-01-02", analytics.MetaData.MinDt)
+	assert.EqualStrings(t, "2024-02-26", analytics.MetaData.MaxDt)
+	assert.EqualStrings(t, "Close", analytics.MetaData.OHLC)
+	assert.EqualStrings(t, "DAILY", analytics.MetaData.Interval)
+
+	returns := analytics.Payload["RETURNS_CALCULATIONS"]
+	assertEqualRounded(t, -0.0249, returns.Min["STOCK1"])
+	assertEqualRounded(t, 0.0295, returns.Max["STOCK1"])
+	assertEqualRounded(t, -0.0005, returns.Mean["STOCK1"])
+	assertEqualRounded(t, -0.0023, returns.Median["STOCK1"])
+	assertEqualRounded(t, -0.0243, returns.CumulativeReturn["STOCK1"])
+	assertEqualRounded(t, 0.0002, returns.Variance["STOCK1"])
+	assertEqualRounded(t, 0.2376, returns.StdDev["STOCK1"])
+	assertEqualRounded(t, 0.1139, returns.Autocorrelation["STOCK1"])
+
+	annualized, ok := annualizedMean.Result(returns)
+	assert.True(t, ok)
+	assertEqualRounded(t, -0.1314, annualized.Mean["STOCK1"])
+
+	drawdown := returns.Drawdown["STOCK1"]
+	assertEqualRounded(t, -0.1288, drawdown.MaxDrawdown)
+	assert.EqualStrings(t, "2024-01-16", drawdown.DrawdownRange.StartDrawdown)
+	assert.EqualStrings(t, "2024-02-09", drawdown.DrawdownRange.EndDrawdown)
+
+	histogram := returns.Histogram["STOCK1"]
+	for i, count := range []int{12, 8, 14, 5} {
+		assert.EqualInt(t, count, histogram.BinCount[i])
+	}
+	assert.EqualInt(t, 5, len(histogram.BinEdges))
+	assertEqualRounded(t, -0.0113, histogram.BinEdges[1])
+
+	assert.EqualStrings(t, "STOCK2", returns.Covariance.Index[1])
+	assertEqualRounded(t, 0.0002, returns.Covariance.Correlation[0][0])
+	assertEqualRounded(t, 0.0001, returns.Covariance.Correlation[1][0])
+	assert.EqualInt(t, 2, len(returns.Correlation.Correlation[1]))
+	assertEqualRounded(t, 1, returns.Correlation.Correlation[1][1])
+	assertEqualRounded(t, 0.4411, returns.Correlation.Correlation[1][0])
+	spearman, ok := NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation).WithMethod(CorrelationMethodSpearman).Result(returns)
+	assert.True(t, ok)
+	assertEqualRounded(t, 0.4478, spearman.Correlation.Correlation[1][0])
+	kendall, ok := NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation).WithMethod(CorrelationMethodKendall).Result(returns)
+	assert.True(t, ok)
+	assertEqualRounded(t, 0.3063, kendall.Correlation.Correlation[1][0])
+}
+
+func TestLocalAnalyticsErrors(t *testing.T) {
+	series := localAnalyticsPriceSeries(t)
+	correlation := []AnalyticsCalculationSpec{NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation)}
+
+	_, err := LocalAnalytics(nil, correlation, AnalyticsOhlcClose)
+	assert.ErrorIncludesMessage(t, "no price series given", err)
+	_, err = LocalAnalytics(series[:1], correlation, AnalyticsOhlcClose)
+	assert.ErrorIncludesMessage(t, "requires at least 2 symbols", err)
+
+	weekly := NewPriceSeries("STOCK3", IntervalWeekly, series[1].Bars)
+	_, err = LocalAnalytics([]*PriceSeries{series[0], weekly}, correlation, AnalyticsOhlcClose)
+	assert.ErrorIncludesMessage(t, "STOCK3: interval weekly differs from daily", err)
+
+	short := NewPriceSeries("STOCK3", IntervalDaily, series[0].Bars[:1])
+	_, err = LocalAnalytics([]*PriceSeries{short}, []AnalyticsCalculationSpec{
+		NewAnalyticsCalculationSpec(AnalyticsCalculationMean),
+	}, AnalyticsOhlcClose)
+	assert.ErrorIncludesMessage(t, "STOCK3: not enough data points: need 2, got 1", err)
+
+	// too short for a sample variance or the lag, rather than NaN
+	short = NewPriceSeries("STOCK3", IntervalDaily, series[0].Bars[:2])
+	_, err = LocalAnalytics([]*PriceSeries{short}, []AnalyticsCalculationSpec{
+		NewAnalyticsCalculationSpec(AnalyticsCalculationStdDev),
+	}, AnalyticsOhlcClose)
+	assert.ErrorIncludesMessage(t, "STOCK3: STDDEV: not enough data points: need 3, got 2", err)
+
+	short = NewPriceSeries("STOCK3", IntervalDaily, series[0].Bars[:4])
+	_, err = LocalAnalytics([]*PriceSeries{short}, []AnalyticsCalculationSpec{
+		NewAnalyticsCalculationSpec(AnalyticsCalculationAutocorrelation).WithLag(2),
+	}, AnalyticsOhlcClose)
+	assert.ErrorIncludesMessage(t, "STOCK3: AUTOCORRELATION(lag=2): not enough data points: need 5, got 4", err)
+
+	analytics, err := LocalAnalytics([]*PriceSeries{NewPriceSeries("STOCK3", IntervalDaily, series[0].Bars[:5])}, []AnalyticsCalculationSpec{
+		NewAnalyticsCalculationSpec(AnalyticsCalculationVariance),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationAutocorrelation).WithLag(2),
+	}, AnalyticsOhlcClose)
+	assert.NoError(t.Fatalf, err)
+	_, err = json.Marshal(analytics)
+	assert.NoError(t, err)
+}
+
+func TestLocalAnalyticsFlatSeries(t *testing.T) {
+	series := localAnalyticsPriceSeries(t)
+	var bars []Bar
+	for _, bar := range series[0].Bars {
+		bars = append(bars, Bar{Date: bar.Date, Open: 100, High: 100, Low: 100, Close: 100})
+	}
+	flat := NewPriceSeries("FLAT", series[0].Interval, bars)
+	correlations := []AnalyticsCalculationSpec{
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation).WithMethod(CorrelationMethodSpearman),
+		NewAnalyticsCalculationSpec(AnalyticsCalculationCorrelation).WithMethod(CorrelationMethodKendall),
+	}
+	analytics, err := LocalAnalytics([]*PriceSeries{series[0], flat},
+		append(correlations, NewAnalyticsCalculationSpec(AnalyticsCalculationAutocorrelation)),
+		AnalyticsOhlcClose)
+	assert.NoError(t.Fatalf, err)
+
+	returns := analytics.Payload["RETURNS_CALCULATIONS"]
+	assert.EqualFloat64(t, 0, returns.Autocorrelation["FLAT"])
+	for _, spec := range correlations {
+		correlation, ok := spec.Result(returns)
+		assert.True(t.Fatalf, ok)
+		assert.EqualFloat64(t, 0, correlation.Correlation.Correlation[1][0])
+		assert.EqualFloat64(t, 0, correlation.Correlation.Correlation[1][1])
+	}
+
+	_, err = json.Marshal(analytics)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,206 @@
+package alphavantage
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// UnmarshalJSON custom unmarshaller to accept the "covariance" key the
+// API uses for COVARIANCE results besides "correlation".
+func (cd *CorrelationData) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Index       []string    `json:"index"`
+		Correlation [][]float64 `json:"correlation"`
+		Covariance  [][]float64 `json:"covariance"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	cd.Index = raw.Index
+	cd.Correlation = raw.Correlation
+	if cd.Correlation == nil {
+		cd.Correlation = raw.Covariance
+	}
+	return nil
+}
+
+// Validate checks that there is a row for each symbol of the index and
+// that the rows form a lower triangular (or a full square) matrix.  The
+// upper triangle of a full square matrix must mirror the lower one.
+func (cd CorrelationData) Validate() error {
+	n := len(cd.Index)
+	if len(cd.Correlation) != n {
+		return fmt.Errorf("matrix has %d rows for %d symbols", len(cd.Correlation), n)
+	}
+	seen := make(map[string]bool, n)
+	for i, symbol := range cd.Index {
+		if seen[symbol] {
+			return fmt.Errorf("duplicate symbol in index: %s", symbol)
+		}
+		seen[symbol] = true
+		if l := len(cd.Correlation[i]); l != i+1 && l != n {
+			return fmt.Errorf("row %d (%s) has %d values, expected %d", i, symbol, l, i+1)
+		}
+	}
+	for i, row := range cd.Correlation {
+		for j := i + 1; j < len(row); j++ {
+			if !symmetric(row[j], cd.Correlation[j][i]) {
+				return fmt.Errorf("matrix isn't symmetric: %s/%s is %v, %s/%s is %v",
+					cd.Index[i], cd.Index[j], row[j], cd.Index[j], cd.Index[i], cd.Correlation[j][i])
+			}
+		}
+	}
+	return nil
+}
+
+// symmetric reports whether a and b are equal up to rounding
+func symmetric(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+// Matrix expands the lower triangular data to a full symmetric matrix.
+func (cd CorrelationData) Matrix() (*Matrix, error) {
+	if err := cd.Validate(); err != nil {
+		return nil, err
+	}
+	n := len(cd.Index)
+	m := &Matrix{
+		Index:  make([]string, n),
+		Values: make([][]float64, n),
+	}
+	copy(m.Index, cd.Index)
+	for i := range m.Values {
+		m.Values[i] = make([]float64, n)
+	}
+	for i, row := range cd.Correlation {
+		for j := 0; j <= i; j++ {
+			m.Values[i][j] = row[j]
+			m.Values[j][i] = row[j]
+		}
+	}
+	return m, nil
+}
+
+// Matrix is a full symmetric covariance or correlation matrix, Values
+// are indexed like Index.
+type Matrix struct {
+	Index  []string
+	Values [][]float64
+}
+
+// MatrixPair is the value of two different symbols of a Matrix.
+type MatrixPair struct {
+	A     string
+	B     string
+	Value float64
+}
+
+// Len returns the number of symbols
+func (m *Matrix) Len() int {
+	return len(m.Index)
+}
+
+// Get returns the value of the symbols a and b in any order.
+func (m *Matrix) Get(a, b string) (float64, bool) {
+	i, j := m.position(a), m.position(b)
+	if i < 0 || j < 0 || i >= len(m.Values) || j >= len(m.Values[i]) {
+		return 0, false
+	}
+	return m.Values[i][j], true
+}
+
+// position returns the index of the symbol, -1 if it's missing
+func (m *Matrix) position(symbol string) int {
+	for i, s := range m.Index {
+		if s == symbol {
+			return i
+		}
+	}
+	return -1
+}
+
+// Pairs returns all pairs of different symbols ordered by strength,
+// i.e. by absolute value, strongest first.
+func (m *Matrix) Pairs() []MatrixPair {
+	var pairs []MatrixPair
+	for i := range m.Index {
+		for j := 0; j < i; j++ {
+			pairs = append(pairs, MatrixPair{A: m.Index[j], B: m.Index[i], Value: m.Values[i][j]})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return math.Abs(pairs[i].Value) > math.Abs(pairs[j].Value)
+	})
+	return pairs
+}
+
+// WriteCSV writes the matrix with a header row and a header column of
+// the symbols.
+func (m *Matrix) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := append([]string{""}, m.Index...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for i, row := range m.Values {
+		record := make([]string, 0, len(row)+1)
+		record = append(record, m.Index[i])
+		for _, value := range row {
+			record = append(record, strconv.FormatFloat(value, 'g', -1, 64))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// MarshalJSON renders the matrix as nested objects keyed by symbol,
+// e.g. {"A": {"A": 1, "B": 0.5}, "B": {"A": 0.5, "B": 1}}.
+func (m *Matrix) MarshalJSON() ([]byte, error) {
+	out := make(map[string]map[string]float64, len(m.Index))
+	for i, a := range m.Index {
+		out[a] = make(map[string]float64, len(m.Index))
+		for j, b := range m.Index {
+			out[a][b] = m.Values[i][j]
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON reads the nested objects of MarshalJSON.  The index is
+// sorted by symbol, the matrix must be complete and symmetric.
+func (m *Matrix) UnmarshalJSON(data []byte) error {
+	var raw map[string]map[string]float64
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	cd := CorrelationData{Index: make([]string, 0, len(raw))}
+	for symbol := range raw {
+		cd.Index = append(cd.Index, symbol)
+	}
+	sort.Strings(cd.Index)
+	for _, a := range cd.Index {
+		row := make([]float64, len(cd.Index))
+		for j, b := range cd.Index {
+			value, ok := raw[a][b]
+			if !ok {
+				return fmt.Errorf("matrix has no value for %s/%s", a, b)
+			}
+			row[j] = value
+		}
+		cd.Correlation = append(cd.Correlation, row)
+	}
+	matrix, err := cd.Matrix()
+	if err != nil {
+		return err
+	}
+	*m = *matrix
+	return nil
+}
@@ -0,0 +1,132 @@
+package alphavantage
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestCorrelationDataMatrix(t *testing.T) {
+	var buf = `{
+  "index": ["STOCK1", "STOCK2", "STOCK3"],
+  "correlation": [
+    [1],
+    [0.1223760538, 1],
+    [-0.5, 0.25, 1]
+  ]
+}`
+	var data CorrelationData
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(buf), &data))
+	matrix, err := data.Matrix()
+	assert.NoError(t.Fatalf, err)
+
+	assert.EqualInt(t, 3, matrix.Len())
+	value, ok := matrix.Get("STOCK1", "STOCK2")
+	assert.True(t, ok)
+	assert.EqualFloat64(t, 0.1223760538, value)
+	value, ok = matrix.Get("STOCK2", "STOCK1")
+	assert.True(t, ok)
+	assert.EqualFloat64(t, 0.1223760538, value)
+	assert.EqualFloat64(t, -0.5, matrix.Values[0][2])
+	_, ok = matrix.Get("STOCK1", "STOCK4")
+	assert.False(t, ok)
+
+	pairs := matrix.Pairs()
+	assert.EqualInt(t, 3, len(pairs))
+	assert.EqualStrings(t, "STOCK1", pairs[0].A)
+	assert.EqualStrings(t, "STOCK3", pairs[0].B)
+	assert.EqualFloat64(t, -0.5, pairs[0].Value)
+	assert.EqualFloat64(t, 0.1223760538, pairs[2].Value)
+
+	var csvBuf bytes.Buffer
+	assert.NoError(t, matrix.WriteCSV(&csvBuf))
+	assert.EqualStrings(t, ",STOCK1,STOCK2,STOCK3\n"+
+		"STOCK1,1,0.1223760538,-0.5\n"+
+		"STOCK2,0.1223760538,1,0.25\n"+
+		"STOCK3,-0.5,0.25,1\n", csvBuf.String())
+
+	jsonBuf, err := json.Marshal(matrix)
+	assert.NoError(t, err)
+	var decoded map[string]map[string]float64
+	assert.NoError(t, json.Unmarshal(jsonBuf, &decoded))
+	assert.EqualFloat64(t, 0.25, decoded["STOCK3"]["STOCK2"])
+}
+
+func TestCorrelationDataCovariance(t *testing.T) {
+	var buf = `{
+  "index": ["STOCK1", "STOCK2"],
+  "covariance": [
+    [0.0009215485],
+    [4.40494e-05, 0.0001405945]
+  ]
+}`
+	var data CorrelationData
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(buf), &data))
+	matrix, err := data.Matrix()
+	assert.NoError(t.Fatalf, err)
+	value, _ := matrix.Get("STOCK2", "STOCK1")
+	assert.EqualFloat64(t, 4.40494e-05, value)
+}
+
+func TestCorrelationDataValidate(t *testing.T) {
+	data := CorrelationData{Index: []string{"STOCK1", "STOCK2"}, Correlation: [][]float64{{1}}}
+	assert.ErrorIncludesMessage(t, "matrix has 1 rows for 2 symbols", data.Validate())
+
+	data.Correlation = [][]float64{{1}, {0.5, 1, 2}}
+	assert.ErrorIncludesMessage(t, "row 1 (STOCK2) has 3 values, expected 2", data.Validate())
+
+	data.Index = []string{"STOCK1", "STOCK1"}
+	data.Correlation = [][]float64{{1}, {0.5, 1}}
+	assert.ErrorIncludesMessage(t, "duplicate symbol in index: STOCK1", data.Validate())
+
+	// full square matrices are accepted as well
+	data.Index = []string{"STOCK1", "STOCK2"}
+	data.Correlation = [][]float64{{1, 0.5}, {0.5, 1}}
+	assert.NoError(t, data.Validate())
+
+	data.Correlation = [][]float64{{1, 0.7}, {0.5, 1}}
+	assert.ErrorIncludesMessage(t, "matrix isn't symmetric: STOCK1/STOCK2 is 0.7, STOCK2/STOCK1 is 0.5", data.Validate())
+	_, err := data.Matrix()
+	assert.ErrorIncludesMessage(t, "matrix isn't symmetric", err)
+}
+
+func TestMatrixLiteral(t *testing.T) {
+	matrix := &Matrix{
+		Index:  []string{"STOCK1", "STOCK2"},
+		Values: [][]float64{{1, 0.5}, {0.5, 1}},
+	}
+	value, ok := matrix.Get("STOCK2", "STOCK1")
+	assert.True(t, ok)
+	assert.EqualFloat64(t, 0.5, value)
+	_, ok = matrix.Get("STOCK1", "STOCK3")
+	assert.False(t, ok)
+}
+
+func TestMatrixJSONRoundTrip(t *testing.T) {
+	data := CorrelationData{
+		Index:       []string{"STOCK2", "STOCK1"},
+		Correlation: [][]float64{{1}, {-0.25, 1}},
+	}
+	matrix, err := data.Matrix()
+	assert.NoError(t.Fatalf, err)
+	buf, err := json.Marshal(matrix)
+	assert.NoError(t.Fatalf, err)
+
+	var decoded Matrix
+	assert.NoError(t.Fatalf, json.Unmarshal(buf, &decoded))
+	assert.EqualStrings(t, "STOCK1,STOCK2", strings.Join(decoded.Index, ","))
+	value, ok := decoded.Get("STOCK1", "STOCK2")
+	assert.True(t, ok)
+	assert.EqualFloat64(t, -0.25, value)
+	value, ok = decoded.Get("STOCK2", "STOCK2")
+	assert.True(t, ok)
+	assert.EqualFloat64(t, 1, value)
+
+	err = json.Unmarshal([]byte(`{"A": {"A": 1, "B": 0.5}, "B": {"B": 1}}`), &decoded)
+	assert.ErrorIncludesMessage(t, "matrix has no value for B/A", err)
+	err = json.Unmarshal([]byte(`{"A": {"A": 1, "B": 0.5}, "B": {"A": 0.4, "B": 1}}`), &decoded)
+	assert.ErrorIncludesMessage(t, "matrix isn't symmetric", err)
+}
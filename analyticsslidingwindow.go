@@ -0,0 +1,171 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// analyticsMinWindowSize is the smallest WINDOW_SIZE accepted by the API.
+const analyticsMinWindowSize = 10
+
+// AnalyticsSlidingWindow represents the Metadata and the Payload of
+// the sliding window analytics.
+type AnalyticsSlidingWindow struct {
+	MetaData SlidingWindowMetaData                `json:"meta_data"`
+	Payload  map[string]SlidingWindowCalculations `json:"payload"`
+}
+
+// SlidingWindowMetaData represents the metadata in the sliding window API response.
+type SlidingWindowMetaData struct {
+	Symbols    string `json:"symbols"`
+	WindowSize int    `json:"window_size"`
+	MinDt      string `json:"min_dt"`
+	MaxDt      string `json:"max_dt"`
+	OHLC       string `json:"ohlc"`
+	Interval   string `json:"interval"`
+}
+
+// DatedValue represents a single value of a DatedSeries.
+type DatedValue struct {
+	Date  string
+	Value float64
+}
+
+// DatedSeries is a date ordered (oldest first) series of values.
+type DatedSeries []DatedValue
+
+// SlidingWindowCalculations maps a calculation (e.g. "MEAN" or
+// "STDDEV(ANNUALIZED=TRUE)") to its rolling series, keyed by symbol or,
+// for CORRELATION and COVARIANCE, by symbol pair ("SYMBOL1-SYMBOL2").
+type SlidingWindowCalculations map[string]map[string]DatedSeries
+
+// UnmarshalJSON custom unmarshaller to turn the date maps into ordered
+// series.  The API wraps each calculation into a "RUNNING_<NAME>"
+// object which is flattened.
+func (swc *SlidingWindowCalculations) UnmarshalJSON(data []byte) error {
+	var raw map[string]map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	calculations := make(SlidingWindowCalculations)
+	for calculation, running := range raw {
+		series := make(map[string]DatedSeries)
+		for _, byKey := range running {
+			for key, values := range byKey {
+				var byDate map[string]float64
+				if err := json.Unmarshal(values, &byDate); err != nil {
+					return fmt.Errorf("unexpected %s values for %s: %w", calculation, key, err)
+				}
+				series[key] = newDatedSeries(byDate)
+			}
+		}
+		calculations[calculation] = series
+	}
+	*swc = calculations
+	return nil
+}
+
+func newDatedSeries(byDate map[string]float64) DatedSeries {
+	series := make(DatedSeries, 0, len(byDate))
+	for date, value := range byDate {
+		series = append(series, DatedValue{Date: date, Value: value})
+	}
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Date < series[j].Date
+	})
+	return series
+}
+
+// Dates returns the dates of the series, oldest first.
+func (ds DatedSeries) Dates() []string {
+	dates := make([]string, len(ds))
+	for i, dv := range ds {
+		dates[i] = dv.Date
+	}
+	return dates
+}
+
+// Values returns the values of the series, oldest first.
+func (ds DatedSeries) Values() []float64 {
+	values := make([]float64, len(ds))
+	for i, dv := range ds {
+		values[i] = dv.Value
+	}
+	return values
+}
+
+// Latest returns the most recent value or nil if the series is empty.
+func (ds DatedSeries) Latest() *DatedValue {
+	if len(ds) == 0 {
+		return nil
+	}
+	return &ds[len(ds)-1]
+}
+
+func toAnalyticsSlidingWindow(buf []byte) (*AnalyticsSlidingWindow, error) {
+	analytics := &AnalyticsSlidingWindow{}
+	if err := json.Unmarshal(buf, analytics); err != nil {
+		return nil, err
+	}
+	return analytics, nil
+}
+
+// AnalyticsSlidingWindow fetches rolling analytics over a moving window
+// of windowSize data points for the specified symbols and calculations
+// (e.g. MEAN, VARIANCE, STDDEV or CORRELATION).  It takes the same
+// range arguments as Analytics2.
+func (c *Client) AnalyticsSlidingWindow(symbols []string,
+	calculations []string,
+	windowSize int,
+	rangeFactor int,
+	rangeUnit AnalyticsRangeUnit,
+	ohlc AnalyticsOhlc,
+	interval AnalyticsInterval) (*AnalyticsSlidingWindow, error) {
+	const functionName = "ANALYTICS_SLIDING_WINDOW"
+	if windowSize < analyticsMinWindowSize {
+		return nil, fmt.Errorf("window size must be at least %d, got %d", analyticsMinWindowSize, windowSize)
+	}
+	_symbols := strings.Join(symbols, ",")
+	var _range string
+	if rangeUnit == AnalyticsRangeUnitFull {
+		_range = string(rangeUnit)
+	} else {
+		_range = strconv.Itoa(rangeFactor) + string(rangeUnit)
+	}
+	_ohlc := string(ohlc)
+	_calculations := strings.Join(calculations, ",")
+	url := fmt.Sprintf("%s/query?function=%s&SYMBOLS=%s&CALCULATIONS=%s&RANGE=%s&OHLC=%s&INTERVAL=%s&WINDOW_SIZE=%d&apikey=%s",
+		baseURL, functionName, _symbols, _calculations, _range, _ohlc, interval, windowSize, c.apiKey)
+
+	body, err := c.makeHTTPRequest(url)
+	if err != nil {
+		return nil, err
+	}
+	analytics, err := toAnalyticsSlidingWindow(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := c.checkResponse(functionName, body, analytics); err != nil {
+		return nil, err
+	}
+	return analytics, nil
+}
+
+// Series returns the rolling series of the calculation for the given
+// symbol (or symbol pair) from the returns calculations, or nil.
+func (a *AnalyticsSlidingWindow) Series(calculation string, key string) DatedSeries {
+	return a.Payload["RETURNS_CALCULATIONS"][calculation][key]
+}
+
+// Correlation returns the rolling correlation between the two symbols,
+// whichever order the API used for the pair, or nil.
+func (a *AnalyticsSlidingWindow) Correlation(symbol1 string, symbol2 string) DatedSeries {
+	correlation := a.Payload["RETURNS_CALCULATIONS"][string(AnalyticsCalculationCorrelation)]
+	if series, exists := correlation[symbol1+"-"+symbol2]; exists {
+		return series
+	}
+	return correlation[symbol2+"-"+symbol1]
+}
@@ -0,0 +1,79 @@
+package alphavantage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToAnalyticsSlidingWindow(t *testing.T) {
+	var buf = `{
+  "meta_data": {
+    "symbols": "STOCK1,STOCK2",
+    "window_size": 20,
+    "min_dt": "2023-07-03",
+    "max_dt": "2023-08-31",
+    "ohlc": "Close",
+    "interval": "DAILY"
+  },
+  "payload": {
+    "RETURNS_CALCULATIONS": {
+      "MEAN": {
+        "RUNNING_MEAN": {
+          "STOCK1": {
+            "2023-08-31": 0.0011,
+            "2023-08-29": 0.0009,
+            "2023-08-30": 0.0010
+          },
+          "STOCK2": {
+            "2023-08-29": -0.0004,
+            "2023-08-30": -0.0002,
+            "2023-08-31": 0.0001
+          }
+        }
+      },
+      "STDDEV(ANNUALIZED=TRUE)": {
+        "RUNNING_STDDEV(ANNUALIZED=TRUE)": {
+          "STOCK1": {
+            "2023-08-30": 0.2105,
+            "2023-08-31": 0.2150
+          }
+        }
+      },
+      "CORRELATION": {
+        "RUNNING_CORRELATION": {
+          "STOCK1-STOCK2": {
+            "2023-08-30": 0.4512,
+            "2023-08-31": 0.4620
+          }
+        }
+      }
+    }
+  }
+}`
+
+	analytics, err := toAnalyticsSlidingWindow([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "ANALYTICS_SLIDING_WINDOW", buf, analytics)
+	assert.EqualStrings(t, "STOCK1,STOCK2", analytics.MetaData.Symbols)
+	assert.EqualInt(t, 20, analytics.MetaData.WindowSize)
+
+	mean := analytics.Series("MEAN", "STOCK1")
+	assert.EqualInt(t, 3, len(mean))
+	dates := mean.Dates()
+	assert.EqualStrings(t, "2023-08-29", dates[0])
+	assert.EqualStrings(t, "2023-08-31", dates[2])
+	values := mean.Values()
+	assert.EqualFloat64(t, 0.0009, values[0])
+	assert.EqualFloat64(t, 0.0011, mean.Latest().Value)
+
+	stdDev := analytics.Series("STDDEV(ANNUALIZED=TRUE)", "STOCK1")
+	assert.EqualInt(t, 2, len(stdDev))
+	assert.EqualFloat64(t, 0.2150, stdDev.Latest().Value)
+
+	correlation := analytics.Correlation("STOCK2", "STOCK1")
+	assert.EqualInt(t, 2, len(correlation))
+	assert.EqualFloat64(t, 0.4620, correlation.Latest().Value)
+
+	assert.True(t, analytics.Series("VARIANCE", "STOCK1").Latest() == nil)
+}
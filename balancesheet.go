@@ -7,14 +7,14 @@ import (
 
 // BalanceSheet represents the balance sheet data for a company.
 type BalanceSheet struct {
-	Symbol           string              `json:"symbol"`
+	Symbol           string              `json:"symbol" validate:"required"`
 	AnnualReports    []BsAnnualReport    `json:"annualReports"`
 	QuarterlyReports []BsQuarterlyReport `json:"quarterlyReports"`
 }
 
 // BsAnnualReport represents an annual report in the balance sheet.
 type BsAnnualReport struct {
-	FiscalDateEnding                       string `json:"fiscalDateEnding"`
+	FiscalDateEnding                       string `json:"fiscalDateEnding" validate:"required"`
 	ReportedCurrency                       string `json:"reportedCurrency"`
 	TotalAssets                            AVInt  `json:"totalAssets"`
 	TotalCurrentAssets                     AVInt  `json:"totalCurrentAssets"`
@@ -56,7 +56,7 @@ type BsAnnualReport struct {
 
 // BsQuarterlyReport represents a quarterly report in the balance sheet.
 type BsQuarterlyReport struct {
-	FiscalDateEnding                       string `json:"fiscalDateEnding"`
+	FiscalDateEnding                       string `json:"fiscalDateEnding" validate:"required"`
 	ReportedCurrency                       string `json:"reportedCurrency"`
 	TotalAssets                            AVInt  `json:"totalAssets"`
 	TotalCurrentAssets                     AVInt  `json:"totalCurrentAssets"`
@@ -113,5 +113,12 @@ func (c *Client) BalanceSheet(symbol string) (*BalanceSheet, error) {
 		return nil, err
 	}
 
-	return toBalanceSheet(body)
+	balanceSheet, err := toBalanceSheet(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkResponse(function, body, balanceSheet); err != nil {
+		return nil, err
+	}
+	return balanceSheet, nil
 }
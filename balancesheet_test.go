@@ -98,6 +98,7 @@ func TestToBalanceSheet(t *testing.T) {
 `
 	balanceSheet, err := toBalanceSheet([]byte(buf))
 	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "BALANCE_SHEET", buf, balanceSheet)
 
 	assert.EqualStrings(t, "STOCK1", balanceSheet.Symbol)
 
@@ -7,14 +7,14 @@ import (
 
 // CashFlow represents the cash flow data for a company.
 type CashFlow struct {
-	Symbol           string              `json:"symbol"`
+	Symbol           string              `json:"symbol" validate:"required"`
 	AnnualReports    []CfAnnualReport    `json:"annualReports"`
 	QuarterlyReports []CfQuarterlyReport `json:"quarterlyReports"`
 }
 
 // CfAnnualReport represents an annual report in the cash flow.
 type CfAnnualReport struct {
-	FiscalDateEnding                                          string `json:"fiscalDateEnding"`
+	FiscalDateEnding                                          string `json:"fiscalDateEnding" validate:"required"`
 	ReportedCurrency                                          string `json:"reportedCurrency"`
 	OperatingCashflow                                         AVInt  `json:"operatingCashflow"`
 	PaymentsForOperatingActivities                            AVInt  `json:"paymentsForOperatingActivities"`
@@ -47,7 +47,7 @@ type CfAnnualReport struct {
 
 // CfQuarterlyReport represents a quarterly report in the cash flow.
 type CfQuarterlyReport struct {
-	FiscalDateEnding                                          string `json:"fiscalDateEnding"`
+	FiscalDateEnding                                          string `json:"fiscalDateEnding" validate:"required"`
 	ReportedCurrency                                          string `json:"reportedCurrency"`
 	OperatingCashflow                                         AVInt  `json:"operatingCashflow"`
 	PaymentsForOperatingActivities                            AVInt  `json:"paymentsForOperatingActivities"`
@@ -95,5 +95,12 @@ func (c *Client) CashFlow(symbol string) (*CashFlow, error) {
 		return nil, err
 	}
 
-	return toCashFlow(body)
+	cashFlow, err := toCashFlow(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkResponse(function, body, cashFlow); err != nil {
+		return nil, err
+	}
+	return cashFlow, nil
 }
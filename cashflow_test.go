@@ -80,6 +80,7 @@ func TestToCashFlow(t *testing.T) {
 `
 	cashFlow, err := toCashFlow([]byte(buf))
 	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "CASH_FLOW", buf, cashFlow)
 
 	assert.EqualStrings(t, "STOCK1", cashFlow.Symbol)
 
@@ -0,0 +1,62 @@
+package columnar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/sklinkert/alphavantage"
+)
+
+// BarsSchema returns the schema of BarsRecord.  The timestamp is in
+// the time zone of the series, date is the day of the timestamp.
+func BarsSchema(timeZone string) *arrow.Schema {
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	return arrow.NewSchema([]arrow.Field{
+		{Name: "symbol", Type: arrow.BinaryTypes.String},
+		{Name: "timestamp", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: timeZone}},
+		{Name: "date", Type: arrow.FixedWidthTypes.Date32},
+		{Name: "open", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "high", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "low", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "close", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "volume", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+}
+
+// BarsRecord converts the bars of the price series, e.g. of
+// TimeSeries.PriceSeries(), into a record.
+func BarsRecord(mem memory.Allocator, series *alphavantage.PriceSeries) (arrow.Record, error) {
+	location := time.UTC
+	if series.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(series.TimeZone); err != nil {
+			return nil, fmt.Errorf("failed to load time zone: %w", err)
+		}
+	}
+	b := array.NewRecordBuilder(mem, BarsSchema(location.String()))
+	defer b.Release()
+	for _, bar := range series.Bars {
+		layout := alphavantage.DateFormat
+		if len(bar.Date) > len(alphavantage.DateFormat) {
+			layout = intradayTimeLayout
+		}
+		ts, err := time.ParseInLocation(layout, bar.Date, location)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", series.Symbol, err)
+		}
+		b.Field(0).(*array.StringBuilder).Append(series.Symbol)
+		b.Field(1).(*array.TimestampBuilder).Append(arrow.Timestamp(ts.UnixMilli()))
+		b.Field(2).(*array.Date32Builder).Append(arrow.Date32FromTime(ts))
+		b.Field(3).(*array.Float64Builder).Append(bar.Open)
+		b.Field(4).(*array.Float64Builder).Append(bar.High)
+		b.Field(5).(*array.Float64Builder).Append(bar.Low)
+		b.Field(6).(*array.Float64Builder).Append(bar.Close)
+		b.Field(7).(*array.Int64Builder).Append(int64(bar.Volume))
+	}
+	return b.NewRecord(), nil
+}
@@ -0,0 +1,62 @@
+package columnar
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/sklinkert/alphavantage"
+)
+
+func newCheckedAllocator(t *testing.T) memory.Allocator {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	t.Cleanup(func() { mem.AssertSize(t, 0) })
+	return mem
+}
+
+func TestBarsRecord(t *testing.T) {
+	mem := newCheckedAllocator(t)
+	series := alphavantage.NewPriceSeries("IBM", alphavantage.Interval1Min, []alphavantage.Bar{
+		{Date: "2024-01-02 16:00:00", Open: 161.4, High: 161.6, Low: 161.3, Close: 161.5, Volume: 120345},
+		{Date: "2024-01-02 15:59:00", Open: 161.2, High: 161.5, Low: 161.1, Close: 161.4, Volume: 80123},
+	})
+	series.TimeZone = "US/Eastern"
+
+	rec, err := BarsRecord(mem, series)
+	assert.NoError(t.Fatalf, err)
+	defer rec.Release()
+
+	assert.EqualInt(t, 2, int(rec.NumRows()))
+	assert.EqualStrings(t, "US/Eastern", rec.Schema().Field(1).Type.(*arrow.TimestampType).TimeZone)
+	assert.EqualStrings(t, "IBM", rec.Column(0).(*array.String).Value(0))
+	// 2024-01-02 15:59:00 EST is 20:59:00 UTC
+	timestamp := rec.Column(1).(*array.Timestamp).Value(0).ToTime(arrow.Millisecond)
+	assert.EqualStrings(t, "2024-01-02T20:59:00Z", timestamp.Format("2006-01-02T15:04:05Z07:00"))
+	assert.EqualStrings(t, "2024-01-02", rec.Column(2).(*array.Date32).Value(1).FormattedString())
+	assert.EqualFloat64(t, 161.4, rec.Column(6).(*array.Float64).Value(0))
+	assert.EqualInt(t, 120345, int(rec.Column(7).(*array.Int64).Value(1)))
+}
+
+func TestBarsRecordDaily(t *testing.T) {
+	mem := newCheckedAllocator(t)
+	series := alphavantage.NewPriceSeries("IBM", alphavantage.IntervalDaily, []alphavantage.Bar{
+		{Date: "2024-01-02", Close: 161.5},
+	})
+
+	rec, err := BarsRecord(mem, series)
+	assert.NoError(t.Fatalf, err)
+	defer rec.Release()
+
+	assert.EqualStrings(t, "UTC", rec.Schema().Field(1).Type.(*arrow.TimestampType).TimeZone)
+	assert.EqualStrings(t, "2024-01-02", rec.Column(2).(*array.Date32).Value(0).FormattedString())
+}
+
+func TestBarsRecordInvalidDate(t *testing.T) {
+	mem := newCheckedAllocator(t)
+	series := alphavantage.NewPriceSeries("IBM", alphavantage.IntervalDaily, []alphavantage.Bar{{Date: "02.01.2024"}})
+
+	_, err := BarsRecord(mem, series)
+	assert.ErrorIncludesMessage(t, "IBM: parsing time", err)
+}
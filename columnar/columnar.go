@@ -0,0 +1,347 @@
+// Package columnar converts datasets fetched with the alphavantage
+// package into Arrow records and writes them as Parquet files, e.g. to
+// read them with pandas, pyarrow or Spark.
+//
+// The records have typed schemas: dates are date32, timestamps are
+// timestamp[ms] and numbers are float64 or int64.  Fields which the API
+// reports as "None" are null, see AVFloat64.IsNull.  Records are
+// allocated with the given allocator and have to be released by the
+// caller.
+package columnar
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/sklinkert/alphavantage"
+)
+
+const (
+	newsTimeLayout     = "20060102T150405"
+	intradayTimeLayout = "2006-01-02 15:04:05"
+)
+
+// dateColumns are string fields holding a date
+var dateColumns = map[string]bool{
+	"fiscalDateEnding": true,
+	"date":             true,
+	"expiration":       true,
+}
+
+// timestampColumns are string fields holding a timestamp, with their layout
+var timestampColumns = map[string]string{
+	"time_published": newsTimeLayout,
+}
+
+type columnKind int
+
+const (
+	kindString columnKind = iota
+	kindDate
+	kindTimestamp
+	kindFloat
+	kindInt
+	kindUint
+	kindAVFloat
+	kindAVPercent
+	kindAVInt
+	kindAVDate
+	kindStringList
+	kindStructList
+)
+
+var (
+	avFloat64Type = reflect.TypeOf(alphavantage.AVFloat64{})
+	avPercentType = reflect.TypeOf(alphavantage.AVPercent{})
+	avIntType     = reflect.TypeOf(alphavantage.AVInt{})
+	avDateType    = reflect.TypeOf(alphavantage.AVDate{})
+)
+
+// column is a JSON field of a struct and its Arrow type
+type column struct {
+	name   string
+	kind   columnKind
+	layout string
+	// elem are the columns of the struct elements of a kindStructList
+	elem []column
+}
+
+func (c column) field() arrow.Field {
+	f := arrow.Field{Name: c.name, Nullable: true}
+	switch c.kind {
+	case kindString:
+		f.Type = arrow.BinaryTypes.String
+	case kindDate, kindAVDate:
+		f.Type = arrow.FixedWidthTypes.Date32
+	case kindTimestamp:
+		f.Type = &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}
+	case kindFloat:
+		f.Type, f.Nullable = arrow.PrimitiveTypes.Float64, false
+	case kindInt:
+		f.Type, f.Nullable = arrow.PrimitiveTypes.Int64, false
+	case kindUint:
+		f.Type, f.Nullable = arrow.PrimitiveTypes.Uint64, false
+	case kindAVFloat, kindAVPercent:
+		f.Type = arrow.PrimitiveTypes.Float64
+	case kindAVInt:
+		f.Type = arrow.PrimitiveTypes.Int64
+	case kindStringList:
+		f.Type = arrow.ListOf(arrow.BinaryTypes.String)
+	case kindStructList:
+		fields := make([]arrow.Field, len(c.elem))
+		for i, elem := range c.elem {
+			fields[i] = elem.field()
+		}
+		f.Type = arrow.ListOf(arrow.StructOf(fields...))
+	}
+	return f
+}
+
+var columnsCache sync.Map
+
+// columnsOf returns the columns of the JSON fields of the struct type.
+// Fields of unsupported types are skipped.
+func columnsOf(t reflect.Type) []column {
+	if cached, ok := columnsCache.Load(t); ok {
+		return cached.([]column)
+	}
+	var columns []column
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		col := column{name: name}
+		switch field.Type {
+		case avFloat64Type:
+			col.kind = kindAVFloat
+		case avPercentType:
+			col.kind = kindAVPercent
+		case avIntType:
+			col.kind = kindAVInt
+		case avDateType:
+			col.kind = kindAVDate
+		default:
+			switch field.Type.Kind() {
+			case reflect.String:
+				col.kind = kindString
+				if dateColumns[name] {
+					col.kind = kindDate
+				} else if layout, ok := timestampColumns[name]; ok {
+					col.kind, col.layout = kindTimestamp, layout
+				}
+			case reflect.Float32, reflect.Float64:
+				col.kind = kindFloat
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				col.kind = kindInt
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				col.kind = kindUint
+			case reflect.Slice:
+				switch elem := field.Type.Elem(); elem.Kind() {
+				case reflect.String:
+					col.kind = kindStringList
+				case reflect.Struct:
+					col.kind, col.elem = kindStructList, columnsOf(elem)
+				default:
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		columns = append(columns, col)
+	}
+	columnsCache.Store(t, columns)
+	return columns
+}
+
+// mergeColumns returns the columns of all types, e.g. of the annual and
+// quarterly reports of a statement, in the order they first appear.
+func mergeColumns(types ...reflect.Type) []column {
+	var columns []column
+	seen := make(map[string]bool)
+	for _, t := range types {
+		for _, col := range columnsOf(t) {
+			if seen[col.name] {
+				continue
+			}
+			seen[col.name] = true
+			columns = append(columns, col)
+		}
+	}
+	return columns
+}
+
+// recordBuilder builds records of structs with optional leading columns
+// which aren't fields of the structs, e.g. the symbol of a report.
+type recordBuilder struct {
+	builder *array.RecordBuilder
+	columns []column
+	leading int
+}
+
+func newRecordBuilder(mem memory.Allocator, leading []arrow.Field, columns []column) *recordBuilder {
+	fields := append([]arrow.Field{}, leading...)
+	for _, col := range columns {
+		fields = append(fields, col.field())
+	}
+	return &recordBuilder{
+		builder: array.NewRecordBuilder(mem, arrow.NewSchema(fields, nil)),
+		columns: columns,
+		leading: len(leading),
+	}
+}
+
+// appendStruct appends the fields of the struct value v.  Columns the
+// struct doesn't have are null.
+func (rb *recordBuilder) appendStruct(v reflect.Value) error {
+	for i, col := range rb.columns {
+		if err := appendField(rb.builder.Field(rb.leading+i), col, fieldByName(v, col.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rb *recordBuilder) appendString(i int, value string) {
+	appendString(rb.builder.Field(i).(*array.StringBuilder), value)
+}
+
+func (rb *recordBuilder) newRecord() arrow.Record {
+	defer rb.builder.Release()
+	return rb.builder.NewRecord()
+}
+
+var fieldIndexCache sync.Map
+
+// fieldByName returns the field with the JSON name, or an invalid value
+// if the struct doesn't have it.
+func fieldByName(v reflect.Value, name string) reflect.Value {
+	t := v.Type()
+	var indexes map[string]int
+	if cached, ok := fieldIndexCache.Load(t); ok {
+		indexes = cached.(map[string]int)
+	} else {
+		indexes = make(map[string]int)
+		for i := 0; i < t.NumField(); i++ {
+			jsonName := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			if jsonName == "" {
+				jsonName = t.Field(i).Name
+			}
+			indexes[jsonName] = i
+		}
+		fieldIndexCache.Store(t, indexes)
+	}
+	index, ok := indexes[name]
+	if !ok {
+		return reflect.Value{}
+	}
+	return v.Field(index)
+}
+
+func appendField(b array.Builder, col column, v reflect.Value) error {
+	if !v.IsValid() {
+		b.AppendNull()
+		return nil
+	}
+	switch col.kind {
+	case kindString:
+		appendString(b.(*array.StringBuilder), v.String())
+	case kindDate:
+		return appendDate(b.(*array.Date32Builder), col.name, v.String())
+	case kindTimestamp:
+		return appendTimestamp(b.(*array.TimestampBuilder), col.name, v.String(), col.layout, time.UTC)
+	case kindFloat:
+		b.(*array.Float64Builder).Append(v.Float())
+	case kindInt:
+		b.(*array.Int64Builder).Append(v.Int())
+	case kindUint:
+		b.(*array.Uint64Builder).Append(v.Uint())
+	case kindAVFloat, kindAVPercent, kindAVInt:
+		number := v.Interface().(alphavantage.NullableNumber)
+		switch {
+		case number.IsNull():
+			b.AppendNull()
+		case col.kind == kindAVInt:
+			b.(*array.Int64Builder).Append(int64(v.Interface().(alphavantage.AVInt).Value))
+		default:
+			b.(*array.Float64Builder).Append(number.Float64())
+		}
+	case kindAVDate:
+		value := v.Interface().(alphavantage.AVDate).Value
+		if value.IsZero() {
+			b.AppendNull()
+		} else {
+			b.(*array.Date32Builder).Append(arrow.Date32FromTime(value))
+		}
+	case kindStringList:
+		lb := b.(*array.ListBuilder)
+		lb.Append(true)
+		vb := lb.ValueBuilder().(*array.StringBuilder)
+		for i := 0; i < v.Len(); i++ {
+			vb.Append(v.Index(i).String())
+		}
+	case kindStructList:
+		lb := b.(*array.ListBuilder)
+		lb.Append(true)
+		sb := lb.ValueBuilder().(*array.StructBuilder)
+		for i := 0; i < v.Len(); i++ {
+			sb.Append(true)
+			for j, elem := range col.elem {
+				if err := appendField(sb.FieldBuilder(j), elem, fieldByName(v.Index(i), elem.name)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isNone reports whether the string value is missing
+func isNone(value string) bool {
+	return value == "" || value == "None" || value == "-"
+}
+
+func appendString(b *array.StringBuilder, value string) {
+	if value == "None" {
+		b.AppendNull()
+		return
+	}
+	b.Append(value)
+}
+
+func appendDate(b *array.Date32Builder, name, value string) error {
+	if isNone(value) {
+		b.AppendNull()
+		return nil
+	}
+	date, err := time.Parse(alphavantage.DateFormat, value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	b.Append(arrow.Date32FromTime(date))
+	return nil
+}
+
+func appendTimestamp(b *array.TimestampBuilder, name, value, layout string, location *time.Location) error {
+	if isNone(value) {
+		b.AppendNull()
+		return nil
+	}
+	ts, err := time.ParseInLocation(layout, value, location)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	b.Append(arrow.Timestamp(ts.UnixMilli()))
+	return nil
+}
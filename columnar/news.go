@@ -0,0 +1,34 @@
+package columnar
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/sklinkert/alphavantage"
+)
+
+// NewsRecord converts the feed items into a record with a column per
+// JSON field.  Authors, topics and ticker sentiments are list columns,
+// the leading date column is the day of time_published, e.g. to
+// partition by.
+func NewsRecord(mem memory.Allocator, feed []alphavantage.FeedItem) (arrow.Record, error) {
+	leading := []arrow.Field{{Name: "date", Type: arrow.FixedWidthTypes.Date32, Nullable: true}}
+	rb := newRecordBuilder(mem, leading, columnsOf(reflect.TypeOf(alphavantage.FeedItem{})))
+	for _, item := range feed {
+		dates := rb.builder.Field(0).(*array.Date32Builder)
+		if published, err := time.Parse(newsTimeLayout, item.TimePublished); err == nil {
+			dates.Append(arrow.Date32FromTime(published))
+		} else {
+			// time_published is validated by appendStruct
+			dates.AppendNull()
+		}
+		if err := rb.appendStruct(reflect.ValueOf(item)); err != nil {
+			rb.builder.Release()
+			return nil, err
+		}
+	}
+	return rb.newRecord(), nil
+}
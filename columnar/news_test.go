@@ -0,0 +1,59 @@
+package columnar
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/sklinkert/alphavantage"
+)
+
+func TestNewsRecord(t *testing.T) {
+	mem := newCheckedAllocator(t)
+	feed := []alphavantage.FeedItem{
+		{
+			Title:                 "IBM beats estimates",
+			URL:                   "https://example.com/ibm",
+			TimePublished:         "20240125T101500",
+			Authors:               []string{"Jane Doe", "John Roe"},
+			Topics:                []alphavantage.Topic{{Topic: "Earnings", RelevanceScore: 0.999}},
+			OverallSentimentScore: alphavantage.AVFloat64{Value: 0.25},
+			TickerSentiment: []alphavantage.TickerSentiment{
+				{Ticker: "IBM", RelevanceScore: 0.9, TickerSentimentScore: 0.3, TickerSentimentLabel: "Somewhat-Bullish"},
+			},
+		},
+		{Title: "Markets wrap", URL: "https://example.com/wrap", TimePublished: "20240124T200000", BannerImage: "None"},
+	}
+
+	rec, err := NewsRecord(mem, feed)
+	assert.NoError(t.Fatalf, err)
+	defer rec.Release()
+
+	schema := rec.Schema()
+	column := func(name string) arrow.Array {
+		return rec.Column(schema.FieldIndices(name)[0])
+	}
+	assert.EqualStrings(t, "date", schema.Field(0).Name)
+	assert.EqualStrings(t, "2024-01-25", column("date").(*array.Date32).Value(0).FormattedString())
+	published := column("time_published").(*array.Timestamp).Value(0).ToTime(arrow.Millisecond)
+	assert.EqualStrings(t, "2024-01-25 10:15:00", published.Format("2006-01-02 15:04:05"))
+
+	authors := column("authors").(*array.List)
+	start, end := authors.ValueOffsets(0)
+	assert.EqualInt(t, 2, int(end-start))
+	assert.EqualStrings(t, "John Roe", authors.ListValues().(*array.String).Value(1))
+
+	topics := column("topics").(*array.List).ListValues().(*array.Struct)
+	assert.EqualStrings(t, "Earnings", topics.Field(0).(*array.String).Value(0))
+	assert.EqualFloat64(t, 0.999, topics.Field(1).(*array.Float64).Value(0))
+
+	sentiment := column("ticker_sentiment").(*array.List)
+	start, end = sentiment.ValueOffsets(1)
+	assert.EqualInt(t, 0, int(end-start))
+	assert.EqualFloat64(t, 0.3, sentiment.ListValues().(*array.Struct).Field(2).(*array.Float64).Value(0))
+
+	assert.EqualFloat64(t, 0.25, column("overall_sentiment_score").(*array.Float64).Value(0))
+	assert.True(t, column("overall_sentiment_score").IsNull(1))
+	assert.True(t, column("banner_image").IsNull(1))
+}
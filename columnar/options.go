@@ -0,0 +1,22 @@
+package columnar
+
+import (
+	"reflect"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/sklinkert/alphavantage"
+)
+
+// OptionContractsRecord converts the contracts of an option chain, e.g.
+// HistoricalOptionsData.Data, into a record with a column per JSON field.
+func OptionContractsRecord(mem memory.Allocator, contracts []alphavantage.OptionContract) (arrow.Record, error) {
+	rb := newRecordBuilder(mem, nil, columnsOf(reflect.TypeOf(alphavantage.OptionContract{})))
+	for _, contract := range contracts {
+		if err := rb.appendStruct(reflect.ValueOf(contract)); err != nil {
+			rb.builder.Release()
+			return nil, err
+		}
+	}
+	return rb.newRecord(), nil
+}
@@ -0,0 +1,50 @@
+package columnar
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/sklinkert/alphavantage"
+)
+
+func TestOptionContractsRecord(t *testing.T) {
+	mem := newCheckedAllocator(t)
+	contracts := []alphavantage.OptionContract{
+		{
+			ContractID: "IBM240216C00160000", Symbol: "IBM", Expiration: "2024-02-16", Type: "call", Date: "2024-01-02",
+			Strike: alphavantage.AVFloat64{Value: 160}, Bid: alphavantage.AVFloat64{Value: 4.1},
+			OpenInterest: alphavantage.AVInt{Value: 120}, Delta: alphavantage.AVFloat64{Value: 0.55},
+			Last: alphavantage.NewAVFloat64(0),
+		},
+	}
+
+	rec, err := OptionContractsRecord(mem, contracts)
+	assert.NoError(t.Fatalf, err)
+	defer rec.Release()
+
+	schema := rec.Schema()
+	assert.EqualInt(t, 20, len(schema.Fields()))
+	column := func(name string) arrow.Array {
+		return rec.Column(schema.FieldIndices(name)[0])
+	}
+	assert.True(t, arrow.TypeEqual(arrow.FixedWidthTypes.Date32, schema.Field(schema.FieldIndices("expiration")[0]).Type))
+	assert.EqualStrings(t, "IBM240216C00160000", column("contractID").(*array.String).Value(0))
+	assert.EqualStrings(t, "2024-02-16", column("expiration").(*array.Date32).Value(0).FormattedString())
+	assert.EqualFloat64(t, 160, column("strike").(*array.Float64).Value(0))
+	assert.EqualInt(t, 120, int(column("open_interest").(*array.Int64).Value(0)))
+	assert.EqualFloat64(t, 0.55, column("delta").(*array.Float64).Value(0))
+	assert.True(t, column("last").IsValid(0))
+	// "None" values are null
+	assert.True(t, column("ask").IsNull(0))
+	assert.True(t, column("volume").IsNull(0))
+}
+
+func TestOptionContractsRecordInvalidDate(t *testing.T) {
+	mem := newCheckedAllocator(t)
+	contracts := []alphavantage.OptionContract{{ContractID: "IBM240216C00160000", Expiration: "16.02.2024"}}
+
+	_, err := OptionContractsRecord(mem, contracts)
+	assert.ErrorIncludesMessage(t, "expiration: parsing time", err)
+}
@@ -0,0 +1,152 @@
+package columnar
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/compress"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+)
+
+// nullPartition is the partition directory of null values, as used by Hive
+const nullPartition = "__HIVE_DEFAULT_PARTITION__"
+
+func newParquetWriter(schema *arrow.Schema, w io.Writer) (*pqarrow.FileWriter, error) {
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	return pqarrow.NewFileWriter(schema, w, props, pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()))
+}
+
+// WriteParquet writes the record as a snappy compressed Parquet file.
+// w is closed if it is an io.Closer.
+func WriteParquet(w io.Writer, rec arrow.Record) error {
+	fw, err := newParquetWriter(rec.Schema(), w)
+	if err != nil {
+		return err
+	}
+	if err := fw.Write(rec); err != nil {
+		fw.Close()
+		return err
+	}
+	return fw.Close()
+}
+
+// WritePartitioned writes the record into Hive style partitions below
+// dir, e.g. dir/symbol=IBM/date=2024-01-02/name.parquet, which pyarrow
+// and Spark read as partitioned dataset.  The partition columns have
+// to be string or date32 columns, they are removed from the files.
+// Existing files with the same name are replaced.  It returns the
+// paths of the written files.
+func WritePartitioned(dir, name string, rec arrow.Record, partitionBy ...string) ([]string, error) {
+	schema := rec.Schema()
+	isPartition := make(map[int]bool)
+	var partitions []int
+	for _, column := range partitionBy {
+		indices := schema.FieldIndices(column)
+		if len(indices) == 0 {
+			return nil, fmt.Errorf("unknown partition column: %q", column)
+		}
+		switch schema.Field(indices[0]).Type.ID() {
+		case arrow.STRING, arrow.DATE32:
+		default:
+			return nil, fmt.Errorf("partition column %s has unsupported type %s", column, schema.Field(indices[0]).Type)
+		}
+		isPartition[indices[0]] = true
+		partitions = append(partitions, indices[0])
+	}
+	var fields []arrow.Field
+	for i, field := range schema.Fields() {
+		if !isPartition[i] {
+			fields = append(fields, field)
+		}
+	}
+	fileSchema := arrow.NewSchema(fields, nil)
+
+	writers := make(map[string]*pqarrow.FileWriter)
+	var paths []string
+	closeAll := func() error {
+		var firstErr error
+		for _, path := range paths {
+			if err := writers[path].Close(); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("failed to close %s: %w", path, err)
+			}
+		}
+		return firstErr
+	}
+	// consecutive rows of the same partition are written at once
+	for start := 0; start < int(rec.NumRows()); {
+		path := partitionPath(dir, rec, partitions, start)
+		end := start + 1
+		for end < int(rec.NumRows()) && partitionPath(dir, rec, partitions, end) == path {
+			end++
+		}
+		path = filepath.Join(path, name+".parquet")
+		fw, ok := writers[path]
+		if !ok {
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				closeAll()
+				return nil, err
+			}
+			f, err := os.Create(path)
+			if err != nil {
+				closeAll()
+				return nil, err
+			}
+			if fw, err = newParquetWriter(fileSchema, f); err != nil {
+				f.Close()
+				closeAll()
+				return nil, err
+			}
+			writers[path] = fw
+			paths = append(paths, path)
+		}
+		if err := writeColumns(fw, fileSchema, rec, isPartition, start, end); err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		start = end
+	}
+	if err := closeAll(); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// writeColumns writes the rows [start, end) without the partition columns.
+func writeColumns(fw *pqarrow.FileWriter, schema *arrow.Schema, rec arrow.Record, isPartition map[int]bool, start, end int) error {
+	slice := rec.NewSlice(int64(start), int64(end))
+	defer slice.Release()
+	var columns []arrow.Array
+	for i, column := range slice.Columns() {
+		if !isPartition[i] {
+			columns = append(columns, column)
+		}
+	}
+	projected := array.NewRecord(schema, columns, int64(end-start))
+	defer projected.Release()
+	return fw.WriteBuffered(projected)
+}
+
+// partitionPath returns the directory of the row's partition.
+func partitionPath(dir string, rec arrow.Record, partitions []int, row int) string {
+	path := dir
+	for _, index := range partitions {
+		column := rec.Column(index)
+		value := nullPartition
+		if column.IsValid(row) {
+			switch c := column.(type) {
+			case *array.String:
+				value = url.PathEscape(c.Value(row))
+			case *array.Date32:
+				value = c.Value(row).FormattedString()
+			}
+		}
+		path = filepath.Join(path, rec.ColumnName(index)+"="+value)
+	}
+	return path
+}
@@ -0,0 +1,96 @@
+package columnar
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AMekss/assert"
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+	"github.com/sklinkert/alphavantage"
+)
+
+func readParquet(t *testing.T, buf []byte) arrow.Table {
+	t.Helper()
+	table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(buf), parquet.NewReaderProperties(nil),
+		pqarrow.ArrowReadProperties{}, newCheckedAllocator(t))
+	assert.NoError(t.Fatalf, err)
+	t.Cleanup(table.Release)
+	return table
+}
+
+func testOptionContracts() []alphavantage.OptionContract {
+	contract := func(id, symbol, date string, strike float64) alphavantage.OptionContract {
+		return alphavantage.OptionContract{ContractID: id, Symbol: symbol, Expiration: "2024-02-16", Type: "call",
+			Date: date, Strike: alphavantage.AVFloat64{Value: strike}}
+	}
+	return []alphavantage.OptionContract{
+		contract("IBM240216C00160000", "IBM", "2024-01-02", 160),
+		contract("IBM240216C00170000", "IBM", "2024-01-02", 170),
+		contract("MSFT240216C00400000", "MSFT", "2024-01-02", 400),
+		contract("IBM240216C00160000", "IBM", "2024-01-03", 160),
+		contract("IBM240216C00165000", "IBM", "2024-01-02", 165),
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	mem := newCheckedAllocator(t)
+	rec, err := OptionContractsRecord(mem, testOptionContracts())
+	assert.NoError(t.Fatalf, err)
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	assert.NoError(t.Fatalf, WriteParquet(&buf, rec))
+
+	table := readParquet(t, buf.Bytes())
+	assert.EqualInt(t, 5, int(table.NumRows()))
+	for i, field := range rec.Schema().Fields() {
+		assert.EqualStrings(t, field.Name, table.Schema().Field(i).Name)
+		assert.True(t, arrow.TypeEqual(field.Type, table.Schema().Field(i).Type))
+	}
+	strikes := table.Column(table.Schema().FieldIndices("strike")[0]).Data().Chunk(0).(*array.Float64)
+	assert.EqualFloat64(t, 400, strikes.Value(2))
+	bids := table.Column(table.Schema().FieldIndices("bid")[0]).Data().Chunk(0)
+	assert.EqualInt(t, 5, bids.NullN())
+}
+
+func TestWritePartitioned(t *testing.T) {
+	mem := newCheckedAllocator(t)
+	rec, err := OptionContractsRecord(mem, testOptionContracts())
+	assert.NoError(t.Fatalf, err)
+	defer rec.Release()
+	dir := t.TempDir()
+
+	paths, err := WritePartitioned(dir, "part-0", rec, "symbol", "date")
+	assert.NoError(t.Fatalf, err)
+
+	assert.EqualInt(t, 3, len(paths))
+	assert.EqualStrings(t, filepath.Join(dir, "symbol=IBM", "date=2024-01-02", "part-0.parquet"), paths[0])
+	assert.EqualStrings(t, filepath.Join(dir, "symbol=MSFT", "date=2024-01-02", "part-0.parquet"), paths[1])
+	assert.EqualStrings(t, filepath.Join(dir, "symbol=IBM", "date=2024-01-03", "part-0.parquet"), paths[2])
+
+	buf, err := os.ReadFile(paths[0])
+	assert.NoError(t.Fatalf, err)
+	table := readParquet(t, buf)
+	// the rows of the partition are collected and the partition columns removed
+	assert.EqualInt(t, 3, int(table.NumRows()))
+	assert.EqualInt(t, 18, len(table.Schema().Fields()))
+	assert.EqualInt(t, 0, len(table.Schema().FieldIndices("symbol")))
+}
+
+func TestWritePartitionedInvalidColumn(t *testing.T) {
+	mem := newCheckedAllocator(t)
+	rec, err := OptionContractsRecord(mem, testOptionContracts())
+	assert.NoError(t.Fatalf, err)
+	defer rec.Release()
+
+	_, err = WritePartitioned(t.TempDir(), "part-0", rec, "underlying")
+	assert.ErrorIncludesMessage(t, `unknown partition column: "underlying"`, err)
+	_, err = WritePartitioned(t.TempDir(), "part-0", rec, "strike")
+	assert.ErrorIncludesMessage(t, "partition column strike has unsupported type float64", err)
+}
@@ -0,0 +1,64 @@
+package columnar
+
+import (
+	"reflect"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/sklinkert/alphavantage"
+)
+
+const (
+	periodAnnual    = "annual"
+	periodQuarterly = "quarterly"
+)
+
+// statementFields are the leading columns of the statement records
+var statementFields = []arrow.Field{
+	{Name: "symbol", Type: arrow.BinaryTypes.String},
+	{Name: "period", Type: arrow.BinaryTypes.String},
+}
+
+// BalanceSheetRecord converts the annual and quarterly reports into a
+// record with the leading columns symbol and period ("annual" or
+// "quarterly") and a column per JSON field of the reports.
+func BalanceSheetRecord(mem memory.Allocator, balanceSheet *alphavantage.BalanceSheet) (arrow.Record, error) {
+	return statementRecord(mem, balanceSheet.Symbol,
+		reflect.ValueOf(balanceSheet.AnnualReports), reflect.ValueOf(balanceSheet.QuarterlyReports))
+}
+
+// IncomeStatementRecord converts the annual and quarterly reports like
+// BalanceSheetRecord.
+func IncomeStatementRecord(mem memory.Allocator, incomeStatement *alphavantage.IncomeStatement) (arrow.Record, error) {
+	return statementRecord(mem, incomeStatement.Symbol,
+		reflect.ValueOf(incomeStatement.AnnualReports), reflect.ValueOf(incomeStatement.QuarterlyReports))
+}
+
+// CashFlowRecord converts the annual and quarterly reports like
+// BalanceSheetRecord.
+func CashFlowRecord(mem memory.Allocator, cashFlow *alphavantage.CashFlow) (arrow.Record, error) {
+	return statementRecord(mem, cashFlow.Symbol,
+		reflect.ValueOf(cashFlow.AnnualReports), reflect.ValueOf(cashFlow.QuarterlyReports))
+}
+
+func statementRecord(mem memory.Allocator, symbol string, annual, quarterly reflect.Value) (arrow.Record, error) {
+	columns := mergeColumns(annual.Type().Elem(), quarterly.Type().Elem())
+	rb := newRecordBuilder(mem, statementFields, columns)
+	for _, reports := range []struct {
+		period string
+		values reflect.Value
+	}{
+		{periodAnnual, annual},
+		{periodQuarterly, quarterly},
+	} {
+		for i := 0; i < reports.values.Len(); i++ {
+			rb.appendString(0, symbol)
+			rb.appendString(1, reports.period)
+			if err := rb.appendStruct(reports.values.Index(i)); err != nil {
+				rb.builder.Release()
+				return nil, err
+			}
+		}
+	}
+	return rb.newRecord(), nil
+}
@@ -0,0 +1,63 @@
+package columnar
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/sklinkert/alphavantage"
+)
+
+func TestBalanceSheetRecord(t *testing.T) {
+	mem := newCheckedAllocator(t)
+	balanceSheet := &alphavantage.BalanceSheet{
+		Symbol: "IBM",
+		AnnualReports: []alphavantage.BsAnnualReport{
+			{FiscalDateEnding: "2023-12-31", ReportedCurrency: "USD", TotalAssets: alphavantage.AVInt{Value: 135241000000}},
+		},
+		QuarterlyReports: []alphavantage.BsQuarterlyReport{
+			{FiscalDateEnding: "2023-12-31", ReportedCurrency: "USD", TotalAssets: alphavantage.AVInt{Value: 135241000000}},
+			{FiscalDateEnding: "2023-09-30", ReportedCurrency: "USD"},
+		},
+	}
+
+	rec, err := BalanceSheetRecord(mem, balanceSheet)
+	assert.NoError(t.Fatalf, err)
+	defer rec.Release()
+
+	schema := rec.Schema()
+	column := func(name string) arrow.Array {
+		return rec.Column(schema.FieldIndices(name)[0])
+	}
+	assert.EqualInt(t, 3, int(rec.NumRows()))
+	assert.EqualStrings(t, "IBM", column("symbol").(*array.String).Value(2))
+	assert.EqualStrings(t, "annual", column("period").(*array.String).Value(0))
+	assert.EqualStrings(t, "quarterly", column("period").(*array.String).Value(1))
+	assert.EqualStrings(t, "2023-09-30", column("fiscalDateEnding").(*array.Date32).Value(2).FormattedString())
+	assert.EqualInt(t, 135241000000, int(column("totalAssets").(*array.Int64).Value(0)))
+	assert.True(t, column("totalAssets").IsNull(2))
+}
+
+func TestIncomeStatementAndCashFlowRecord(t *testing.T) {
+	mem := newCheckedAllocator(t)
+	incomeStatement := &alphavantage.IncomeStatement{
+		Symbol:        "IBM",
+		AnnualReports: []alphavantage.IsAnnualReport{{FiscalDateEnding: "2023-12-31", TotalRevenue: alphavantage.AVInt{Value: 61860000000}}},
+	}
+	cashFlow := &alphavantage.CashFlow{
+		Symbol:           "IBM",
+		QuarterlyReports: []alphavantage.CfQuarterlyReport{{FiscalDateEnding: "2023-12-31", OperatingCashflow: alphavantage.AVInt{Value: 4480000000}}},
+	}
+
+	rec, err := IncomeStatementRecord(mem, incomeStatement)
+	assert.NoError(t.Fatalf, err)
+	defer rec.Release()
+	assert.EqualInt(t, 61860000000, int(rec.Column(rec.Schema().FieldIndices("totalRevenue")[0]).(*array.Int64).Value(0)))
+
+	rec, err = CashFlowRecord(mem, cashFlow)
+	assert.NoError(t.Fatalf, err)
+	defer rec.Release()
+	assert.EqualStrings(t, "quarterly", rec.Column(1).(*array.String).Value(0))
+	assert.EqualInt(t, 4480000000, int(rec.Column(rec.Schema().FieldIndices("operatingCashflow")[0]).(*array.Int64).Value(0)))
+}
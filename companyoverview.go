@@ -7,7 +7,7 @@ import (
 
 // CompanyOverview represents the company overview data for a company.
 type CompanyOverview struct {
-	Symbol                     string    `json:"Symbol"`
+	Symbol                     string    `json:"Symbol" validate:"required"`
 	AssetType                  string    `json:"AssetType"`
 	Name                       string    `json:"Name"`
 	Description                string    `json:"Description"`
@@ -77,5 +77,12 @@ func (c *Client) CompanyOverview(symbol string) (*CompanyOverview, error) {
 	if err != nil {
 		return nil, err
 	}
-	return toCompanyOverview(body)
+	companyOverview, err := toCompanyOverview(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkResponse(function, body, companyOverview); err != nil {
+		return nil, err
+	}
+	return companyOverview, nil
 }
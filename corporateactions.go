@@ -0,0 +1,72 @@
+package alphavantage
+
+import (
+	"math"
+	"sort"
+)
+
+// corporateActionTolerance is the absolute difference below which a
+// dividend amount or split factor is considered equal.
+const corporateActionTolerance = 0.0001
+
+// CorporateActionMismatch describes a date on which the dividend or
+// split columns of an adjusted time series disagree with the corporate
+// action endpoints.
+type CorporateActionMismatch struct {
+	Date     string
+	Field    string  // "dividend" or "split"
+	Expected float64 // from Dividends/Splits
+	Actual   float64 // from TimeSeriesAdjustedData
+}
+
+// ReconcileCorporateActions compares the DividendAmount and
+// SplitCoefficient of the daily adjusted time series with the given
+// dividends and splits.  Only dates covered by the time series are
+// checked.  Either dividends or splits can be nil to skip that check.
+// The mismatches are ordered by date.
+func ReconcileCorporateActions(ts *TimeSeriesAdjusted, dividends *Dividends, splits *Splits) []CorporateActionMismatch {
+	mismatches := make([]CorporateActionMismatch, 0)
+	var dividendsByDate, splitsByDate map[string]float64
+	if dividends != nil {
+		dividendsByDate = dividends.ByExDate()
+	}
+	if splits != nil {
+		splitsByDate = splits.ByEffectiveDate()
+	}
+
+	for date, data := range ts.TimeSeriesDaily {
+		if dividends != nil {
+			expected := dividendsByDate[date]
+			if math.Abs(expected-data.DividendAmount) > corporateActionTolerance {
+				mismatches = append(mismatches, CorporateActionMismatch{
+					Date:     date,
+					Field:    "dividend",
+					Expected: expected,
+					Actual:   data.DividendAmount,
+				})
+			}
+		}
+		if splits != nil {
+			expected, exists := splitsByDate[date]
+			if !exists {
+				expected = 1
+			}
+			if math.Abs(expected-data.SplitCoefficient) > corporateActionTolerance {
+				mismatches = append(mismatches, CorporateActionMismatch{
+					Date:     date,
+					Field:    "split",
+					Expected: expected,
+					Actual:   data.SplitCoefficient,
+				})
+			}
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].Date == mismatches[j].Date {
+			return mismatches[i].Field < mismatches[j].Field
+		}
+		return mismatches[i].Date < mismatches[j].Date
+	})
+	return mismatches
+}
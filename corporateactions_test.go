@@ -0,0 +1,48 @@
+package alphavantage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AMekss/assert"
+)
+
+func TestReconcileCorporateActions(t *testing.T) {
+	ts := &TimeSeriesAdjusted{
+		TimeSeriesDaily: map[string]TimeSeriesAdjustedData{
+			"2024-05-08": {DividendAmount: 0, SplitCoefficient: 1},
+			"2024-05-09": {DividendAmount: 1.67, SplitCoefficient: 1},
+			"2024-05-10": {DividendAmount: 0, SplitCoefficient: 1},
+			"2024-05-13": {DividendAmount: 0.5, SplitCoefficient: 1},
+		},
+	}
+	dividends := &Dividends{Data: []Dividend{
+		{ExDividendDate: AVDate{Value: mustParseDate("2024-05-09")}, Amount: AVFloat64{Value: 1.67}},
+	}}
+	splits := &Splits{Data: []Split{
+		{EffectiveDate: AVDate{Value: mustParseDate("2024-05-10")}, SplitFactor: AVFloat64{Value: 2}},
+	}}
+
+	mismatches := ReconcileCorporateActions(ts, dividends, splits)
+	assert.EqualInt(t, 2, len(mismatches))
+
+	assert.EqualStrings(t, "2024-05-10", mismatches[0].Date)
+	assert.EqualStrings(t, "split", mismatches[0].Field)
+	assert.EqualFloat64(t, 2, mismatches[0].Expected)
+	assert.EqualFloat64(t, 1, mismatches[0].Actual)
+
+	assert.EqualStrings(t, "2024-05-13", mismatches[1].Date)
+	assert.EqualStrings(t, "dividend", mismatches[1].Field)
+	assert.EqualFloat64(t, 0, mismatches[1].Expected)
+	assert.EqualFloat64(t, 0.5, mismatches[1].Actual)
+
+	assert.EqualInt(t, 1, len(ReconcileCorporateActions(ts, dividends, nil)))
+}
+
+func mustParseDate(value string) time.Time {
+	date, err := time.Parse(DateFormat, value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
@@ -0,0 +1,571 @@
+package alphavantage
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CSVMarshaler is implemented by types which write their own CSV value,
+// e.g. AVFloat64 writes "None" for missing values.
+type CSVMarshaler interface {
+	MarshalCSV() ([]byte, error)
+}
+
+const (
+	// CSVSectionColumn is the column with the JSON name of the slice or
+	// map field a row was read from, e.g. "annualReports".
+	CSVSectionColumn = "section"
+	// CSVKeyColumn is the column with the map key of a row, e.g. the
+	// date of a time series data point.
+	CSVKeyColumn = "key"
+)
+
+var (
+	csvMarshalerType    = reflect.TypeOf((*CSVMarshaler)(nil)).Elem()
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// csvLeaf is a scalar field, index is the path to the field through
+// nested structs.
+type csvLeaf struct {
+	name  string
+	index []int
+}
+
+// csvCollection is a slice or map field of structs whose elements are
+// written as rows.
+type csvCollection struct {
+	name   string
+	index  int
+	isMap  bool
+	leaves []csvLeaf
+}
+
+// csvLayout describes how values of a type are flattened into rows.
+type csvLayout struct {
+	isSlice     bool
+	leaves      []csvLeaf
+	collections []csvCollection
+	header      []string
+}
+
+// WriteCSV writes v as CSV with a header row.  The columns are the JSON
+// names of the fields in declaration order, nested structs are
+// flattened with the JSON names joined by ".".
+//
+// v can be a slice of structs, e.g. []OptionContract, with a row per
+// element, or a struct.  Slice and map fields of structs in a struct,
+// e.g. the reports of a BalanceSheet or the data points of a
+// TimeSeries, are written as a row per element: the scalar fields of
+// the struct are repeated, CSVSectionColumn names the field and, for
+// maps, CSVKeyColumn holds the key (rows are ordered by key).  Columns
+// of the elements with the name of a scalar field of the struct are
+// prefixed with the field's JSON name, e.g. "data.symbol" for the
+// contracts of HistoricalOptionsData.  Other slices and maps are
+// skipped.
+//
+// Missing values of the AV types are written as "None".
+func WriteCSV(w io.Writer, v interface{}) error {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if !value.IsValid() {
+		return fmt.Errorf("unsupported value: %v", v)
+	}
+	layout, err := csvLayoutOf(value.Type())
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(layout.header); err != nil {
+		return err
+	}
+	if err := layout.rows(value, writer.Write); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadCSV reads CSV written by WriteCSV into v, which has to be a
+// pointer to a value of the written type.  Missing columns are left
+// unset.
+func ReadCSV(r io.Reader, v interface{}) error {
+	ptr := reflect.ValueOf(v)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return fmt.Errorf("expected a non-nil pointer, got %T", v)
+	}
+	layout, err := csvLayoutOf(ptr.Elem().Type())
+	if err != nil {
+		return err
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("missing CSV header")
+	}
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		if !layout.hasColumn(name) {
+			return fmt.Errorf("unknown column: %q", name)
+		}
+		columns[name] = i
+	}
+	return layout.read(ptr.Elem(), columns, records[1:])
+}
+
+func csvLayoutOf(t reflect.Type) (*csvLayout, error) {
+	layout := &csvLayout{}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		elem := t.Elem()
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("unsupported element type: %s", t.Elem())
+		}
+		layout.isSlice = true
+		layout.leaves = csvLeaves(elem, "", nil)
+	case reflect.Struct:
+		layout.leaves = csvLeaves(t, "", nil)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := csvFieldName(field)
+			if !ok {
+				continue
+			}
+			var elem reflect.Type
+			switch field.Type.Kind() {
+			case reflect.Slice, reflect.Map:
+				elem = field.Type.Elem()
+			default:
+				continue
+			}
+			if elem.Kind() != reflect.Struct || isCSVScalar(elem) {
+				continue
+			}
+			layout.collections = append(layout.collections, csvCollection{
+				name:   name,
+				index:  i,
+				isMap:  field.Type.Kind() == reflect.Map,
+				leaves: csvLeaves(elem, "", nil),
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type: %s", t)
+	}
+
+	for _, leaf := range layout.leaves {
+		layout.header = append(layout.header, leaf.name)
+	}
+	// columns of the elements which clash with the scalar fields, e.g.
+	// the symbol of an option contract and of the response, are prefixed
+	// with the JSON name of the collection
+	taken := map[string]bool{CSVSectionColumn: true, CSVKeyColumn: true}
+	for _, leaf := range layout.leaves {
+		taken[leaf.name] = true
+	}
+	for _, collection := range layout.collections {
+		for i, leaf := range collection.leaves {
+			if taken[leaf.name] {
+				collection.leaves[i].name = collection.name + "." + leaf.name
+			}
+		}
+	}
+	if len(layout.collections) > 0 {
+		layout.header = append(layout.header, CSVSectionColumn)
+		for _, collection := range layout.collections {
+			if collection.isMap {
+				layout.header = append(layout.header, CSVKeyColumn)
+				break
+			}
+		}
+		// the columns of all collections, in the order they first appear
+		seen := make(map[string]bool)
+		for _, collection := range layout.collections {
+			for _, leaf := range collection.leaves {
+				if !seen[leaf.name] {
+					seen[leaf.name] = true
+					layout.header = append(layout.header, leaf.name)
+				}
+			}
+		}
+	}
+	seen := make(map[string]bool, len(layout.header))
+	for _, name := range layout.header {
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate column: %q", name)
+		}
+		seen[name] = true
+	}
+	return layout, nil
+}
+
+// csvLeaves returns the scalar fields of the struct type, nested structs
+// are flattened.
+func csvLeaves(t reflect.Type, prefix string, index []int) []csvLeaf {
+	var leaves []csvLeaf
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := csvFieldName(field)
+		if !ok {
+			continue
+		}
+		fieldIndex := append(append([]int{}, index...), i)
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		switch {
+		case isCSVScalar(fieldType):
+			leaves = append(leaves, csvLeaf{name: prefix + name, index: fieldIndex})
+		case fieldType.Kind() == reflect.Struct && field.Type.Kind() != reflect.Ptr:
+			nested := prefix + name + "."
+			if field.Anonymous {
+				nested = prefix
+			}
+			leaves = append(leaves, csvLeaves(fieldType, nested, fieldIndex)...)
+		}
+	}
+	return leaves
+}
+
+func csvFieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// isCSVScalar reports whether values of the type are written as a single
+// CSV value.
+func isCSVScalar(t reflect.Type) bool {
+	if t.Implements(csvMarshalerType) || t.Implements(jsonMarshalerType) ||
+		reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func (l *csvLayout) hasColumn(name string) bool {
+	for _, column := range l.header {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+// rows calls write with the rows of value.
+func (l *csvLayout) rows(value reflect.Value, write func([]string) error) error {
+	if l.isSlice {
+		for i := 0; i < value.Len(); i++ {
+			row, err := encodeCSVLeaves(reflect.Indirect(value.Index(i)), l.leaves)
+			if err != nil {
+				return err
+			}
+			if err := write(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	parent, err := encodeCSVLeaves(value, l.leaves)
+	if err != nil {
+		return err
+	}
+	if len(l.collections) == 0 {
+		return write(parent)
+	}
+	position := make(map[string]int, len(l.header))
+	for i, name := range l.header {
+		position[name] = i
+	}
+	rows := 0
+	writeElem := func(collection csvCollection, key string, elem reflect.Value) error {
+		values, err := encodeCSVLeaves(elem, collection.leaves)
+		if err != nil {
+			return err
+		}
+		row := make([]string, len(l.header))
+		copy(row, parent)
+		row[position[CSVSectionColumn]] = collection.name
+		if collection.isMap {
+			row[position[CSVKeyColumn]] = key
+		}
+		for i, leaf := range collection.leaves {
+			row[position[leaf.name]] = values[i]
+		}
+		rows++
+		return write(row)
+	}
+	for _, collection := range l.collections {
+		field := value.Field(collection.index)
+		if !collection.isMap {
+			for i := 0; i < field.Len(); i++ {
+				if err := writeElem(collection, "", field.Index(i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		keys := field.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			if err := writeElem(collection, fmt.Sprint(key.Interface()), field.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+	}
+	if rows == 0 {
+		// keep the scalar fields of a struct without elements
+		row := make([]string, len(l.header))
+		copy(row, parent)
+		return write(row)
+	}
+	return nil
+}
+
+func encodeCSVLeaves(value reflect.Value, leaves []csvLeaf) ([]string, error) {
+	row := make([]string, len(leaves))
+	if !value.IsValid() {
+		// nil element
+		return row, nil
+	}
+	for i, leaf := range leaves {
+		field, ok := fieldByCSVIndex(value, leaf.index, false)
+		if !ok {
+			continue
+		}
+		encoded, err := encodeCSVValue(field)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", leaf.name, err)
+		}
+		row[i] = encoded
+	}
+	return row, nil
+}
+
+// fieldByCSVIndex returns the nested field, allocating nil pointers if
+// alloc is true.
+func fieldByCSVIndex(value reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for _, i := range index {
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				if !alloc {
+					return reflect.Value{}, false
+				}
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(i)
+	}
+	return value, true
+}
+
+func encodeCSVValue(value reflect.Value) (string, error) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", nil
+		}
+		value = value.Elem()
+	}
+	if marshaler, ok := value.Interface().(CSVMarshaler); ok {
+		buf, err := marshaler.MarshalCSV()
+		return string(buf), err
+	}
+	if marshaler, ok := value.Interface().(json.Marshaler); ok {
+		buf, err := marshaler.MarshalJSON()
+		if err != nil {
+			return "", err
+		}
+		var s string
+		if json.Unmarshal(buf, &s) == nil {
+			return s, nil
+		}
+		return string(buf), nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("unsupported type: %s", value.Type())
+}
+
+// read sets value from the rows.
+func (l *csvLayout) read(value reflect.Value, columns map[string]int, rows [][]string) error {
+	if l.isSlice {
+		if value.Kind() == reflect.Array && len(rows) > value.Len() {
+			return fmt.Errorf("expected at most %d rows, got %d", value.Len(), len(rows))
+		}
+		if value.Kind() == reflect.Slice {
+			value.Set(reflect.MakeSlice(value.Type(), len(rows), len(rows)))
+		}
+		for i, row := range rows {
+			elem := value.Index(i)
+			if elem.Kind() == reflect.Ptr {
+				elem.Set(reflect.New(elem.Type().Elem()))
+				elem = elem.Elem()
+			}
+			if err := decodeCSVLeaves(elem, l.leaves, columns, row, i+2); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+	if len(l.collections) == 0 && len(rows) > 1 {
+		return fmt.Errorf("expected 1 row, got %d", len(rows))
+	}
+	if err := decodeCSVLeaves(value, l.leaves, columns, rows[0], 2); err != nil {
+		return err
+	}
+	if len(l.collections) == 0 {
+		return nil
+	}
+	collections := make(map[string]csvCollection, len(l.collections))
+	for _, collection := range l.collections {
+		collections[collection.name] = collection
+	}
+	for i, row := range rows {
+		section := csvCell(row, columns, CSVSectionColumn)
+		if section == "" {
+			continue
+		}
+		collection, ok := collections[section]
+		if !ok {
+			return fmt.Errorf("row %d: unknown section: %q", i+2, section)
+		}
+		field := value.Field(collection.index)
+		elem := reflect.New(field.Type().Elem()).Elem()
+		if err := decodeCSVLeaves(elem, collection.leaves, columns, row, i+2); err != nil {
+			return err
+		}
+		if !collection.isMap {
+			field.Set(reflect.Append(field, elem))
+			continue
+		}
+		key := reflect.New(field.Type().Key()).Elem()
+		if err := decodeCSVValue(key, csvCell(row, columns, CSVKeyColumn)); err != nil {
+			return fmt.Errorf("row %d: %s: %w", i+2, CSVKeyColumn, err)
+		}
+		if field.IsNil() {
+			field.Set(reflect.MakeMap(field.Type()))
+		}
+		field.SetMapIndex(key, elem)
+	}
+	return nil
+}
+
+func csvCell(row []string, columns map[string]int, name string) string {
+	i, ok := columns[name]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return row[i]
+}
+
+func decodeCSVLeaves(value reflect.Value, leaves []csvLeaf, columns map[string]int, row []string, line int) error {
+	for _, leaf := range leaves {
+		if _, ok := columns[leaf.name]; !ok {
+			continue
+		}
+		field, _ := fieldByCSVIndex(value, leaf.index, true)
+		if err := decodeCSVValue(field, csvCell(row, columns, leaf.name)); err != nil {
+			return fmt.Errorf("row %d: %s: %w", line, leaf.name, err)
+		}
+	}
+	return nil
+}
+
+func decodeCSVValue(value reflect.Value, cell string) error {
+	if value.Kind() == reflect.Ptr {
+		if cell == "" {
+			return nil
+		}
+		value.Set(reflect.New(value.Type().Elem()))
+		value = value.Elem()
+	}
+	if unmarshaler, ok := value.Addr().Interface().(json.Unmarshaler); ok {
+		quoted, _ := json.Marshal(cell)
+		err := unmarshaler.UnmarshalJSON(quoted)
+		if err != nil && json.Valid([]byte(cell)) {
+			// e.g. numbers written by MarshalJSON
+			err = unmarshaler.UnmarshalJSON([]byte(cell))
+		}
+		return err
+	}
+	if value.Kind() != reflect.String && (cell == "" || cell == "None" || cell == "-") {
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(cell, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(cell, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(cell, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type: %s", value.Type())
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package alphavantage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestAVTypesMarshalCSV(t *testing.T) {
+	for _, test := range []struct {
+		value    CSVMarshaler
+		expected string
+	}{
+		{AVFloat64{Value: 1.25}, "1.25"},
+		{AVFloat64{}, "None"},
+		{AVPercent{Value: 12.3456}, "12.3456%"},
+		{AVPercent{}, "None"},
+		{AVInt{Value: -42}, "-42"},
+		{AVInt{}, "None"},
+	} {
+		buf, err := test.value.MarshalCSV()
+		assert.NoError(t.Fatalf, err)
+		assert.EqualStrings(t, test.expected, string(buf))
+	}
+}
+
+func TestWriteCSVSlice(t *testing.T) {
+	contracts := []OptionContract{
+		{ContractID: "IBM240216C00160000", Symbol: "IBM", Strike: AVFloat64{Value: 160}, Type: "call", BidSize: AVInt{Value: 12}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t.Fatalf, WriteCSV(&buf, contracts))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.EqualInt(t, 2, len(lines))
+	assert.EqualStrings(t, "contractID,symbol,expiration,strike,type,last,mark,bid,bid_size,ask,ask_size,volume,open_interest,"+
+		"date,implied_volatility,delta,gamma,theta,vega,rho", lines[0])
+	assert.EqualStrings(t, "IBM240216C00160000,IBM,,160,call,None,None,None,12,None,None,None,None,,None,None,None,None,None,None", lines[1])
+
+	var read []OptionContract
+	assert.NoError(t.Fatalf, ReadCSV(&buf, &read))
+	assert.EqualInt(t, 1, len(read))
+	assert.EqualStrings(t, "IBM240216C00160000", read[0].ContractID)
+	assert.EqualFloat64(t, 160, read[0].Strike.Value)
+	assert.EqualInt(t, 12, read[0].BidSize.Value)
+	assert.EqualFloat64(t, 0, read[0].Bid.Value)
+}
+
+func TestWriteCSVStatement(t *testing.T) {
+	balanceSheet := &BalanceSheet{
+		Symbol: "IBM",
+		AnnualReports: []BsAnnualReport{
+			{FiscalDateEnding: "2023-12-31", ReportedCurrency: "USD", TotalAssets: AVInt{Value: 135241000000}},
+		},
+		QuarterlyReports: []BsQuarterlyReport{
+			{FiscalDateEnding: "2023-12-31", ReportedCurrency: "USD", TotalAssets: AVInt{Value: 135241000000}},
+			{FiscalDateEnding: "2023-09-30", ReportedCurrency: "USD"},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t.Fatalf, WriteCSV(&buf, balanceSheet))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.EqualInt(t, 4, len(lines))
+	assert.True(t, strings.HasPrefix(lines[0], "symbol,section,fiscalDateEnding,reportedCurrency,totalAssets,"))
+	assert.True(t, strings.HasPrefix(lines[1], "IBM,annualReports,2023-12-31,USD,135241000000,"))
+	assert.True(t, strings.HasPrefix(lines[3], "IBM,quarterlyReports,2023-09-30,USD,None,"))
+
+	var read BalanceSheet
+	assert.NoError(t.Fatalf, ReadCSV(&buf, &read))
+	assert.EqualStrings(t, "IBM", read.Symbol)
+	assert.EqualInt(t, 1, len(read.AnnualReports))
+	assert.EqualInt(t, 2, len(read.QuarterlyReports))
+	assert.EqualInt(t, 135241000000, read.AnnualReports[0].TotalAssets.Value)
+	assert.EqualStrings(t, "2023-09-30", read.QuarterlyReports[1].FiscalDateEnding)
+	assert.EqualInt(t, 0, read.QuarterlyReports[1].TotalAssets.Value)
+}
+
+func TestWriteCSVTimeSeries(t *testing.T) {
+	ts := &TimeSeries{
+		Metadata: TimeSeriesMetadata{Symbol: "IBM", LastRefreshed: "2024-01-03"},
+		TimeSeriesDaily: map[string]TimeSeriesData{
+			"2024-01-03": {Open: 161, Close: 160.1, Volume: 4086065},
+			"2024-01-02": {Open: 162.83, Close: 161.5, Volume: 3825045},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t.Fatalf, WriteCSV(&buf, ts))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.EqualInt(t, 3, len(lines))
+	assert.EqualStrings(t, "Meta Data.1. Information,Meta Data.2. Symbol,Meta Data.3. Last Refreshed,"+
+		"Meta Data.4. Output Size,Meta Data.4. Time Zone,Meta Data.5. Time Zone,"+
+		"section,key,1. open,2. high,3. low,4. close,5. volume", lines[0])
+	assert.EqualStrings(t, ",IBM,2024-01-03,,,,Time Series (Daily),2024-01-02,162.83,0,0,161.5,3825045", lines[1])
+
+	var read TimeSeries
+	assert.NoError(t.Fatalf, ReadCSV(&buf, &read))
+	assert.EqualStrings(t, "IBM", read.Metadata.Symbol)
+	assert.EqualInt(t, 2, len(read.TimeSeriesDaily))
+	assert.EqualFloat64(t, 160.1, read.TimeSeriesDaily["2024-01-03"].Close)
+	assert.EqualInt(t, 3825045, int(read.TimeSeriesDaily["2024-01-02"].Volume))
+	assert.True(t, read.TimeSeriesWeekly == nil)
+}
+
+func TestWriteCSVOptions(t *testing.T) {
+	contracts := []OptionContract{
+		{ContractID: "IBM240216C00160000", Symbol: "IBM", Expiration: "2024-02-16", Strike: AVFloat64{Value: 160}, Type: "call", Delta: AVFloat64{Value: 0.5}},
+		{ContractID: "IBM240216P00160000", Symbol: "IBM", Expiration: "2024-02-16", Strike: AVFloat64{Value: 160}, Type: "put"},
+	}
+	for _, test := range []struct {
+		value interface{}
+		read  func() interface{}
+		data  func(v interface{}) (string, []OptionContract)
+	}{
+		{
+			&HistoricalOptionsData{Symbol: "IBM", Endpoint: "Historical Options", Message: "success", Data: contracts},
+			func() interface{} { return &HistoricalOptionsData{} },
+			func(v interface{}) (string, []OptionContract) {
+				data := v.(*HistoricalOptionsData)
+				return data.Symbol, data.Data
+			},
+		},
+		{
+			&RealtimeOptionsData{Symbol: "IBM", Endpoint: "Realtime Options", Message: "success", Data: contracts},
+			func() interface{} { return &RealtimeOptionsData{} },
+			func(v interface{}) (string, []OptionContract) {
+				data := v.(*RealtimeOptionsData)
+				return data.Symbol, data.Data
+			},
+		},
+	} {
+		var buf bytes.Buffer
+		assert.NoError(t.Fatalf, WriteCSV(&buf, test.value))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.EqualInt(t, 3, len(lines))
+		assert.True(t, strings.HasPrefix(lines[0], "symbol,endpoint,message,section,contractID,data.symbol,expiration,strike,"))
+		assert.True(t, strings.HasPrefix(lines[2], "IBM,"))
+
+		read := test.read()
+		assert.NoError(t.Fatalf, ReadCSV(&buf, read))
+		symbol, data := test.data(read)
+		assert.EqualStrings(t, "IBM", symbol)
+		assert.EqualInt(t, 2, len(data))
+		assert.EqualStrings(t, "IBM240216C00160000", data[0].ContractID)
+		assert.EqualStrings(t, "IBM", data[0].Symbol)
+		assert.EqualFloat64(t, 0.5, data[0].Delta.Value)
+		assert.EqualStrings(t, "put", data[1].Type)
+		assert.True(t, data[1].Delta.IsNull())
+	}
+}
+
+func TestWriteCSVQuote(t *testing.T) {
+	quote := GlobalQuoteResponse{GlobalQuote: GlobalQuote{Symbol: "IBM", Price: 160.1, Volume: 4086065, ChangePercent: AVPercent{Value: -0.8669}}}
+
+	var buf bytes.Buffer
+	assert.NoError(t.Fatalf, WriteCSV(&buf, quote))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.EqualInt(t, 2, len(lines))
+	assert.True(t, strings.HasPrefix(lines[0], "Global Quote.01. symbol,Global Quote.02. open,"))
+	assert.True(t, strings.HasSuffix(lines[1], ",-0.8669%"))
+
+	var read GlobalQuoteResponse
+	assert.NoError(t.Fatalf, ReadCSV(&buf, &read))
+	assert.EqualStrings(t, "IBM", read.GlobalQuote.Symbol)
+	assert.EqualFloat64(t, 160.1, read.GlobalQuote.Price)
+	assert.EqualFloat64(t, -0.8669, read.GlobalQuote.ChangePercent.Value)
+}
+
+func TestWriteCSVEmptyCollections(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t.Fatalf, WriteCSV(&buf, &Earnings{Symbol: "IBM"}))
+
+	var read Earnings
+	assert.NoError(t.Fatalf, ReadCSV(&buf, &read))
+	assert.EqualStrings(t, "IBM", read.Symbol)
+	assert.EqualInt(t, 0, len(read.AnnualEarnings))
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	var transactions []InsiderTransaction
+	err := ReadCSV(strings.NewReader("ticker,price\nIBM,1\n"), &transactions)
+	assert.ErrorIncludesMessage(t, `unknown column: "price"`, err)
+
+	var quote GlobalQuote
+	err = ReadCSV(strings.NewReader("01. symbol,02. open\nIBM,abc\n"), &quote)
+	assert.ErrorIncludesMessage(t, "row 2: 02. open: strconv.ParseFloat", err)
+
+	err = ReadCSV(strings.NewReader("01. symbol\nIBM\nMSFT\n"), &quote)
+	assert.ErrorIncludesMessage(t, "expected 1 row, got 2", err)
+
+	err = ReadCSV(strings.NewReader(""), transactions)
+	assert.ErrorIncludesMessage(t, "expected a non-nil pointer", err)
+
+	err = WriteCSV(&bytes.Buffer{}, []string{"IBM"})
+	assert.ErrorIncludesMessage(t, "unsupported element type: string", err)
+}
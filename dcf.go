@@ -0,0 +1,275 @@
+package alphavantage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidAssumptions is returned for DCF assumptions which don't give a
+// valuation, e.g. a terminal growth which isn't below the WACC.
+var ErrInvalidAssumptions = errors.New("invalid DCF assumptions")
+
+// TerminalMethod selects how the value after the growth stages is
+// computed.
+type TerminalMethod int
+
+// Methods of the terminal value
+const (
+	// TerminalGordon grows the free cash flow of the last year forever at
+	// the terminal growth: FCF * (1 + g) / (WACC - g)
+	TerminalGordon TerminalMethod = iota
+	// TerminalExitMultiple values the company at a multiple of the free
+	// cash flow of the last year: FCF * multiple
+	TerminalExitMultiple
+)
+
+// GrowthStage grows the free cash flow by Growth per year, e.g. 0.1 for
+// 10%, for Years years.
+type GrowthStage struct {
+	Years  int
+	Growth float64
+}
+
+// DCFInputs are the company's figures of a DCF valuation, see
+// NewDCFInputs.
+type DCFInputs struct {
+	// FreeCashFlow is the free cash flow of the base year,
+	// operatingCashflow - capitalExpenditures
+	FreeCashFlow float64
+	// Debt is shortLongTermDebtTotal
+	Debt float64
+	// Cash is cashAndShortTermInvestments
+	Cash              float64
+	SharesOutstanding float64
+	MarketCap         float64
+	Beta              float64
+}
+
+// DCFAssumptions are the assumptions of a DCF valuation.  Rates are
+// fractions, e.g. 0.05 for 5%.
+type DCFAssumptions struct {
+	Stages []GrowthStage
+
+	// The cost of equity is RiskFreeRate + Beta * EquityRiskPremium (CAPM),
+	// e.g. with the rate of TreasuryYield.RiskFreeRate
+	RiskFreeRate      float64
+	EquityRiskPremium float64
+	// CostOfDebt is before taxes, e.g. the yield of the company's bonds
+	CostOfDebt float64
+	TaxRate    float64
+
+	Terminal TerminalMethod
+	// TerminalGrowth is the perpetual growth of TerminalGordon
+	TerminalGrowth float64
+	// ExitMultiple is the multiple of TerminalExitMultiple
+	ExitMultiple float64
+}
+
+// DCFYear is a projected year of a DCF valuation.
+type DCFYear struct {
+	// Year is 1 for the first year after the base year
+	Year           int
+	Growth         float64
+	FreeCashFlow   float64
+	DiscountFactor float64
+	PresentValue   float64
+}
+
+// DCFValuation is the result of a DCF valuation.
+type DCFValuation struct {
+	// CostOfEquity = RiskFreeRate + Beta * EquityRiskPremium
+	CostOfEquity float64
+	// AfterTaxCostOfDebt = CostOfDebt * (1 - TaxRate)
+	AfterTaxCostOfDebt float64
+	// WACC = E / (D + E) * CostOfEquity + D / (D + E) * AfterTaxCostOfDebt,
+	// with the market cap E and the debt D
+	WACC float64
+
+	Years []DCFYear
+	// TerminalValue is at the end of the last year
+	TerminalValue float64
+	// PresentTerminalValue is the terminal value discounted to today
+	PresentTerminalValue float64
+
+	// EnterpriseValue is the sum of the present values
+	EnterpriseValue float64
+	// EquityValue = EnterpriseValue - Debt + Cash
+	EquityValue float64
+	// PerShare = EquityValue / SharesOutstanding, the intrinsic value
+	PerShare float64
+}
+
+// NewDCFInputs takes the free cash flow, debt and cash of the latest fiscal
+// year of the annual reports, and the shares outstanding, market cap and
+// beta of the overview.  Values which are "None" are errors.
+func NewDCFInputs(balanceSheet *BalanceSheet, cashFlow *CashFlow, overview *CompanyOverview) (DCFInputs, error) {
+	financials, fiscalYear, err := latestFiscalYear(balanceSheet, nil, cashFlow)
+	if err != nil {
+		return DCFInputs{}, fmt.Errorf("DCF: %w", err)
+	}
+	in := financials.Inputs()
+	inputs := DCFInputs{
+		FreeCashFlow: in.Value("operatingCashflow", fiscalYear, 0) - in.Value("capitalExpenditures", fiscalYear, 0),
+		Debt:         in.Value("shortLongTermDebtTotal", fiscalYear, 0),
+		Cash:         in.Value("cashAndShortTermInvestments", fiscalYear, 0),
+	}
+	if err := in.Err(); err != nil {
+		return DCFInputs{}, fmt.Errorf("DCF: %w", err)
+	}
+	if overview == nil {
+		return DCFInputs{}, fmt.Errorf("DCF: no company overview: %w", ErrNoneValue)
+	}
+	for _, field := range []struct {
+		name  string
+		value NullableNumber
+	}{
+		{"SharesOutstanding", overview.SharesOutstanding},
+		{"MarketCapitalization", overview.MarketCapitalization},
+		{"Beta", overview.Beta},
+	} {
+		if field.value.IsNull() {
+			return DCFInputs{}, fmt.Errorf("DCF: %s: %w", field.name, ErrNoneValue)
+		}
+	}
+	inputs.SharesOutstanding = overview.SharesOutstanding.Float64()
+	inputs.MarketCap = overview.MarketCapitalization.Float64()
+	inputs.Beta = overview.Beta.Float64()
+	return inputs, nil
+}
+
+// WACC returns the cost of equity by CAPM, the cost of debt after taxes
+// and the WACC weighted by market cap and debt.
+func (a DCFAssumptions) WACC(inputs DCFInputs) (costOfEquity, costOfDebt, wacc float64, err error) {
+	capital := inputs.MarketCap + inputs.Debt
+	if inputs.MarketCap < 0 || inputs.Debt < 0 || capital == 0 {
+		return 0, 0, 0, fmt.Errorf("%w: market cap %v and debt %v", ErrInvalidAssumptions, inputs.MarketCap, inputs.Debt)
+	}
+	costOfEquity = a.RiskFreeRate + inputs.Beta*a.EquityRiskPremium
+	costOfDebt = a.CostOfDebt * (1 - a.TaxRate)
+	wacc = inputs.MarketCap/capital*costOfEquity + inputs.Debt/capital*costOfDebt
+	return costOfEquity, costOfDebt, wacc, nil
+}
+
+// DCF values the company by discounting the free cash flows of the growth
+// stages and the terminal value with the WACC.  Cash flows are discounted
+// from the end of their year.
+func DCF(inputs DCFInputs, assumptions DCFAssumptions) (*DCFValuation, error) {
+	costOfEquity, costOfDebt, wacc, err := assumptions.WACC(inputs)
+	if err != nil {
+		return nil, err
+	}
+	valuation, err := discount(inputs, assumptions, wacc, assumptions.terminal())
+	if err != nil {
+		return nil, err
+	}
+	valuation.CostOfEquity = costOfEquity
+	valuation.AfterTaxCostOfDebt = costOfDebt
+	return valuation, nil
+}
+
+// terminal returns the terminal growth or exit multiple of the method
+func (a DCFAssumptions) terminal() float64 {
+	if a.Terminal == TerminalExitMultiple {
+		return a.ExitMultiple
+	}
+	return a.TerminalGrowth
+}
+
+// discount values the company with the WACC and the terminal growth or
+// exit multiple.
+func discount(inputs DCFInputs, assumptions DCFAssumptions, wacc, terminal float64) (*DCFValuation, error) {
+	if wacc <= -1 {
+		return nil, fmt.Errorf("%w: WACC %v", ErrInvalidAssumptions, wacc)
+	}
+	if inputs.SharesOutstanding <= 0 {
+		return nil, fmt.Errorf("%w: shares outstanding %v", ErrInvalidAssumptions, inputs.SharesOutstanding)
+	}
+	valuation := &DCFValuation{WACC: wacc}
+	fcf := inputs.FreeCashFlow
+	for _, stage := range assumptions.Stages {
+		if stage.Years < 0 {
+			return nil, fmt.Errorf("%w: %d years", ErrInvalidAssumptions, stage.Years)
+		}
+		for i := 0; i < stage.Years; i++ {
+			fcf *= 1 + stage.Growth
+			year := len(valuation.Years) + 1
+			factor := 1 / math.Pow(1+wacc, float64(year))
+			valuation.Years = append(valuation.Years, DCFYear{
+				Year:           year,
+				Growth:         stage.Growth,
+				FreeCashFlow:   fcf,
+				DiscountFactor: factor,
+				PresentValue:   fcf * factor,
+			})
+			valuation.EnterpriseValue += fcf * factor
+		}
+	}
+
+	switch assumptions.Terminal {
+	case TerminalGordon:
+		if terminal >= wacc {
+			return nil, fmt.Errorf("%w: terminal growth %v isn't below the WACC %v", ErrInvalidAssumptions, terminal, wacc)
+		}
+		valuation.TerminalValue = fcf * (1 + terminal) / (wacc - terminal)
+	case TerminalExitMultiple:
+		valuation.TerminalValue = fcf * terminal
+	default:
+		return nil, fmt.Errorf("%w: terminal method %d", ErrInvalidAssumptions, assumptions.Terminal)
+	}
+	valuation.PresentTerminalValue = valuation.TerminalValue / math.Pow(1+wacc, float64(len(valuation.Years)))
+	valuation.EnterpriseValue += valuation.PresentTerminalValue
+	valuation.EquityValue = valuation.EnterpriseValue - inputs.Debt + inputs.Cash
+	valuation.PerShare = valuation.EquityValue / inputs.SharesOutstanding
+	return valuation, nil
+}
+
+// DCFSensitivity is the per-share value for combinations of WACCs and
+// terminal growths or exit multiples.
+type DCFSensitivity struct {
+	WACCs []float64
+	// Terminals are terminal growths or exit multiples, depending on the
+	// terminal method
+	Terminals []float64
+	// PerShare is indexed by WACC, then terminal, "None" for combinations
+	// without a valuation, e.g. a terminal growth above the WACC
+	PerShare [][]AVFloat64
+}
+
+// Sensitivity values the company for each WACC and terminal growth, or
+// exit multiple for TerminalExitMultiple.
+func Sensitivity(inputs DCFInputs, assumptions DCFAssumptions, waccs, terminals []float64) *DCFSensitivity {
+	sensitivity := &DCFSensitivity{WACCs: waccs, Terminals: terminals, PerShare: make([][]AVFloat64, len(waccs))}
+	for i, wacc := range waccs {
+		sensitivity.PerShare[i] = make([]AVFloat64, len(terminals))
+		for j, terminal := range terminals {
+			valuation, err := discount(inputs, assumptions, wacc, terminal)
+			if err != nil {
+				continue
+			}
+			sensitivity.PerShare[i][j] = NewAVFloat64(valuation.PerShare)
+		}
+	}
+	return sensitivity
+}
+
+// SensitivityAround returns a table of the WACC of the assumptions and the
+// terminal growth or exit multiple, each plus and minus steps times the
+// step, e.g. 2 steps of 0.01 for WACCs of 7% to 11% around 9%.
+func SensitivityAround(inputs DCFInputs, assumptions DCFAssumptions, steps int, waccStep, terminalStep float64) (*DCFSensitivity, error) {
+	if steps < 0 {
+		return nil, fmt.Errorf("%w: %d steps", ErrInvalidAssumptions, steps)
+	}
+	_, _, wacc, err := assumptions.WACC(inputs)
+	if err != nil {
+		return nil, err
+	}
+	around := func(center, step float64) []float64 {
+		values := make([]float64, 0, 2*steps+1)
+		for i := -steps; i <= steps; i++ {
+			values = append(values, center+float64(i)*step)
+		}
+		return values
+	}
+	return Sensitivity(inputs, assumptions, around(wacc, waccStep), around(assumptions.terminal(), terminalStep)), nil
+}
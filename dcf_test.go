@@ -0,0 +1,153 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func newDCFInputs() DCFInputs {
+	return DCFInputs{
+		FreeCashFlow:      100,
+		Debt:              200,
+		Cash:              50,
+		SharesOutstanding: 10,
+		MarketCap:         800,
+		Beta:              1.2,
+	}
+}
+
+func newDCFAssumptions() DCFAssumptions {
+	return DCFAssumptions{
+		Stages:            []GrowthStage{{Years: 2, Growth: 0.1}, {Years: 1, Growth: 0.05}},
+		RiskFreeRate:      0.04,
+		EquityRiskPremium: 0.05,
+		CostOfDebt:        0.05,
+		TaxRate:           0.2,
+		Terminal:          TerminalGordon,
+		TerminalGrowth:    0.02,
+		ExitMultiple:      15,
+	}
+}
+
+func TestDCFAssumptionsWACC(t *testing.T) {
+	costOfEquity, costOfDebt, wacc, err := newDCFAssumptions().WACC(newDCFInputs())
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64Tol(t, 0.1, costOfEquity, 1e-12)
+	assert.EqualFloat64Tol(t, 0.04, costOfDebt, 1e-12)
+	assert.EqualFloat64Tol(t, 0.8*0.1+0.2*0.04, wacc, 1e-12)
+
+	inputs := newDCFInputs()
+	inputs.MarketCap, inputs.Debt = 0, 0
+	_, _, _, err = newDCFAssumptions().WACC(inputs)
+	assert.True(t, errors.Is(err, ErrInvalidAssumptions))
+}
+
+func TestDCF(t *testing.T) {
+	valuation, err := DCF(newDCFInputs(), newDCFAssumptions())
+	assert.NoError(t.Fatalf, err)
+
+	wacc := 0.088
+	assert.EqualFloat64Tol(t, wacc, valuation.WACC, 1e-12)
+	assert.EqualInt(t, 3, len(valuation.Years))
+	fcfs := []float64{110, 121, 127.05}
+	var presentValues float64
+	for i, year := range valuation.Years {
+		assert.EqualInt(t, i+1, year.Year)
+		assert.EqualFloat64Tol(t, fcfs[i], year.FreeCashFlow, 1e-9)
+		assert.EqualFloat64Tol(t, fcfs[i]/math.Pow(1+wacc, float64(i+1)), year.PresentValue, 1e-9)
+		presentValues += year.PresentValue
+	}
+	assert.EqualFloat64Tol(t, 0.05, valuation.Years[2].Growth, 1e-12)
+
+	terminalValue := 127.05 * 1.02 / (wacc - 0.02)
+	assert.EqualFloat64Tol(t, terminalValue, valuation.TerminalValue, 1e-9)
+	assert.EqualFloat64Tol(t, terminalValue/math.Pow(1+wacc, 3), valuation.PresentTerminalValue, 1e-9)
+	enterpriseValue := presentValues + valuation.PresentTerminalValue
+	assert.EqualFloat64Tol(t, enterpriseValue, valuation.EnterpriseValue, 1e-9)
+	assert.EqualFloat64Tol(t, enterpriseValue-150, valuation.EquityValue, 1e-9)
+	assert.EqualFloat64Tol(t, (enterpriseValue-150)/10, valuation.PerShare, 1e-9)
+
+	assumptions := newDCFAssumptions()
+	assumptions.Terminal = TerminalExitMultiple
+	valuation, err = DCF(newDCFInputs(), assumptions)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64Tol(t, 127.05*15, valuation.TerminalValue, 1e-9)
+
+	assumptions = newDCFAssumptions()
+	assumptions.TerminalGrowth = 0.1
+	_, err = DCF(newDCFInputs(), assumptions)
+	assert.True(t, errors.Is(err, ErrInvalidAssumptions))
+	assert.ErrorIncludesMessage(t, "isn't below the WACC", err)
+
+	inputs := newDCFInputs()
+	inputs.SharesOutstanding = 0
+	_, err = DCF(inputs, newDCFAssumptions())
+	assert.True(t, errors.Is(err, ErrInvalidAssumptions))
+}
+
+func TestSensitivity(t *testing.T) {
+	sensitivity, err := SensitivityAround(newDCFInputs(), newDCFAssumptions(), 1, 0.01, 0.01)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 3, len(sensitivity.WACCs))
+	assert.EqualInt(t, 3, len(sensitivity.Terminals))
+	assert.EqualFloat64Tol(t, 0.078, sensitivity.WACCs[0], 1e-12)
+	assert.EqualFloat64Tol(t, 0.03, sensitivity.Terminals[2], 1e-12)
+
+	valuation, err := DCF(newDCFInputs(), newDCFAssumptions())
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64Tol(t, valuation.PerShare, sensitivity.PerShare[1][1].Float64(), 1e-9)
+	// lower WACCs and higher growth are worth more
+	assert.True(t, sensitivity.PerShare[0][1].Float64() > sensitivity.PerShare[1][1].Float64())
+	assert.True(t, sensitivity.PerShare[1][2].Float64() > sensitivity.PerShare[1][1].Float64())
+
+	sensitivity = Sensitivity(newDCFInputs(), newDCFAssumptions(), []float64{0.02}, []float64{0.01, 0.03})
+	assert.False(t, sensitivity.PerShare[0][0].IsNull())
+	assert.True(t, sensitivity.PerShare[0][1].IsNull())
+	buf, err := json.Marshal(sensitivity)
+	assert.NoError(t.Fatalf, err)
+	assert.True(t, strings.HasSuffix(string(buf), `,"None"]]}`))
+
+	_, err = SensitivityAround(newDCFInputs(), newDCFAssumptions(), -1, 0.01, 0.01)
+	assert.True(t, errors.Is(err, ErrInvalidAssumptions))
+}
+
+func TestNewDCFInputs(t *testing.T) {
+	balanceSheet := &BalanceSheet{AnnualReports: []BsAnnualReport{{
+		FiscalDateEnding:            "2023-12-31",
+		ShortLongTermDebtTotal:      NewAVInt(200),
+		CashAndShortTermInvestments: NewAVInt(50),
+	}, {
+		FiscalDateEnding: "2022-12-31",
+	}}}
+	cashFlow := &CashFlow{AnnualReports: []CfAnnualReport{{
+		FiscalDateEnding:    "2023-12-31",
+		OperatingCashflow:   NewAVInt(130),
+		CapitalExpenditures: NewAVInt(30),
+	}}}
+	overview := &CompanyOverview{
+		SharesOutstanding:    NewAVInt(10),
+		MarketCapitalization: NewAVInt(800),
+		Beta:                 NewAVFloat64(1.2),
+	}
+	inputs, err := NewDCFInputs(balanceSheet, cashFlow, overview)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64(t, 100, inputs.FreeCashFlow)
+	assert.EqualFloat64(t, 200, inputs.Debt)
+	assert.EqualFloat64(t, 50, inputs.Cash)
+	assert.EqualFloat64(t, 10, inputs.SharesOutstanding)
+	assert.EqualFloat64(t, 800, inputs.MarketCap)
+	assert.EqualFloat64(t, 1.2, inputs.Beta)
+
+	overview.Beta = AVFloat64{}
+	_, err = NewDCFInputs(balanceSheet, cashFlow, overview)
+	assert.EqualErrors(t, errors.New("DCF: Beta: value is None"), err)
+
+	cashFlow.AnnualReports[0].CapitalExpenditures = AVInt{}
+	_, err = NewDCFInputs(balanceSheet, cashFlow, overview)
+	assert.EqualErrors(t, errors.New("DCF: FY2023 capitalExpenditures: value is None"), err)
+}
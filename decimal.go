@@ -0,0 +1,114 @@
+package alphavantage
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// AVDecimal represents an exact decimal value which handles "None" like
+// AVFloat64, Valid is false for "None".  Values are parsed from the text
+// of the API response, so prices and statement values keep all their
+// digits, and percentages like "1.2345%" are stored in percent.
+type AVDecimal struct {
+	Value decimal.Decimal
+	Valid bool
+}
+
+// NewAVDecimal returns a valid AVDecimal.
+func NewAVDecimal(value decimal.Decimal) AVDecimal {
+	return AVDecimal{Value: value, Valid: true}
+}
+
+// UnmarshalJSON custom unmarshaller to handle "None" value.
+func (cd *AVDecimal) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+		*cd = AVDecimal{}
+	case json.Number:
+		return cd.parse(string(v))
+	case string:
+		if v == "None" || v == "-" {
+			*cd = AVDecimal{}
+			return nil
+		}
+		return cd.parse(v)
+	default:
+		return fmt.Errorf("unexpected value type: %T", v)
+	}
+	return nil
+}
+
+func (cd *AVDecimal) parse(s string) error {
+	d, err := decimal.NewFromString(strings.TrimSuffix(s, "%"))
+	if err != nil {
+		return fmt.Errorf("unexpected string value: %s: %v", s, err)
+	}
+	*cd = AVDecimal{Value: d, Valid: true}
+	return nil
+}
+
+// MarshalJSON method to handle custom JSON marshaling
+func (cd AVDecimal) MarshalJSON() ([]byte, error) {
+	if cd.IsNull() {
+		return []byte("\"None\""), nil
+	}
+	return json.Marshal(cd.Value.String())
+}
+
+// MarshalCSV custom marshaller to handle CSV marshaling.
+func (cd AVDecimal) MarshalCSV() ([]byte, error) {
+	if cd.IsNull() {
+		return []byte("None"), nil
+	}
+	return []byte(cd.Value.String()), nil
+}
+
+// IsNull reports whether the value is "None".
+func (cd AVDecimal) IsNull() bool {
+	return !cd.Valid && cd.Value.IsZero()
+}
+
+// Float64 returns the nearest float64 value, 0 for "None".
+func (cd AVDecimal) Float64() float64 {
+	return cd.Value.InexactFloat64()
+}
+
+// String returns the exact value or "None".
+func (cd AVDecimal) String() string {
+	if cd.IsNull() {
+		return "None"
+	}
+	return cd.Value.String()
+}
+
+// Scan implements sql.Scanner, NULL is "None".  Text columns keep the
+// exact value.
+func (cd *AVDecimal) Scan(src interface{}) error {
+	if src == nil {
+		*cd = AVDecimal{}
+		return nil
+	}
+	var d decimal.Decimal
+	if err := d.Scan(src); err != nil {
+		return err
+	}
+	*cd = AVDecimal{Value: d, Valid: true}
+	return nil
+}
+
+// NullString returns the exact value as sql.NullString, which implements
+// driver.Valuer.
+func (cd AVDecimal) NullString() sql.NullString {
+	return sql.NullString{String: cd.Value.String(), Valid: !cd.IsNull()}
+}
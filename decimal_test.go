@@ -0,0 +1,69 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+	"github.com/shopspring/decimal"
+)
+
+func TestAVDecimalUnmarshal(t *testing.T) {
+	var values struct {
+		Price   AVDecimal `json:"price"`
+		Number  AVDecimal `json:"number"`
+		Percent AVDecimal `json:"percent"`
+		Zero    AVDecimal `json:"zero"`
+		Missing AVDecimal `json:"missing"`
+		Dash    AVDecimal `json:"dash"`
+		Null    AVDecimal `json:"null"`
+	}
+	data := `{"price":"123.4500","number":0.1,"percent":"-1.2345%","zero":"0","missing":"None","dash":"-","null":null}`
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(data), &values))
+
+	assert.EqualStrings(t, "123.45", values.Price.String())
+	assert.EqualDecimal(t, decimal.RequireFromString("0.1"), values.Number.Value)
+	assert.EqualDecimal(t, decimal.RequireFromString("-1.2345"), values.Percent.Value)
+	assert.False(t, values.Zero.IsNull())
+	assert.True(t, values.Missing.IsNull())
+	assert.True(t, values.Dash.IsNull())
+	assert.True(t, values.Null.IsNull())
+	assert.EqualStrings(t, "None", values.Missing.String())
+
+	buf, err := json.Marshal(values)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, `{"price":"123.45","number":"0.1","percent":"-1.2345","zero":"0","missing":"None","dash":"None","null":"None"}`, string(buf))
+}
+
+func TestAVDecimalUnmarshalInvalid(t *testing.T) {
+	var value AVDecimal
+	err := json.Unmarshal([]byte(`"abc"`), &value)
+	assert.ErrorIncludesMessage(t, "unexpected string value: abc", err)
+}
+
+func TestAVDecimalExact(t *testing.T) {
+	var a, b AVDecimal
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(`"0.1"`), &a))
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(`"0.2"`), &b))
+	assert.EqualStrings(t, "0.3", a.Value.Add(b.Value).String())
+	assert.EqualFloat64(t, 0.1, a.Float64())
+}
+
+func TestAVDecimalScan(t *testing.T) {
+	var value AVDecimal
+	assert.NoError(t.Fatalf, value.Scan("123456789012.345678"))
+	assert.EqualStrings(t, "123456789012.345678", value.String())
+	assert.EqualStrings(t, "123456789012.345678", value.NullString().String)
+	assert.True(t, value.NullString().Valid)
+
+	assert.NoError(t.Fatalf, value.Scan(nil))
+	assert.True(t, value.IsNull())
+	assert.False(t, value.NullString().Valid)
+
+	csv, err := NewAVDecimal(decimal.Zero).MarshalCSV()
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "0", string(csv))
+	csv, err = AVDecimal{}.MarshalCSV()
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "None", string(csv))
+}
@@ -0,0 +1,446 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// The decimal variants have the same JSON fields as the float based
+// types but keep the exact values of prices, EPS, strikes and statement
+// values, e.g. for accounting reconciliation.
+
+// TimeSeriesDataDecimal is TimeSeriesData with exact prices.
+type TimeSeriesDataDecimal struct {
+	Open   AVDecimal `json:"1. open" validate:"required"`
+	High   AVDecimal `json:"2. high" validate:"required"`
+	Low    AVDecimal `json:"3. low" validate:"required"`
+	Close  AVDecimal `json:"4. close" validate:"required"`
+	Volume uint64    `json:"5. volume,string"`
+}
+
+// TimeSeriesDecimal is TimeSeries with exact prices.
+type TimeSeriesDecimal struct {
+	Metadata          TimeSeriesMetadata               `json:"Meta Data"`
+	TimeSeriesDaily   map[string]TimeSeriesDataDecimal `json:"Time Series (Daily)"`
+	TimeSeriesWeekly  map[string]TimeSeriesDataDecimal `json:"Weekly Time Series"`
+	TimeSeriesMonthly map[string]TimeSeriesDataDecimal `json:"Monthly Time Series"`
+}
+
+// TimeSeriesAdjustedDataDecimal is TimeSeriesAdjustedData with exact
+// prices.
+type TimeSeriesAdjustedDataDecimal struct {
+	Open             AVDecimal `json:"1. open" validate:"required"`
+	High             AVDecimal `json:"2. high" validate:"required"`
+	Low              AVDecimal `json:"3. low" validate:"required"`
+	Close            AVDecimal `json:"4. close" validate:"required"`
+	AdjustedClose    AVDecimal `json:"5. adjusted close" validate:"required"`
+	Volume           uint64    `json:"6. volume,string"`
+	DividendAmount   AVDecimal `json:"7. dividend amount"`
+	SplitCoefficient AVDecimal `json:"8. split coefficient"`
+}
+
+// TimeSeriesAdjustedDecimal is TimeSeriesAdjusted with exact prices.
+type TimeSeriesAdjustedDecimal struct {
+	Metadata          TimeSeriesMetadata                       `json:"Meta Data"`
+	TimeSeriesDaily   map[string]TimeSeriesAdjustedDataDecimal `json:"Time Series (Daily)"`
+	TimeSeriesWeekly  map[string]TimeSeriesAdjustedDataDecimal `json:"Weekly Adjusted Time Series"`
+	TimeSeriesMonthly map[string]TimeSeriesAdjustedDataDecimal `json:"Monthly Adjusted Time Series"`
+}
+
+// GlobalQuoteDecimal is GlobalQuote with exact prices.
+type GlobalQuoteDecimal struct {
+	Symbol           string    `json:"01. symbol"`
+	Open             AVDecimal `json:"02. open"`
+	High             AVDecimal `json:"03. high"`
+	Low              AVDecimal `json:"04. low"`
+	Price            AVDecimal `json:"05. price"`
+	Volume           int       `json:"06. volume,string"`
+	LatestTradingDay string    `json:"07. latest trading day"`
+	PreviousClose    AVDecimal `json:"08. previous close"`
+	Change           AVDecimal `json:"09. change"`
+	ChangePercent    AVDecimal `json:"10. change percent"`
+}
+
+// OptionContractDecimal is OptionContract with exact prices and greeks.
+type OptionContractDecimal struct {
+	ContractID        string    `json:"contractID" validate:"required"`
+	Symbol            string    `json:"symbol"`
+	Expiration        string    `json:"expiration" validate:"required"`
+	Strike            AVDecimal `json:"strike" validate:"required"`
+	Type              string    `json:"type"` // "call" or "put"
+	Last              AVDecimal `json:"last"`
+	Mark              AVDecimal `json:"mark"`
+	Bid               AVDecimal `json:"bid"`
+	BidSize           AVInt     `json:"bid_size"`
+	Ask               AVDecimal `json:"ask"`
+	AskSize           AVInt     `json:"ask_size"`
+	Volume            AVInt     `json:"volume"`
+	OpenInterest      AVInt     `json:"open_interest"`
+	Date              string    `json:"date"`
+	ImpliedVolatility AVDecimal `json:"implied_volatility"`
+	Delta             AVDecimal `json:"delta"`
+	Gamma             AVDecimal `json:"gamma"`
+	Theta             AVDecimal `json:"theta"`
+	Vega              AVDecimal `json:"vega"`
+	Rho               AVDecimal `json:"rho"`
+}
+
+// HistoricalOptionsDataDecimal is HistoricalOptionsData with exact
+// prices and greeks.
+type HistoricalOptionsDataDecimal struct {
+	Symbol   string                  `json:"symbol"`
+	Endpoint string                  `json:"endpoint"`
+	Message  string                  `json:"message"`
+	Data     []OptionContractDecimal `json:"data"`
+}
+
+// AnnualEarningsDecimal is AnnualEarnings with exact EPS.
+type AnnualEarningsDecimal struct {
+	FiscalDateEnding string    `json:"fiscalDateEnding" validate:"required"`
+	ReportedEPS      AVDecimal `json:"reportedEPS"`
+}
+
+// QuarterlyEarningsDecimal is QuarterlyEarnings with exact EPS.
+type QuarterlyEarningsDecimal struct {
+	FiscalDateEnding   string    `json:"fiscalDateEnding" validate:"required"`
+	ReportedDate       string    `json:"reportedDate"`
+	ReportedEPS        AVDecimal `json:"reportedEPS"`
+	EstimatedEPS       AVDecimal `json:"estimatedEPS"`
+	Surprise           AVDecimal `json:"surprise"`
+	SurprisePercentage AVDecimal `json:"surprisePercentage"`
+}
+
+// EarningsDecimal is Earnings with exact EPS.
+type EarningsDecimal struct {
+	Symbol            string                     `json:"symbol" validate:"required"`
+	AnnualEarnings    []AnnualEarningsDecimal    `json:"annualEarnings"`
+	QuarterlyEarnings []QuarterlyEarningsDecimal `json:"quarterlyEarnings"`
+}
+
+// BalanceSheetDecimal is BalanceSheet with exact values.
+type BalanceSheetDecimal struct {
+	Symbol           string                     `json:"symbol" validate:"required"`
+	AnnualReports    []BsAnnualReportDecimal    `json:"annualReports"`
+	QuarterlyReports []BsQuarterlyReportDecimal `json:"quarterlyReports"`
+}
+
+// BsAnnualReportDecimal is BsAnnualReport with exact values.
+type BsAnnualReportDecimal struct {
+	FiscalDateEnding                       string    `json:"fiscalDateEnding" validate:"required"`
+	ReportedCurrency                       string    `json:"reportedCurrency"`
+	TotalAssets                            AVDecimal `json:"totalAssets"`
+	TotalCurrentAssets                     AVDecimal `json:"totalCurrentAssets"`
+	CashAndCashEquivalentsAtCarryingValue  AVDecimal `json:"cashAndCashEquivalentsAtCarryingValue"`
+	CashAndShortTermInvestments            AVDecimal `json:"cashAndShortTermInvestments"`
+	Inventory                              AVDecimal `json:"inventory"`
+	CurrentNetReceivables                  AVDecimal `json:"currentNetReceivables"`
+	TotalNonCurrentAssets                  AVDecimal `json:"totalNonCurrentAssets"`
+	PropertyPlantEquipment                 AVDecimal `json:"propertyPlantEquipment"`
+	AccumulatedDepreciationAmortizationPpe AVDecimal `json:"accumulatedDepreciationAmortizationPPE"`
+	IntangibleAssets                       AVDecimal `json:"intangibleAssets"`
+	IntangibleAssetsExcludingGoodwill      AVDecimal `json:"intangibleAssetsExcludingGoodwill"`
+	Goodwill                               AVDecimal `json:"goodwill"`
+	Investments                            AVDecimal `json:"investments"`
+	LongTermInvestments                    AVDecimal `json:"longTermInvestments"`
+	ShortTermInvestments                   AVDecimal `json:"shortTermInvestments"`
+	OtherCurrentAssets                     AVDecimal `json:"otherCurrentAssets"`
+	OtherNonCurrentAssets                  AVDecimal `json:"otherNonCurrentAssets"`
+	TotalLiabilities                       AVDecimal `json:"totalLiabilities"`
+	TotalCurrentLiabilities                AVDecimal `json:"totalCurrentLiabilities"`
+	CurrentAccountsPayable                 AVDecimal `json:"currentAccountsPayable"`
+	DeferredRevenue                        AVDecimal `json:"deferredRevenue"`
+	CurrentDebt                            AVDecimal `json:"currentDebt"`
+	ShortTermDebt                          AVDecimal `json:"shortTermDebt"`
+	TotalNonCurrentLiabilities             AVDecimal `json:"totalNonCurrentLiabilities"`
+	CapitalLeaseObligations                AVDecimal `json:"capitalLeaseObligations"`
+	LongTermDebt                           AVDecimal `json:"longTermDebt"`
+	CurrentLongTermDebt                    AVDecimal `json:"currentLongTermDebt"`
+	LongTermDebtNoncurrent                 AVDecimal `json:"longTermDebtNoncurrent"`
+	ShortLongTermDebtTotal                 AVDecimal `json:"shortLongTermDebtTotal"`
+	OtherCurrentLiabilities                AVDecimal `json:"otherCurrentLiabilities"`
+	OtherNonCurrentLiabilities             AVDecimal `json:"otherNonCurrentLiabilities"`
+	TotalShareholderEquity                 AVDecimal `json:"totalShareholderEquity"`
+	TreasuryStock                          AVDecimal `json:"treasuryStock"`
+	RetainedEarnings                       AVDecimal `json:"retainedEarnings"`
+	CommonStock                            AVDecimal `json:"commonStock"`
+	CommonStockSharesOutstanding           AVDecimal `json:"commonStockSharesOutstanding"`
+}
+
+// BsQuarterlyReportDecimal is BsQuarterlyReport with exact values.
+type BsQuarterlyReportDecimal struct {
+	FiscalDateEnding                       string    `json:"fiscalDateEnding" validate:"required"`
+	ReportedCurrency                       string    `json:"reportedCurrency"`
+	TotalAssets                            AVDecimal `json:"totalAssets"`
+	TotalCurrentAssets                     AVDecimal `json:"totalCurrentAssets"`
+	CashAndCashEquivalentsAtCarryingValue  AVDecimal `json:"cashAndCashEquivalentsAtCarryingValue"`
+	CashAndShortTermInvestments            AVDecimal `json:"cashAndShortTermInvestments"`
+	Inventory                              AVDecimal `json:"inventory"`
+	CurrentNetReceivables                  AVDecimal `json:"currentNetReceivables"`
+	TotalNonCurrentAssets                  AVDecimal `json:"totalNonCurrentAssets"`
+	PropertyPlantEquipment                 AVDecimal `json:"propertyPlantEquipment"`
+	AccumulatedDepreciationAmortizationPpe AVDecimal `json:"accumulatedDepreciationAmortizationPPE"`
+	IntangibleAssets                       AVDecimal `json:"intangibleAssets"`
+	IntangibleAssetsExcludingGoodwill      AVDecimal `json:"intangibleAssetsExcludingGoodwill"`
+	Goodwill                               AVDecimal `json:"goodwill"`
+	Investments                            AVDecimal `json:"investments"`
+	LongTermInvestments                    AVDecimal `json:"longTermInvestments"`
+	ShortTermInvestments                   AVDecimal `json:"shortTermInvestments"`
+	OtherCurrentAssets                     AVDecimal `json:"otherCurrentAssets"`
+	OtherNonCurrentAssets                  AVDecimal `json:"otherNonCurrentAssets"`
+	TotalLiabilities                       AVDecimal `json:"totalLiabilities"`
+	TotalCurrentLiabilities                AVDecimal `json:"totalCurrentLiabilities"`
+	CurrentAccountsPayable                 AVDecimal `json:"currentAccountsPayable"`
+	DeferredRevenue                        AVDecimal `json:"deferredRevenue"`
+	CurrentDebt                            AVDecimal `json:"currentDebt"`
+	ShortTermDebt                          AVDecimal `json:"shortTermDebt"`
+	TotalNonCurrentLiabilities             AVDecimal `json:"totalNonCurrentLiabilities"`
+	CapitalLeaseObligations                AVDecimal `json:"capitalLeaseObligations"`
+	LongTermDebt                           AVDecimal `json:"longTermDebt"`
+	CurrentLongTermDebt                    AVDecimal `json:"currentLongTermDebt"`
+	LongTermDebtNoncurrent                 AVDecimal `json:"longTermDebtNoncurrent"`
+	ShortLongTermDebtTotal                 AVDecimal `json:"shortLongTermDebtTotal"`
+	OtherCurrentLiabilities                AVDecimal `json:"otherCurrentLiabilities"`
+	OtherNonCurrentLiabilities             AVDecimal `json:"otherNonCurrentLiabilities"`
+	TotalShareholderEquity                 AVDecimal `json:"totalShareholderEquity"`
+	TreasuryStock                          AVDecimal `json:"treasuryStock"`
+	RetainedEarnings                       AVDecimal `json:"retainedEarnings"`
+	CommonStock                            AVDecimal `json:"commonStock"`
+	CommonStockSharesOutstanding           AVDecimal `json:"commonStockSharesOutstanding"`
+}
+
+// IncomeStatementDecimal is IncomeStatement with exact values.
+type IncomeStatementDecimal struct {
+	Symbol           string                     `json:"symbol" validate:"required"`
+	AnnualReports    []IsAnnualReportDecimal    `json:"annualReports"`
+	QuarterlyReports []IsQuarterlyReportDecimal `json:"quarterlyReports"`
+}
+
+// IsAnnualReportDecimal is IsAnnualReport with exact values.
+type IsAnnualReportDecimal struct {
+	FiscalDateEnding                  string    `json:"fiscalDateEnding" validate:"required"`
+	ReportedCurrency                  string    `json:"reportedCurrency"`
+	GrossProfit                       AVDecimal `json:"grossProfit"`
+	TotalRevenue                      AVDecimal `json:"totalRevenue"`
+	CostOfRevenue                     AVDecimal `json:"costOfRevenue"`
+	CostOfGoodsAndServicesSold        AVDecimal `json:"costofGoodsAndServicesSold"`
+	OperatingIncome                   AVDecimal `json:"operatingIncome"`
+	SellingGeneralAndAdministrative   AVDecimal `json:"sellingGeneralAndAdministrative"`
+	ResearchAndDevelopment            AVDecimal `json:"researchAndDevelopment"`
+	OperatingExpenses                 AVDecimal `json:"operatingExpenses"`
+	InvestmentIncomeNet               AVDecimal `json:"investmentIncomeNet"`
+	NetInterestIncome                 AVDecimal `json:"netInterestIncome"`
+	InterestIncome                    AVDecimal `json:"interestIncome"`
+	InterestExpense                   AVDecimal `json:"interestExpense"`
+	NonInterestIncome                 AVDecimal `json:"nonInterestIncome"`
+	OtherNonOperatingIncome           AVDecimal `json:"otherNonOperatingIncome"`
+	Depreciation                      AVDecimal `json:"depreciation"`
+	DepreciationAndAmortization       AVDecimal `json:"depreciationAndAmortization"`
+	IncomeBeforeTax                   AVDecimal `json:"incomeBeforeTax"`
+	IncomeTaxExpense                  AVDecimal `json:"incomeTaxExpense"`
+	InterestAndDebtExpense            AVDecimal `json:"interestAndDebtExpense"`
+	NetIncomeFromContinuingOperations AVDecimal `json:"netIncomeFromContinuingOperations"`
+	ComprehensiveIncomeNetOfTax       AVDecimal `json:"comprehensiveIncomeNetOfTax"`
+	Ebit                              AVDecimal `json:"ebit"`
+	Ebitda                            AVDecimal `json:"ebitda"`
+	NetIncome                         AVDecimal `json:"netIncome"`
+}
+
+// IsQuarterlyReportDecimal is IsQuarterlyReport with exact values.
+type IsQuarterlyReportDecimal struct {
+	FiscalDateEnding                  string    `json:"fiscalDateEnding" validate:"required"`
+	ReportedCurrency                  string    `json:"reportedCurrency"`
+	GrossProfit                       AVDecimal `json:"grossProfit"`
+	TotalRevenue                      AVDecimal `json:"totalRevenue"`
+	CostOfRevenue                     AVDecimal `json:"costOfRevenue"`
+	CostOfGoodsAndServicesSold        AVDecimal `json:"costofGoodsAndServicesSold"`
+	OperatingIncome                   AVDecimal `json:"operatingIncome"`
+	SellingGeneralAndAdministrative   AVDecimal `json:"sellingGeneralAndAdministrative"`
+	ResearchAndDevelopment            AVDecimal `json:"researchAndDevelopment"`
+	OperatingExpenses                 AVDecimal `json:"operatingExpenses"`
+	InvestmentIncomeNet               AVDecimal `json:"investmentIncomeNet"`
+	NetInterestIncome                 AVDecimal `json:"netInterestIncome"`
+	InterestIncome                    AVDecimal `json:"interestIncome"`
+	InterestExpense                   AVDecimal `json:"interestExpense"`
+	NonInterestIncome                 AVDecimal `json:"nonInterestIncome"`
+	OtherNonOperatingIncome           AVDecimal `json:"otherNonOperatingIncome"`
+	Depreciation                      AVDecimal `json:"depreciation"`
+	DepreciationAndAmortization       AVDecimal `json:"depreciationAndAmortization"`
+	IncomeBeforeTax                   AVDecimal `json:"incomeBeforeTax"`
+	IncomeTaxExpense                  AVDecimal `json:"incomeTaxExpense"`
+	InterestAndDebtExpense            AVDecimal `json:"interestAndDebtExpense"`
+	NetIncomeFromContinuingOperations AVDecimal `json:"netIncomeFromContinuingOperations"`
+	ComprehensiveIncomeNetOfTax       AVDecimal `json:"comprehensiveIncomeNetOfTax"`
+	Ebit                              AVDecimal `json:"ebit"`
+	Ebitda                            AVDecimal `json:"ebitda"`
+	NetIncome                         AVDecimal `json:"netIncome"`
+}
+
+// CashFlowDecimal is CashFlow with exact values.
+type CashFlowDecimal struct {
+	Symbol           string                     `json:"symbol" validate:"required"`
+	AnnualReports    []CfAnnualReportDecimal    `json:"annualReports"`
+	QuarterlyReports []CfQuarterlyReportDecimal `json:"quarterlyReports"`
+}
+
+// CfAnnualReportDecimal is CfAnnualReport with exact values.
+type CfAnnualReportDecimal struct {
+	FiscalDateEnding                                          string    `json:"fiscalDateEnding" validate:"required"`
+	ReportedCurrency                                          string    `json:"reportedCurrency"`
+	OperatingCashflow                                         AVDecimal `json:"operatingCashflow"`
+	PaymentsForOperatingActivities                            AVDecimal `json:"paymentsForOperatingActivities"`
+	ProceedsFromOperatingActivities                           AVDecimal `json:"proceedsFromOperatingActivities"`
+	ChangeInOperatingLiabilities                              AVDecimal `json:"changeInOperatingLiabilities"`
+	ChangeInOperatingAssets                                   AVDecimal `json:"changeInOperatingAssets"`
+	DepreciationDepletionAndAmortization                      AVDecimal `json:"depreciationDepletionAndAmortization"`
+	CapitalExpenditures                                       AVDecimal `json:"capitalExpenditures"`
+	ChangeInReceivables                                       AVDecimal `json:"changeInReceivables"`
+	ChangeInInventory                                         AVDecimal `json:"changeInInventory"`
+	ProfitLoss                                                AVDecimal `json:"profitLoss"`
+	CashflowFromInvestment                                    AVDecimal `json:"cashflowFromInvestment"`
+	CashflowFromFinancing                                     AVDecimal `json:"cashflowFromFinancing"`
+	ProceedsFromRepaymentsOfShortTermDebt                     AVDecimal `json:"proceedsFromRepaymentsOfShortTermDebt"`
+	PaymentsForRepurchaseOfCommonStock                        AVDecimal `json:"paymentsForRepurchaseOfCommonStock"`
+	PaymentsForRepurchaseOfEquity                             AVDecimal `json:"paymentsForRepurchaseOfEquity"`
+	PaymentsForRepurchaseOfPreferredStock                     AVDecimal `json:"paymentsForRepurchaseOfPreferredStock"`
+	DividendPayout                                            AVDecimal `json:"dividendPayout"`
+	DividendPayoutCommonStock                                 AVDecimal `json:"dividendPayoutCommonStock"`
+	DividendPayoutPreferredStock                              AVDecimal `json:"dividendPayoutPreferredStock"`
+	ProceedsFromIssuanceOfCommonStock                         AVDecimal `json:"proceedsFromIssuanceOfCommonStock"`
+	ProceedsFromIssuanceOfLongTermDebtAndCapitalSecuritiesNet AVDecimal `json:"proceedsFromIssuanceOfLongTermDebtAndCapitalSecuritiesNet"`
+	ProceedsFromIssuanceOfPreferredStock                      AVDecimal `json:"proceedsFromIssuanceOfPreferredStock"`
+	ProceedsFromRepurchaseOfEquity                            AVDecimal `json:"proceedsFromRepurchaseOfEquity"`
+	ProceedsFromSaleOfTreasuryStock                           AVDecimal `json:"proceedsFromSaleOfTreasuryStock"`
+	ChangeInCashAndCashEquivalents                            AVDecimal `json:"changeInCashAndCashEquivalents"`
+	ChangeInExchangeRate                                      AVDecimal `json:"changeInExchangeRate"`
+	NetIncome                                                 AVDecimal `json:"netIncome"`
+}
+
+// CfQuarterlyReportDecimal is CfQuarterlyReport with exact values.
+type CfQuarterlyReportDecimal struct {
+	FiscalDateEnding                                          string    `json:"fiscalDateEnding" validate:"required"`
+	ReportedCurrency                                          string    `json:"reportedCurrency"`
+	OperatingCashflow                                         AVDecimal `json:"operatingCashflow"`
+	PaymentsForOperatingActivities                            AVDecimal `json:"paymentsForOperatingActivities"`
+	ProceedsFromOperatingActivities                           AVDecimal `json:"proceedsFromOperatingActivities"`
+	ChangeInOperatingLiabilities                              AVDecimal `json:"changeInOperatingLiabilities"`
+	ChangeInOperatingAssets                                   AVDecimal `json:"changeInOperatingAssets"`
+	DepreciationDepletionAndAmortization                      AVDecimal `json:"depreciationDepletionAndAmortization"`
+	CapitalExpenditures                                       AVDecimal `json:"capitalExpenditures"`
+	ChangeInReceivables                                       AVDecimal `json:"changeInReceivables"`
+	ChangeInInventory                                         AVDecimal `json:"changeInInventory"`
+	ProfitLoss                                                AVDecimal `json:"profitLoss"`
+	CashflowFromInvestment                                    AVDecimal `json:"cashflowFromInvestment"`
+	CashflowFromFinancing                                     AVDecimal `json:"cashflowFromFinancing"`
+	ProceedsFromRepaymentsOfShortTermDebt                     AVDecimal `json:"proceedsFromRepaymentsOfShortTermDebt"`
+	PaymentsForRepurchaseOfCommonStock                        AVDecimal `json:"paymentsForRepurchaseOfCommonStock"`
+	PaymentsForRepurchaseOfEquity                             AVDecimal `json:"paymentsForRepurchaseOfEquity"`
+	PaymentsForRepurchaseOfPreferredStock                     AVDecimal `json:"paymentsForRepurchaseOfPreferredStock"`
+	DividendPayout                                            AVDecimal `json:"dividendPayout"`
+	DividendPayoutCommonStock                                 AVDecimal `json:"dividendPayoutCommonStock"`
+	DividendPayoutPreferredStock                              AVDecimal `json:"dividendPayoutPreferredStock"`
+	ProceedsFromIssuanceOfCommonStock                         AVDecimal `json:"proceedsFromIssuanceOfCommonStock"`
+	ProceedsFromIssuanceOfLongTermDebtAndCapitalSecuritiesNet AVDecimal `json:"proceedsFromIssuanceOfLongTermDebtAndCapitalSecuritiesNet"`
+	ProceedsFromIssuanceOfPreferredStock                      AVDecimal `json:"proceedsFromIssuanceOfPreferredStock"`
+	ProceedsFromRepurchaseOfEquity                            AVDecimal `json:"proceedsFromRepurchaseOfEquity"`
+	ProceedsFromSaleOfTreasuryStock                           AVDecimal `json:"proceedsFromSaleOfTreasuryStock"`
+	ChangeInCashAndCashEquivalents                            AVDecimal `json:"changeInCashAndCashEquivalents"`
+	ChangeInExchangeRate                                      AVDecimal `json:"changeInExchangeRate"`
+	NetIncome                                                 AVDecimal `json:"netIncome"`
+}
+
+func toDecimal[T any](buf []byte) (*T, error) {
+	v := new(T)
+	if err := json.Unmarshal(buf, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type globalQuoteDecimalResponse struct {
+	GlobalQuote GlobalQuoteDecimal `json:"Global Quote"`
+}
+
+// fetchDecimal requests the url of the function and parses the response
+// into T.
+func fetchDecimal[T any](c *Client, function, url string) (*T, error) {
+	body, err := c.makeHTTPRequest(url)
+	if err != nil {
+		return nil, err
+	}
+	v, err := toDecimal[T](body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := c.checkResponse(function, body, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// TimeSeriesDecimal fetches the time series like TimeSeries with exact
+// prices.
+func (c *Client) TimeSeriesDecimal(symbol string, interval TimeSeriesInterval, outputSize OutputSize) (*TimeSeriesDecimal, error) {
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s&outputsize=%s", baseURL, interval, symbol, c.apiKey, outputSize)
+	return fetchDecimal[TimeSeriesDecimal](c, string(interval), url)
+}
+
+// TimeSeriesAdjustedDecimal fetches the adjusted time series like
+// TimeSeriesAdjusted with exact prices.
+func (c *Client) TimeSeriesAdjustedDecimal(symbol string, interval TimeSeriesIntervalAdjusted, outputSize OutputSize) (*TimeSeriesAdjustedDecimal, error) {
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s&outputsize=%s", baseURL, interval, symbol, c.apiKey, outputSize)
+	return fetchDecimal[TimeSeriesAdjustedDecimal](c, string(interval), url)
+}
+
+// GlobalQuoteDecimal fetches the quote like GlobalQuote with exact prices.
+func (c *Client) GlobalQuoteDecimal(symbol string) (*GlobalQuoteDecimal, error) {
+	const functionName = "GLOBAL_QUOTE"
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s", baseURL, functionName, symbol, c.apiKey)
+	response, err := fetchDecimal[globalQuoteDecimalResponse](c, functionName, url)
+	if err != nil {
+		return nil, err
+	}
+	return &response.GlobalQuote, nil
+}
+
+// HistoricalOptionsDecimal fetches the options like HistoricalOptions
+// with exact prices and greeks.
+func (c *Client) HistoricalOptionsDecimal(symbol string, date *time.Time) (*HistoricalOptionsDataDecimal, error) {
+	const function = "HISTORICAL_OPTIONS"
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s", baseURL, function, symbol, c.apiKey)
+	if date != nil {
+		url = fmt.Sprintf("%s&date=%s", url, date.Format(DateFormat))
+	}
+	return fetchDecimal[HistoricalOptionsDataDecimal](c, function, url)
+}
+
+// EarningsDecimal fetches the earnings like Earnings with exact EPS.
+func (c *Client) EarningsDecimal(symbol string) (*EarningsDecimal, error) {
+	const function = "EARNINGS"
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s", baseURL, function, symbol, c.apiKey)
+	return fetchDecimal[EarningsDecimal](c, function, url)
+}
+
+// BalanceSheetDecimal fetches the balance sheet like BalanceSheet with
+// exact values.
+func (c *Client) BalanceSheetDecimal(symbol string) (*BalanceSheetDecimal, error) {
+	const function = "BALANCE_SHEET"
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s", baseURL, function, symbol, c.apiKey)
+	return fetchDecimal[BalanceSheetDecimal](c, function, url)
+}
+
+// IncomeStatementDecimal fetches the income statement like
+// IncomeStatement with exact values.
+func (c *Client) IncomeStatementDecimal(symbol string) (*IncomeStatementDecimal, error) {
+	const function = "INCOME_STATEMENT"
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s", baseURL, function, symbol, c.apiKey)
+	return fetchDecimal[IncomeStatementDecimal](c, function, url)
+}
+
+// CashFlowDecimal fetches the cash flow statement like CashFlow with
+// exact values.
+func (c *Client) CashFlowDecimal(symbol string) (*CashFlowDecimal, error) {
+	const function = "CASH_FLOW"
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s", baseURL, function, symbol, c.apiKey)
+	return fetchDecimal[CashFlowDecimal](c, function, url)
+}
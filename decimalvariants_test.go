@@ -0,0 +1,176 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToTimeSeriesDecimal(t *testing.T) {
+	var buf = `{
+    "Meta Data": {
+        "1. Information": "Daily Prices (open, high, low, close) and Volumes",
+        "2. Symbol": "IBM",
+        "3. Last Refreshed": "2024-03-28",
+        "4. Output Size": "Compact",
+        "5. Time Zone": "US/Eastern"
+    },
+    "Time Series (Daily)": {
+        "2024-03-28": {
+            "1. open": "190.9400",
+            "2. high": "191.9300",
+            "3. low": "190.3400",
+            "4. close": "190.9600",
+            "5. volume": "3742169"
+        }
+    }
+}`
+	timeSeries, err := toDecimal[TimeSeriesDecimal]([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "TIME_SERIES_DAILY", buf, timeSeries)
+	data := timeSeries.TimeSeriesDaily["2024-03-28"]
+	assert.EqualStrings(t, "190.94", data.Open.String())
+	assert.EqualStrings(t, "190.96", data.Close.String())
+	assert.EqualInt(t, 3742169, int(data.Volume))
+}
+
+func TestToGlobalQuoteDecimal(t *testing.T) {
+	var buf = `{
+    "Global Quote": {
+        "01. symbol": "IBM",
+        "02. open": "190.9400",
+        "03. high": "191.9300",
+        "04. low": "190.3400",
+        "05. price": "190.9600",
+        "06. volume": "3742169",
+        "07. latest trading day": "2024-03-28",
+        "08. previous close": "190.8000",
+        "09. change": "0.1600",
+        "10. change percent": "0.0839%"
+    }
+}`
+	response, err := toDecimal[globalQuoteDecimalResponse]([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "GLOBAL_QUOTE", buf, response)
+	quote := response.GlobalQuote
+	assert.EqualStrings(t, "IBM", quote.Symbol)
+	assert.EqualStrings(t, "190.96", quote.Price.String())
+	assert.EqualStrings(t, "0.16", quote.Price.Value.Sub(quote.PreviousClose.Value).String())
+	assert.EqualStrings(t, "0.0839", quote.ChangePercent.String())
+	assert.EqualInt(t, 3742169, quote.Volume)
+}
+
+func TestToHistoricalOptionsDecimal(t *testing.T) {
+	var buf = `{
+    "endpoint": "Historical Options",
+    "message": "success",
+    "data": [
+        {
+            "contractID": "IBM240419C00100000",
+            "symbol": "IBM",
+            "expiration": "2024-04-19",
+            "strike": "102.50",
+            "type": "call",
+            "last": "0.00",
+            "mark": "88.35",
+            "bid": "87.50",
+            "bid_size": "10",
+            "ask": "89.20",
+            "ask_size": "10",
+            "volume": "0",
+            "open_interest": "0",
+            "date": "2024-03-28",
+            "implied_volatility": "0.96",
+            "delta": "1.00000",
+            "gamma": "0.00000",
+            "theta": "-0.00539",
+            "vega": "0.00000",
+            "rho": "None"
+        }
+    ]
+}`
+	options, err := toDecimal[HistoricalOptionsDataDecimal]([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "HISTORICAL_OPTIONS", buf, options)
+	assert.EqualInt(t, 1, len(options.Data))
+	contract := options.Data[0]
+	assert.EqualStrings(t, "102.5", contract.Strike.String())
+	assert.False(t, contract.Last.IsNull())
+	assert.EqualStrings(t, "-0.00539", contract.Theta.String())
+	assert.True(t, contract.Rho.IsNull())
+	assert.EqualInt(t, 10, contract.BidSize.Value)
+}
+
+func TestToEarningsDecimal(t *testing.T) {
+	var buf = `{
+    "symbol": "IBM",
+    "annualEarnings": [
+        {"fiscalDateEnding": "2023-12-31", "reportedEPS": "9.61"}
+    ],
+    "quarterlyEarnings": [
+        {
+            "fiscalDateEnding": "2023-12-31",
+            "reportedDate": "2024-01-24",
+            "reportedEPS": "3.87",
+            "estimatedEPS": "3.78",
+            "surprise": "0.09",
+            "surprisePercentage": "2.381"
+        },
+        {
+            "fiscalDateEnding": "2023-09-30",
+            "reportedDate": "2023-10-25",
+            "reportedEPS": "2.2",
+            "estimatedEPS": "None",
+            "surprise": "None",
+            "surprisePercentage": "None"
+        }
+    ]
+}`
+	earnings, err := toDecimal[EarningsDecimal]([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "EARNINGS", buf, earnings)
+	assert.EqualStrings(t, "9.61", earnings.AnnualEarnings[0].ReportedEPS.String())
+	quarter := earnings.QuarterlyEarnings[0]
+	assert.EqualStrings(t, "0.09", quarter.ReportedEPS.Value.Sub(quarter.EstimatedEPS.Value).String())
+	assert.True(t, earnings.QuarterlyEarnings[1].EstimatedEPS.IsNull())
+}
+
+func TestToStatementDecimal(t *testing.T) {
+	var buf = `{
+    "symbol": "IBM",
+    "annualReports": [
+        {
+            "fiscalDateEnding": "2023-12-31",
+            "reportedCurrency": "USD",
+            "totalAssets": "135241000000",
+            "inventory": "1160500000.5",
+            "goodwill": "None"
+        }
+    ],
+    "quarterlyReports": []
+}`
+	balanceSheet, err := toDecimal[BalanceSheetDecimal]([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "BALANCE_SHEET", buf, balanceSheet)
+	assert.EqualStrings(t, "IBM", balanceSheet.Symbol)
+	report := balanceSheet.AnnualReports[0]
+	assert.EqualStrings(t, "2023-12-31", report.FiscalDateEnding)
+	assert.EqualStrings(t, "USD", report.ReportedCurrency)
+	assert.EqualStrings(t, "135241000000", report.TotalAssets.String())
+	assert.EqualStrings(t, "1160500000.5", report.Inventory.String())
+	assert.True(t, report.Goodwill.IsNull())
+	assert.True(t, report.TreasuryStock.IsNull())
+
+	out, err := json.Marshal(report)
+	assert.NoError(t.Fatalf, err)
+	var decoded BsAnnualReportDecimal
+	assert.NoError(t.Fatalf, json.Unmarshal(out, &decoded))
+	assert.EqualStrings(t, "1160500000.5", decoded.Inventory.String())
+	assert.True(t, decoded.Goodwill.IsNull())
+
+	_, err = toDecimal[IncomeStatementDecimal]([]byte(`{"symbol": "IBM", "annualReports": [{"fiscalDateEnding": "2023-12-31", "netIncome": "7502000000"}]}`))
+	assert.NoError(t, err)
+	_, err = toDecimal[CashFlowDecimal]([]byte(`{"symbol": "IBM", "annualReports": [{"fiscalDateEnding": "2023-12-31", "netIncome": "7.502e9x"}]}`))
+	assert.ErrorIncludesMessage(t, "unexpected string value: 7.502e9x", err)
+}
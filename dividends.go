@@ -0,0 +1,62 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Dividend represents a single dividend distribution.
+type Dividend struct {
+	ExDividendDate  AVDate    `json:"ex_dividend_date"`
+	DeclarationDate AVDate    `json:"declaration_date"`
+	RecordDate      AVDate    `json:"record_date"`
+	PaymentDate     AVDate    `json:"payment_date"`
+	Amount          AVFloat64 `json:"amount"`
+}
+
+// Dividends represents the historical and declared future dividend
+// distributions of a company.
+type Dividends struct {
+	Symbol string     `json:"symbol"`
+	Data   []Dividend `json:"data"`
+}
+
+func toDividends(buf []byte) (*Dividends, error) {
+	dividends := &Dividends{}
+	if err := json.Unmarshal(buf, dividends); err != nil {
+		return nil, err
+	}
+	return dividends, nil
+}
+
+// Dividends fetches and returns the dividend history for the specified company symbol.
+func (c *Client) Dividends(symbol string) (*Dividends, error) {
+	const function = "DIVIDENDS"
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s", baseURL, function, symbol, c.apiKey)
+	body, err := c.makeHTTPRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	dividends, err := toDividends(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := c.checkResponse(function, body, dividends); err != nil {
+		return nil, err
+	}
+	return dividends, nil
+}
+
+// ByExDate returns the dividend amounts indexed by ex-dividend date.
+// Several distributions on the same date are summed up.
+func (d *Dividends) ByExDate() map[string]float64 {
+	amounts := make(map[string]float64)
+	for _, dividend := range d.Data {
+		if dividend.ExDividendDate.Value.IsZero() {
+			continue
+		}
+		amounts[dividend.ExDividendDate.String()] += dividend.Amount.Value
+	}
+	return amounts
+}
@@ -0,0 +1,51 @@
+package alphavantage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToDividends(t *testing.T) {
+	var buf = `
+{
+    "symbol": "STOCK1",
+    "data": [
+        {
+            "ex_dividend_date": "2024-05-09",
+            "declaration_date": "2024-04-30",
+            "record_date": "2024-05-10",
+            "payment_date": "2024-06-10",
+            "amount": "1.67"
+        },
+        {
+            "ex_dividend_date": "1962-11-02",
+            "declaration_date": "None",
+            "record_date": "None",
+            "payment_date": "None",
+            "amount": "0.0025"
+        }
+    ]
+}
+`
+	dividends, err := toDividends([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "DIVIDENDS", buf, dividends)
+	assert.EqualStrings(t, "STOCK1", dividends.Symbol)
+	assert.EqualInt(t, 2, len(dividends.Data))
+
+	dividend := dividends.Data[0]
+	assert.EqualTime(t, time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC), dividend.ExDividendDate.Value)
+	assert.EqualTime(t, time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), dividend.DeclarationDate.Value)
+	assert.EqualTime(t, time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC), dividend.RecordDate.Value)
+	assert.EqualTime(t, time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC), dividend.PaymentDate.Value)
+	assert.EqualFloat64(t, 1.67, dividend.Amount.Value)
+
+	old := dividends.Data[1]
+	assert.True(t, old.DeclarationDate.Value.IsZero())
+	assert.EqualStrings(t, "None", old.PaymentDate.String())
+
+	byExDate := dividends.ByExDate()
+	assert.EqualFloat64(t, 0.0025, byExDate["1962-11-02"])
+}
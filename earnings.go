@@ -7,13 +7,13 @@ import (
 
 // AnnualEarnings represents the annual earnings data for a company.
 type AnnualEarnings struct {
-	FiscalDateEnding string    `json:"fiscalDateEnding"`
+	FiscalDateEnding string    `json:"fiscalDateEnding" validate:"required"`
 	ReportedEPS      AVFloat64 `json:"reportedEPS"`
 }
 
 // QuarterlyEarnings represents the quarterly earnings data for a company.
 type QuarterlyEarnings struct {
-	FiscalDateEnding   string    `json:"fiscalDateEnding"`
+	FiscalDateEnding   string    `json:"fiscalDateEnding" validate:"required"`
 	ReportedDate       string    `json:"reportedDate"`
 	ReportedEPS        AVFloat64 `json:"reportedEPS"`
 	EstimatedEPS       AVFloat64 `json:"estimatedEPS"`
@@ -23,7 +23,7 @@ type QuarterlyEarnings struct {
 
 // Earnings represents the earnings data for a company, including both annual and quarterly earnings.
 type Earnings struct {
-	Symbol            string              `json:"symbol"`
+	Symbol            string              `json:"symbol" validate:"required"`
 	AnnualEarnings    []AnnualEarnings    `json:"annualEarnings"`
 	QuarterlyEarnings []QuarterlyEarnings `json:"quarterlyEarnings"`
 }
@@ -45,5 +45,12 @@ func (c *Client) Earnings(symbol string) (*Earnings, error) {
 		return nil, err
 	}
 
-	return toEarnings(body)
+	earnings, err := toEarnings(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkResponse(function, body, earnings); err != nil {
+		return nil, err
+	}
+	return earnings, nil
 }
@@ -34,6 +34,7 @@ func TestToEarnings(t *testing.T) {
 `
 	earnings, err := toEarnings([]byte(buf))
 	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "EARNINGS", buf, earnings)
 
 	assert.EqualStrings(t, "STOCK1", earnings.Symbol)
 
@@ -39,5 +39,12 @@ func (c *Client) EarningsCallTranscript(symbol string, quarter string) (*Earning
 		return nil, err
 	}
 
-	return toEarningsTranscript(body)
+	earningsTranscript, err := toEarningsTranscript(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkResponse(function, body, earningsTranscript); err != nil {
+		return nil, err
+	}
+	return earningsTranscript, nil
 }
@@ -29,6 +29,7 @@ func TestToEarningsTranscript(t *testing.T) {
 `
 	transcript, err := toEarningsTranscript([]byte(buf))
 	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "EARNINGS_CALL_TRANSCRIPT", buf, transcript)
 
 	assert.EqualStrings(t, "STOCK1", transcript.Symbol)
 	assert.EqualStrings(t, "2024Q1", transcript.Quarter)
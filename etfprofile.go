@@ -49,5 +49,12 @@ func (c *Client) ETFProfileData(symbol string) (*ETFProfile, error) {
 		return nil, err
 	}
 
-	return toETFProfile(body)
+	etfProfile, err := toETFProfile(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkResponse(function, body, etfProfile); err != nil {
+		return nil, err
+	}
+	return etfProfile, nil
 }
@@ -32,6 +32,7 @@ func TestToETFProfile(t *testing.T) {
 `
 	etfProfile, err := toETFProfile([]byte(buf))
 	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "ETF_PROFILE", buf, etfProfile)
 
 	assert.EqualStrings(t, "323800000000", etfProfile.NetAssets)
 	assert.EqualStrings(t, "0.002", etfProfile.NetExpenseRatio)
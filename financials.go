@@ -0,0 +1,276 @@
+package alphavantage
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Statement names a statement of Financials.
+type Statement string
+
+// Statements of Financials
+const (
+	StatementBalanceSheet    Statement = "balanceSheet"
+	StatementIncomeStatement Statement = "incomeStatement"
+	StatementCashFlow        Statement = "cashFlow"
+	StatementEarnings        Statement = "earnings"
+)
+
+// FinancialsFetcher fetches the statements of a company, e.g. Client.
+type FinancialsFetcher interface {
+	BalanceSheet(symbol string) (*BalanceSheet, error)
+	IncomeStatement(symbol string) (*IncomeStatement, error)
+	CashFlow(symbol string) (*CashFlow, error)
+	Earnings(symbol string) (*Earnings, error)
+}
+
+// FinancialPeriod holds the statements of a fiscal period.  Annual and
+// quarterly reports have the same line items, quarterly reports are
+// converted to the annual types.  Statements without the period are nil
+// and listed in Missing.
+type FinancialPeriod struct {
+	Period          Period
+	BalanceSheet    *BsAnnualReport
+	IncomeStatement *IsAnnualReport
+	CashFlow        *CfAnnualReport
+	// Earnings of fiscal years only have the reported EPS
+	Earnings *QuarterlyEarnings
+	Missing  []Statement
+}
+
+// Complete reports whether all statements have the period.
+func (fp FinancialPeriod) Complete() bool {
+	return len(fp.Missing) == 0
+}
+
+// LineItem returns the line item by its JSON name, e.g. "totalRevenue",
+// from the income statement, balance sheet or cash flow statement, in
+// that order, e.g. "netIncome" is the one of the income statement.  ok is
+// false if no statement of the period has the line item.
+func (fp FinancialPeriod) LineItem(name string) (value AVInt, ok bool) {
+	for _, report := range []interface{}{fp.IncomeStatement, fp.BalanceSheet, fp.CashFlow} {
+		if value, ok = lineItem(report, name); ok {
+			return value, true
+		}
+	}
+	return AVInt{}, false
+}
+
+// lineItem returns the AVInt field of the report with the JSON name
+func lineItem(report interface{}, name string) (AVInt, bool) {
+	v := reflect.ValueOf(report)
+	if v.IsNil() {
+		return AVInt{}, false
+	}
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if jsonName(t.Field(i)) != name {
+			continue
+		}
+		value, ok := v.Field(i).Interface().(AVInt)
+		return value, ok
+	}
+	return AVInt{}, false
+}
+
+func jsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+// Financials joins the balance sheet, income statement, cash flow
+// statement and earnings of a company by fiscal period.  The periods are
+// the ones of any of the three statements, oldest first.
+type Financials struct {
+	Symbol    string
+	Calendar  FiscalCalendar
+	Annual    []FinancialPeriod
+	Quarterly []FinancialPeriod
+}
+
+// FetchFinancials fetches the statements and earnings of the symbol.
+func FetchFinancials(fetcher FinancialsFetcher, symbol string, calendar FiscalCalendar) (*Financials, error) {
+	balanceSheet, err := fetcher.BalanceSheet(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("balance sheet: %w", err)
+	}
+	incomeStatement, err := fetcher.IncomeStatement(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("income statement: %w", err)
+	}
+	cashFlow, err := fetcher.CashFlow(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("cash flow: %w", err)
+	}
+	earnings, err := fetcher.Earnings(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("earnings: %w", err)
+	}
+	return NewFinancials(symbol, calendar, balanceSheet, incomeStatement, cashFlow, earnings)
+}
+
+// Financials fetches the statements and earnings of the symbol, see
+// FetchFinancials.
+func (c *Client) Financials(symbol string, calendar FiscalCalendar) (*Financials, error) {
+	return FetchFinancials(c, symbol, calendar)
+}
+
+// NewFinancials joins the statements by fiscal period.  Statements may be
+// nil, e.g. if earnings aren't needed, their periods are missing then.
+func NewFinancials(symbol string, calendar FiscalCalendar, balanceSheet *BalanceSheet, incomeStatement *IncomeStatement, cashFlow *CashFlow, earnings *Earnings) (*Financials, error) {
+	if balanceSheet == nil {
+		balanceSheet = &BalanceSheet{}
+	}
+	if incomeStatement == nil {
+		incomeStatement = &IncomeStatement{}
+	}
+	if cashFlow == nil {
+		cashFlow = &CashFlow{}
+	}
+	if earnings == nil {
+		earnings = &Earnings{}
+	}
+
+	financials := &Financials{Symbol: symbol, Calendar: calendar}
+	annual := make(financialPeriods)
+	if err := addReports(annual, balanceSheet.AnnualReports, calendar, true, func(fp *FinancialPeriod, r BsAnnualReport) { fp.BalanceSheet = &r }); err != nil {
+		return nil, fmt.Errorf("balance sheet: %w", err)
+	}
+	if err := addReports(annual, incomeStatement.AnnualReports, calendar, true, func(fp *FinancialPeriod, r IsAnnualReport) { fp.IncomeStatement = &r }); err != nil {
+		return nil, fmt.Errorf("income statement: %w", err)
+	}
+	if err := addReports(annual, cashFlow.AnnualReports, calendar, true, func(fp *FinancialPeriod, r CfAnnualReport) { fp.CashFlow = &r }); err != nil {
+		return nil, fmt.Errorf("cash flow: %w", err)
+	}
+	if err := setEarnings(annual, earnings.AnnualEarnings, calendar, true, func(r AnnualEarnings) QuarterlyEarnings {
+		return QuarterlyEarnings{FiscalDateEnding: r.FiscalDateEnding, ReportedEPS: r.ReportedEPS}
+	}); err != nil {
+		return nil, fmt.Errorf("earnings: %w", err)
+	}
+	financials.Annual = annual.sorted()
+
+	quarterly := make(financialPeriods)
+	if err := addReports(quarterly, balanceSheet.QuarterlyReports, calendar, false, func(fp *FinancialPeriod, r BsQuarterlyReport) {
+		report := BsAnnualReport(r)
+		fp.BalanceSheet = &report
+	}); err != nil {
+		return nil, fmt.Errorf("balance sheet: %w", err)
+	}
+	if err := addReports(quarterly, incomeStatement.QuarterlyReports, calendar, false, func(fp *FinancialPeriod, r IsQuarterlyReport) {
+		report := IsAnnualReport(r)
+		fp.IncomeStatement = &report
+	}); err != nil {
+		return nil, fmt.Errorf("income statement: %w", err)
+	}
+	if err := addReports(quarterly, cashFlow.QuarterlyReports, calendar, false, func(fp *FinancialPeriod, r CfQuarterlyReport) {
+		report := CfAnnualReport(r)
+		fp.CashFlow = &report
+	}); err != nil {
+		return nil, fmt.Errorf("cash flow: %w", err)
+	}
+	if err := setEarnings(quarterly, earnings.QuarterlyEarnings, calendar, false, func(r QuarterlyEarnings) QuarterlyEarnings {
+		return r
+	}); err != nil {
+		return nil, fmt.Errorf("earnings: %w", err)
+	}
+	financials.Quarterly = quarterly.sorted()
+	return financials, nil
+}
+
+// Get returns the statements of the fiscal year and quarter, quarter 0 for
+// the fiscal year.
+func (f *Financials) Get(fiscalYear, fiscalQuarter int) (FinancialPeriod, bool) {
+	periods := f.Quarterly
+	if fiscalQuarter == 0 {
+		periods = f.Annual
+	}
+	for _, fp := range periods {
+		if fp.Period.FiscalYear == fiscalYear && fp.Period.FiscalQuarter == fiscalQuarter {
+			return fp, true
+		}
+	}
+	return FinancialPeriod{}, false
+}
+
+// Incomplete returns the periods which are missing from any statement,
+// the annual ones first.
+func (f *Financials) Incomplete() []FinancialPeriod {
+	var incomplete []FinancialPeriod
+	for _, periods := range [][]FinancialPeriod{f.Annual, f.Quarterly} {
+		for _, fp := range periods {
+			if !fp.Complete() {
+				incomplete = append(incomplete, fp)
+			}
+		}
+	}
+	return incomplete
+}
+
+// financialPeriods collects the statements by fiscal period
+type financialPeriods map[Period]*FinancialPeriod
+
+// periodKey returns the period without its end date, which may differ
+// between statements
+func periodKey(p Period) Period {
+	return Period{FiscalYear: p.FiscalYear, FiscalQuarter: p.FiscalQuarter}
+}
+
+// addReports adds the reports of a statement, creating their periods
+func addReports[R PeriodReport](periods financialPeriods, reports []R, calendar FiscalCalendar, annual bool, set func(*FinancialPeriod, R)) error {
+	index, err := newPeriodIndex(reports, calendar, annual)
+	if err != nil {
+		return err
+	}
+	for _, entry := range index.entries {
+		key := periodKey(entry.Period)
+		fp, ok := periods[key]
+		if !ok {
+			fp = &FinancialPeriod{Period: entry.Period}
+			periods[key] = fp
+		}
+		set(fp, entry.Report)
+	}
+	return nil
+}
+
+// setEarnings sets the earnings of the known periods.  Earnings go back
+// much further than the statements, so they don't create periods.
+func setEarnings[R PeriodReport](periods financialPeriods, reports []R, calendar FiscalCalendar, annual bool, convert func(R) QuarterlyEarnings) error {
+	index, err := newPeriodIndex(reports, calendar, annual)
+	if err != nil {
+		return err
+	}
+	for _, entry := range index.entries {
+		if fp, ok := periods[periodKey(entry.Period)]; ok {
+			earnings := convert(entry.Report)
+			fp.Earnings = &earnings
+		}
+	}
+	return nil
+}
+
+// sorted returns the periods oldest first with their missing statements
+func (periods financialPeriods) sorted() []FinancialPeriod {
+	sorted := make([]FinancialPeriod, 0, len(periods))
+	for _, fp := range periods {
+		if fp.BalanceSheet == nil {
+			fp.Missing = append(fp.Missing, StatementBalanceSheet)
+		}
+		if fp.IncomeStatement == nil {
+			fp.Missing = append(fp.Missing, StatementIncomeStatement)
+		}
+		if fp.CashFlow == nil {
+			fp.Missing = append(fp.Missing, StatementCashFlow)
+		}
+		if fp.Earnings == nil {
+			fp.Missing = append(fp.Missing, StatementEarnings)
+		}
+		sorted = append(sorted, *fp)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Period.Before(sorted[j].Period)
+	})
+	return sorted
+}
@@ -0,0 +1,141 @@
+package alphavantage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AMekss/assert"
+)
+
+// fakeFinancialsFetcher returns the prepared statements.
+type fakeFinancialsFetcher struct {
+	balanceSheet    *BalanceSheet
+	incomeStatement *IncomeStatement
+	cashFlow        *CashFlow
+	earnings        *Earnings
+	err             error
+}
+
+func (f *fakeFinancialsFetcher) BalanceSheet(symbol string) (*BalanceSheet, error) {
+	return f.balanceSheet, nil
+}
+
+func (f *fakeFinancialsFetcher) IncomeStatement(symbol string) (*IncomeStatement, error) {
+	return f.incomeStatement, nil
+}
+
+func (f *fakeFinancialsFetcher) CashFlow(symbol string) (*CashFlow, error) {
+	return f.cashFlow, f.err
+}
+
+func (f *fakeFinancialsFetcher) Earnings(symbol string) (*Earnings, error) {
+	return f.earnings, nil
+}
+
+func newFakeFinancialsFetcher() *fakeFinancialsFetcher {
+	return &fakeFinancialsFetcher{
+		balanceSheet: &BalanceSheet{
+			Symbol: "AAPL",
+			AnnualReports: []BsAnnualReport{
+				{FiscalDateEnding: "2023-09-30", TotalAssets: NewAVInt(352583000000)},
+			},
+			QuarterlyReports: []BsQuarterlyReport{
+				{FiscalDateEnding: "2023-12-30", TotalAssets: NewAVInt(353514000000)},
+				{FiscalDateEnding: "2023-09-30", TotalAssets: NewAVInt(352583000000)},
+			},
+		},
+		incomeStatement: &IncomeStatement{
+			Symbol: "AAPL",
+			AnnualReports: []IsAnnualReport{
+				{FiscalDateEnding: "2023-09-30", TotalRevenue: NewAVInt(383285000000), NetIncome: NewAVInt(96995000000)},
+			},
+			QuarterlyReports: []IsQuarterlyReport{
+				{FiscalDateEnding: "2023-12-30", TotalRevenue: NewAVInt(119575000000), NetIncome: NewAVInt(33916000000)},
+				{FiscalDateEnding: "2023-09-30", TotalRevenue: NewAVInt(89498000000), NetIncome: NewAVInt(22956000000)},
+			},
+		},
+		cashFlow: &CashFlow{
+			Symbol: "AAPL",
+			AnnualReports: []CfAnnualReport{
+				{FiscalDateEnding: "2023-09-30", OperatingCashflow: NewAVInt(110543000000), NetIncome: NewAVInt(96995000001)},
+			},
+			QuarterlyReports: []CfQuarterlyReport{
+				// the latest quarter is missing
+				{FiscalDateEnding: "2023-09-30", OperatingCashflow: NewAVInt(21598000000)},
+			},
+		},
+		earnings: &Earnings{
+			Symbol: "AAPL",
+			AnnualEarnings: []AnnualEarnings{
+				{FiscalDateEnding: "2023-09-30", ReportedEPS: NewAVFloat64(6.13)},
+				{FiscalDateEnding: "2022-09-30", ReportedEPS: NewAVFloat64(6.11)},
+			},
+			QuarterlyEarnings: []QuarterlyEarnings{
+				{FiscalDateEnding: "2023-12-31", ReportedEPS: NewAVFloat64(2.18), EstimatedEPS: NewAVFloat64(2.1)},
+				{FiscalDateEnding: "2023-09-30", ReportedEPS: NewAVFloat64(1.46)},
+			},
+		},
+	}
+}
+
+func TestFetchFinancials(t *testing.T) {
+	financials, err := FetchFinancials(newFakeFinancialsFetcher(), "AAPL", FiscalCalendar{YearEnd: time.September})
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "AAPL", financials.Symbol)
+
+	// earnings of fiscal years without statements are skipped
+	assert.EqualInt(t, 1, len(financials.Annual))
+	annual := financials.Annual[0]
+	assert.EqualStrings(t, "FY2023", annual.Period.String())
+	assert.True(t, annual.Complete())
+	assert.EqualFloat64(t, 6.13, annual.Earnings.ReportedEPS.Value)
+
+	// net income of the income statement
+	netIncome, ok := annual.LineItem("netIncome")
+	assert.True(t, ok)
+	assert.EqualInt(t, 96995000000, netIncome.Value)
+	operatingCashflow, ok := annual.LineItem("operatingCashflow")
+	assert.True(t, ok)
+	assert.EqualInt(t, 110543000000, operatingCashflow.Value)
+	_, ok = annual.LineItem("unknown")
+	assert.False(t, ok)
+
+	assert.EqualInt(t, 2, len(financials.Quarterly))
+	assert.EqualStrings(t, "FY2023Q4", financials.Quarterly[0].Period.String())
+	latest, ok := financials.Get(2024, 1)
+	assert.True(t, ok)
+	assert.EqualInt(t, 353514000000, latest.BalanceSheet.TotalAssets.Value)
+	assert.EqualFloat64(t, 2.18, latest.Earnings.ReportedEPS.Value)
+	assert.True(t, latest.CashFlow == nil)
+	assert.EqualInt(t, 1, len(latest.Missing))
+	assert.EqualStrings(t, string(StatementCashFlow), string(latest.Missing[0]))
+	_, ok = latest.LineItem("operatingCashflow")
+	assert.False(t, ok)
+
+	incomplete := financials.Incomplete()
+	assert.EqualInt(t, 1, len(incomplete))
+	assert.EqualStrings(t, "FY2024Q1", incomplete[0].Period.String())
+
+	_, ok = financials.Get(2022, 0)
+	assert.False(t, ok)
+}
+
+func TestFetchFinancialsError(t *testing.T) {
+	fetcher := newFakeFinancialsFetcher()
+	fetcher.err = errors.New("rate limit")
+	_, err := FetchFinancials(fetcher, "AAPL", CalendarYear)
+	assert.ErrorIncludesMessage(t, "cash flow: rate limit", err)
+}
+
+func TestNewFinancialsWithoutEarnings(t *testing.T) {
+	fetcher := newFakeFinancialsFetcher()
+	financials, err := NewFinancials("AAPL", FiscalCalendar{YearEnd: time.September}, fetcher.balanceSheet, fetcher.incomeStatement, fetcher.cashFlow, nil)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 3, len(financials.Incomplete()))
+	assert.EqualStrings(t, string(StatementEarnings), string(financials.Annual[0].Missing[0]))
+
+	fetcher.incomeStatement.QuarterlyReports[0].FiscalDateEnding = "None"
+	_, err = NewFinancials("AAPL", CalendarYear, fetcher.balanceSheet, fetcher.incomeStatement, nil, nil)
+	assert.ErrorIncludesMessage(t, "income statement: invalid fiscal date ending", err)
+}
@@ -3,7 +3,6 @@ package alphavantage
 import (
 	"encoding/json"
 	"fmt"
-	// "github.com/go-playground/validator/v10"
 )
 
 // GlobalQuoteResponse - encapsulates global quote repsonse
@@ -31,14 +30,6 @@ func toGlobalQuote(buf []byte) (*GlobalQuote, error) {
 		return nil, err
 	}
 
-	// validation is a nice feature but it can break if some
-	// symbols have no data (we could have a strict mode)
-	// validate := validator.New()
-	// err := validate.Struct(globalQuoteResponse.GlobalQuote)
-	// if err != nil {
-	// return nil, err
-	// }
-
 	return &globalQuoteResponse.GlobalQuote, nil
 }
 
@@ -55,5 +46,8 @@ func (c *Client) GlobalQuote(symbol string) (*GlobalQuote, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	if err := c.checkResponse(functionName, body, &GlobalQuoteResponse{}); err != nil {
+		return nil, err
+	}
 	return globalQuote, nil
 }
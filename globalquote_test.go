@@ -25,6 +25,7 @@ func TestToGlobalQuote(t *testing.T) {
 `
 	globalQuote, err := toGlobalQuote([]byte(buf))
 	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "GLOBAL_QUOTE", buf, &GlobalQuoteResponse{})
 	assert.EqualStrings(t, "STOCK1", globalQuote.Symbol)
 	assert.EqualStrings(t, "2020-04-30", globalQuote.LatestTradingDay)
 	assert.EqualFloat64(t, 125.8400, globalQuote.Price)
@@ -8,10 +8,10 @@ import (
 
 // OptionContract represents a single option contract (either call or put) with detailed option Greeks and other metrics.
 type OptionContract struct {
-	ContractID        string    `json:"contractID"`
+	ContractID        string    `json:"contractID" validate:"required"`
 	Symbol            string    `json:"symbol"`
-	Expiration        string    `json:"expiration"`
-	Strike            AVFloat64 `json:"strike"`
+	Expiration        string    `json:"expiration" validate:"required"`
+	Strike            AVFloat64 `json:"strike" validate:"required"`
 	Type              string    `json:"type"` // "call" or "put"
 	Last              AVFloat64 `json:"last"`
 	Mark              AVFloat64 `json:"mark"`
@@ -62,5 +62,12 @@ func (c *Client) HistoricalOptions(symbol string, date *time.Time) (*HistoricalO
 		return nil, err
 	}
 
-	return toHistoricalOptionsData(body)
+	optionsData, err := toHistoricalOptionsData(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkResponse(function, body, optionsData); err != nil {
+		return nil, err
+	}
+	return optionsData, nil
 }
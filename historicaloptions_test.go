@@ -62,6 +62,7 @@ func TestToHistoricalOptionsData(t *testing.T) {
 `
 	optionsData, err := toHistoricalOptionsData([]byte(buf))
 	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "HISTORICAL_OPTIONS", buf, optionsData)
 
 	// Asserting symbol and general information
 	assert.EqualStrings(t, "STOCK1", optionsData.Symbol)
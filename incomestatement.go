@@ -7,14 +7,14 @@ import (
 
 // IncomeStatement represents the income statement data for a company.
 type IncomeStatement struct {
-	Symbol           string              `json:"symbol"`
+	Symbol           string              `json:"symbol" validate:"required"`
 	AnnualReports    []IsAnnualReport    `json:"annualReports"`
 	QuarterlyReports []IsQuarterlyReport `json:"quarterlyReports"`
 }
 
 // IsAnnualReport represents an annual report in the income statement.
 type IsAnnualReport struct {
-	FiscalDateEnding                  string `json:"fiscalDateEnding"`
+	FiscalDateEnding                  string `json:"fiscalDateEnding" validate:"required"`
 	ReportedCurrency                  string `json:"reportedCurrency"`
 	GrossProfit                       AVInt  `json:"grossProfit"`
 	TotalRevenue                      AVInt  `json:"totalRevenue"`
@@ -44,7 +44,7 @@ type IsAnnualReport struct {
 
 // IsQuarterlyReport represents a quarterly report in the income statement.
 type IsQuarterlyReport struct {
-	FiscalDateEnding                  string `json:"fiscalDateEnding"`
+	FiscalDateEnding                  string `json:"fiscalDateEnding" validate:"required"`
 	ReportedCurrency                  string `json:"reportedCurrency"`
 	GrossProfit                       AVInt  `json:"grossProfit"`
 	TotalRevenue                      AVInt  `json:"totalRevenue"`
@@ -89,5 +89,12 @@ func (c *Client) IncomeStatement(symbol string) (*IncomeStatement, error) {
 		return nil, err
 	}
 
-	return toIncomeStatement(body)
+	incomeStatement, err := toIncomeStatement(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkResponse(function, body, incomeStatement); err != nil {
+		return nil, err
+	}
+	return incomeStatement, nil
 }
@@ -74,6 +74,7 @@ func TestToIncomeStatement(t *testing.T) {
 `
 	incomeStatement, err := toIncomeStatement([]byte(buf))
 	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "INCOME_STATEMENT", buf, incomeStatement)
 
 	assert.EqualStrings(t, "STOCK1", incomeStatement.Symbol)
 
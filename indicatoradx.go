@@ -0,0 +1,24 @@
+package alphavantage
+
+// IndicatorADX represents the overall struct for average directional movement index indicator,
+// computed by PriceSeries.ADX and shaped like the API response, e.g.
+// https://www.alphavantage.co/query?function=ADX&symbol=IBM&interval=daily&time_period=10&apikey=demo
+type IndicatorADX struct {
+	Metadata          IndicatorADXMetadata            `json:"Meta Data"`
+	TechnicalAnalysis map[string]TechnicalADXAnalysis `json:"Technical Analysis: ADX"`
+}
+
+// IndicatorADXMetadata is the metadata subset of IndicatorADX
+type IndicatorADXMetadata struct {
+	Symbol        string `json:"1: Symbol"`
+	Indicator     string `json:"2: Indicator"`
+	LastRefreshed string `json:"3: Last Refreshed"`
+	Interval      string `json:"4: Interval"`
+	TimePeriod    int    `json:"5: Time Period"`
+	TimeZone      string `json:"6: Time Zone"`
+}
+
+// TechnicalADXAnalysis is the ADX indicator subset of IndicatorADX
+type TechnicalADXAnalysis struct {
+	ADX float64 `json:",string"`
+}
@@ -0,0 +1,51 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+var indicatorADXSample = `
+	{
+		"Meta Data": {
+			"1: Symbol": "STOCK1",
+			"2: Indicator": "Average Directional Movement Index (ADX)",
+			"3: Last Refreshed": "2024-02-26",
+			"4: Interval": "daily",
+			"5: Time Period": 14,
+			"6: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: ADX": {
+			"2024-02-26": {
+				"ADX": "21.8382"
+			},
+			"2024-02-23": {
+				"ADX": "22.2344"
+			},
+			"2024-02-22": {
+				"ADX": "22.6611"
+			}
+		}
+	}
+`
+
+func TestPriceSeriesADXGolden(t *testing.T) {
+	golden := &IndicatorADX{}
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(indicatorADXSample), golden))
+
+	ps := localIndicatorPriceSeries(t)
+	local, err := ps.ADX(14)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, golden.Metadata.Indicator, local.Metadata.Indicator)
+	assert.EqualStrings(t, golden.Metadata.LastRefreshed, local.Metadata.LastRefreshed)
+
+	for date, want := range golden.TechnicalAnalysis {
+		got, exists := local.TechnicalAnalysis[date]
+		if !exists {
+			t.Fatalf("entry for %s is missing", date)
+		}
+		assertEqualRounded(t, want.ADX, got.ADX)
+	}
+}
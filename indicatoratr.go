@@ -0,0 +1,24 @@
+package alphavantage
+
+// IndicatorATR represents the overall struct for average true range indicator,
+// computed by PriceSeries.ATR and shaped like the API response, e.g.
+// https://www.alphavantage.co/query?function=ATR&symbol=IBM&interval=daily&time_period=14&apikey=demo
+type IndicatorATR struct {
+	Metadata          IndicatorATRMetadata            `json:"Meta Data"`
+	TechnicalAnalysis map[string]TechnicalATRAnalysis `json:"Technical Analysis: ATR"`
+}
+
+// IndicatorATRMetadata is the metadata subset of IndicatorATR
+type IndicatorATRMetadata struct {
+	Symbol        string `json:"1: Symbol"`
+	Indicator     string `json:"2: Indicator"`
+	LastRefreshed string `json:"3: Last Refreshed"`
+	Interval      string `json:"4: Interval"`
+	TimePeriod    int    `json:"5: Time Period"`
+	TimeZone      string `json:"6: Time Zone"`
+}
+
+// TechnicalATRAnalysis is the ATR indicator subset of IndicatorATR
+type TechnicalATRAnalysis struct {
+	ATR float64 `json:",string"`
+}
@@ -0,0 +1,51 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+var indicatorATRSample = `
+	{
+		"Meta Data": {
+			"1: Symbol": "STOCK1",
+			"2: Indicator": "Average True Range (ATR)",
+			"3: Last Refreshed": "2024-02-26",
+			"4: Interval": "daily",
+			"5: Time Period": 14,
+			"6: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: ATR": {
+			"2024-02-26": {
+				"ATR": "2.8423"
+			},
+			"2024-02-23": {
+				"ATR": "2.8587"
+			},
+			"2024-02-22": {
+				"ATR": "2.7870"
+			}
+		}
+	}
+`
+
+func TestPriceSeriesATRGolden(t *testing.T) {
+	golden := &IndicatorATR{}
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(indicatorATRSample), golden))
+
+	ps := localIndicatorPriceSeries(t)
+	local, err := ps.ATR(14)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, golden.Metadata.Indicator, local.Metadata.Indicator)
+	assert.EqualStrings(t, golden.Metadata.LastRefreshed, local.Metadata.LastRefreshed)
+
+	for date, want := range golden.TechnicalAnalysis {
+		got, exists := local.TechnicalAnalysis[date]
+		if !exists {
+			t.Fatalf("entry for %s is missing", date)
+		}
+		assertEqualRounded(t, want.ATR, got.ATR)
+	}
+}
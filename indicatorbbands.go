@@ -0,0 +1,30 @@
+package alphavantage
+
+// IndicatorBBands represents the overall struct for bollinger bands indicator,
+// computed by PriceSeries.BBands and shaped like the API response, e.g.
+// https://www.alphavantage.co/query?function=BBANDS&symbol=IBM&interval=weekly&time_period=5&series_type=close&nbdevup=3&nbdevdn=3&apikey=demo
+type IndicatorBBands struct {
+	Metadata          IndicatorBBandsMetadata            `json:"Meta Data"`
+	TechnicalAnalysis map[string]TechnicalBBandsAnalysis `json:"Technical Analysis: BBANDS"`
+}
+
+// IndicatorBBandsMetadata is the metadata subset of IndicatorBBands
+type IndicatorBBandsMetadata struct {
+	Symbol        string  `json:"1: Symbol"`
+	Indicator     string  `json:"2: Indicator"`
+	LastRefreshed string  `json:"3: Last Refreshed"`
+	Interval      string  `json:"4: Interval"`
+	TimePeriod    int     `json:"5: Time Period"`
+	NbDevUp       float64 `json:"6.1: Deviation multiplier for upper band"`
+	NbDevDn       float64 `json:"6.2: Deviation multiplier for lower band"`
+	MAType        int     `json:"6.3: MA Type"`
+	SeriesType    string  `json:"7: Series Type"`
+	TimeZone      string  `json:"8: Time Zone"`
+}
+
+// TechnicalBBandsAnalysis is the BBANDS indicator subset of IndicatorBBands
+type TechnicalBBandsAnalysis struct {
+	RealUpperBand  float64 `json:"Real Upper Band,string"`
+	RealMiddleBand float64 `json:"Real Middle Band,string"`
+	RealLowerBand  float64 `json:"Real Lower Band,string"`
+}
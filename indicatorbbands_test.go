@@ -0,0 +1,63 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+var indicatorBBandsSample = `
+	{
+		"Meta Data": {
+			"1: Symbol": "STOCK1",
+			"2: Indicator": "Bollinger Bands (BBANDS)",
+			"3: Last Refreshed": "2024-02-26",
+			"4: Interval": "daily",
+			"5: Time Period": 20,
+			"6.1: Deviation multiplier for upper band": 2,
+			"6.2: Deviation multiplier for lower band": 2,
+			"6.3: MA Type": 0,
+			"7: Series Type": "close",
+			"8: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: BBANDS": {
+			"2024-02-26": {
+				"Real Upper Band": "102.0479",
+				"Real Middle Band": "95.6785",
+				"Real Lower Band": "89.3091"
+			},
+			"2024-02-23": {
+				"Real Upper Band": "102.2914",
+				"Real Middle Band": "95.7755",
+				"Real Lower Band": "89.2596"
+			},
+			"2024-02-22": {
+				"Real Upper Band": "102.4902",
+				"Real Middle Band": "95.8745",
+				"Real Lower Band": "89.2588"
+			}
+		}
+	}
+`
+
+func TestPriceSeriesBBandsGolden(t *testing.T) {
+	golden := &IndicatorBBands{}
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(indicatorBBandsSample), golden))
+
+	ps := localIndicatorPriceSeries(t)
+	local, err := ps.BBands(20, 2, 2, SeriesTypeClose)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, golden.Metadata.Indicator, local.Metadata.Indicator)
+	assert.EqualStrings(t, golden.Metadata.LastRefreshed, local.Metadata.LastRefreshed)
+
+	for date, want := range golden.TechnicalAnalysis {
+		got, exists := local.TechnicalAnalysis[date]
+		if !exists {
+			t.Fatalf("entry for %s is missing", date)
+		}
+		assertEqualRounded(t, want.RealUpperBand, got.RealUpperBand)
+		assertEqualRounded(t, want.RealMiddleBand, got.RealMiddleBand)
+		assertEqualRounded(t, want.RealLowerBand, got.RealLowerBand)
+	}
+}
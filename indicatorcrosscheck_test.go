@@ -0,0 +1,198 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+// The cross-check tests compare the local indicators with the API's
+// indicator responses for the same prices.  "make indicator-testdata"
+// captures the responses and the prices of IBM into testdata/indicators,
+// the tests are skipped without them.
+const indicatorTestdata = "testdata/indicators"
+
+type indicatorCrossCheck struct {
+	// function is the API function, captured as IBM_<function>.json
+	function string
+	// prices is the time series function of the indicator's input
+	prices   string
+	interval Interval
+	// warmUp is the number of API values skipped at the start, which
+	// depend on the seeds of EMAs and Wilder's smoothing
+	warmUp int
+	// changes compares the changes from date to date instead of the
+	// values, for OBV which depends on the first bar
+	changes bool
+	// sessions skips the first day of intraday prices, which may be
+	// partial
+	sessions bool
+	local    func(ps *PriceSeries) (interface{}, error)
+}
+
+// The parameters are the ones of the demo key's example queries.
+var indicatorCrossChecks = []indicatorCrossCheck{
+	{function: "SMA", prices: "TIME_SERIES_WEEKLY", interval: IntervalWeekly, local: func(ps *PriceSeries) (interface{}, error) {
+		return ps.SMA(10, SeriesTypeOpen)
+	}},
+	{function: "EMA", prices: "TIME_SERIES_WEEKLY", interval: IntervalWeekly, warmUp: 200, local: func(ps *PriceSeries) (interface{}, error) {
+		return ps.EMA(10, SeriesTypeOpen)
+	}},
+	{function: "WMA", prices: "TIME_SERIES_WEEKLY", interval: IntervalWeekly, local: func(ps *PriceSeries) (interface{}, error) {
+		return ps.WMA(10, SeriesTypeOpen)
+	}},
+	{function: "RSI", prices: "TIME_SERIES_WEEKLY", interval: IntervalWeekly, warmUp: 200, local: func(ps *PriceSeries) (interface{}, error) {
+		return ps.RSI(10, SeriesTypeOpen)
+	}},
+	{function: "MACD", prices: "TIME_SERIES_DAILY", interval: IntervalDaily, warmUp: 300, local: func(ps *PriceSeries) (interface{}, error) {
+		return ps.MACD(12, 26, 9, SeriesTypeOpen)
+	}},
+	{function: "BBANDS", prices: "TIME_SERIES_WEEKLY", interval: IntervalWeekly, local: func(ps *PriceSeries) (interface{}, error) {
+		return ps.BBands(5, 3, 3, SeriesTypeClose)
+	}},
+	{function: "ATR", prices: "TIME_SERIES_DAILY", interval: IntervalDaily, warmUp: 300, local: func(ps *PriceSeries) (interface{}, error) {
+		return ps.ATR(14)
+	}},
+	{function: "ADX", prices: "TIME_SERIES_DAILY", interval: IntervalDaily, warmUp: 300, local: func(ps *PriceSeries) (interface{}, error) {
+		return ps.ADX(10)
+	}},
+	{function: "STOCH", prices: "TIME_SERIES_DAILY", interval: IntervalDaily, local: func(ps *PriceSeries) (interface{}, error) {
+		return ps.Stoch(5, 3, 3)
+	}},
+	{function: "OBV", prices: "TIME_SERIES_WEEKLY", interval: IntervalWeekly, changes: true, local: func(ps *PriceSeries) (interface{}, error) {
+		return ps.OBV()
+	}},
+	{function: "VWAP", prices: "TIME_SERIES_INTRADAY", interval: Interval15Min, sessions: true, local: func(ps *PriceSeries) (interface{}, error) {
+		return ps.VWAP()
+	}},
+}
+
+// readIndicatorTestdata reads a captured response, the test is skipped
+// if it wasn't captured.
+func readIndicatorTestdata(t *testing.T, function string) []byte {
+	t.Helper()
+	buf, err := os.ReadFile(filepath.Join(indicatorTestdata, "IBM_"+function+".json"))
+	if os.IsNotExist(err) {
+		t.Skipf("no captured %s response, run make indicator-testdata", function)
+	}
+	assert.NoError(t.Fatalf, err)
+	return buf
+}
+
+// indicatorPrices reads the captured prices of any interval.
+func indicatorPrices(t *testing.T, function string, interval Interval) *PriceSeries {
+	t.Helper()
+	var response map[string]json.RawMessage
+	assert.NoError(t.Fatalf, json.Unmarshal(readIndicatorTestdata(t, function), &response))
+	var bars []Bar
+	for key, raw := range response {
+		if !strings.Contains(key, "Time Series") {
+			continue
+		}
+		var series map[string]TimeSeriesData
+		assert.NoError(t.Fatalf, json.Unmarshal(raw, &series))
+		for date, data := range series {
+			bars = append(bars, Bar{Date: date, Open: data.Open, High: data.High, Low: data.Low, Close: data.Close, Volume: data.Volume})
+		}
+	}
+	if len(bars) == 0 {
+		t.Fatalf("%s: no prices in the captured response", function)
+	}
+	return NewPriceSeries("IBM", interval, bars)
+}
+
+// indicatorValues returns the values of an indicator response by date,
+// without seconds like the API's intraday indicators, and field.
+func indicatorValues(t *testing.T, function string, buf []byte) map[string]map[string]float64 {
+	t.Helper()
+	var response map[string]json.RawMessage
+	assert.NoError(t.Fatalf, json.Unmarshal(buf, &response))
+	raw, ok := response["Technical Analysis: "+function]
+	if !ok {
+		t.Fatalf("%s: not an indicator response: %.200s", function, buf)
+	}
+	var analysis map[string]map[string]string
+	assert.NoError(t.Fatalf, json.Unmarshal(raw, &analysis))
+
+	values := make(map[string]map[string]float64, len(analysis))
+	for date, fields := range analysis {
+		if len(date) > len("2006-01-02 15:04") {
+			date = date[:len("2006-01-02 15:04")]
+		}
+		values[date] = make(map[string]float64, len(fields))
+		for field, value := range fields {
+			v, err := strconv.ParseFloat(value, 64)
+			assert.NoError(t.Fatalf, err)
+			values[date][field] = v
+		}
+	}
+	return values
+}
+
+func TestPriceSeriesIndicatorsCrossCheck(t *testing.T) {
+	for _, check := range indicatorCrossChecks {
+		check := check
+		t.Run(check.function, func(t *testing.T) {
+			api := indicatorValues(t, check.function, readIndicatorTestdata(t, check.function))
+			ps := indicatorPrices(t, check.prices, check.interval)
+
+			indicator, err := check.local(ps)
+			assert.NoError(t.Fatalf, err)
+			buf, err := json.Marshal(indicator)
+			assert.NoError(t.Fatalf, err)
+			local := indicatorValues(t, check.function, buf)
+
+			dates := make([]string, 0, len(api))
+			for date := range api {
+				dates = append(dates, date)
+			}
+			sort.Strings(dates)
+			if len(dates) > check.warmUp {
+				dates = dates[check.warmUp:]
+			} else {
+				dates = nil
+			}
+
+			firstDay := ps.Bars[0].Date[:len(DateFormat)]
+			compared := 0
+			var previous string
+			for _, date := range dates {
+				if check.sessions && strings.HasPrefix(date, firstDay) {
+					continue
+				}
+				got, ok := local[date]
+				if !ok {
+					previous = ""
+					continue
+				}
+				for field, want := range api[date] {
+					value := got[field]
+					if check.changes {
+						if previous == "" {
+							continue
+						}
+						want -= api[previous][field]
+						value -= local[previous][field]
+					}
+					// the API rounds to 4 decimals, the MACD histogram is
+					// the difference of two rounded values
+					if math.Abs(want-value) > 1e-4+1e-6*math.Abs(want) {
+						t.Errorf("%s %s: API %.4f, local %.4f", date, field, want, value)
+					}
+				}
+				previous = date
+				compared++
+			}
+			if compared == 0 {
+				t.Fatalf("no common dates after a warm-up of %d", check.warmUp)
+			}
+		})
+	}
+}
@@ -39,6 +39,7 @@ func TestToIndicatorEMA(t *testing.T) {
 `
 	indicator, err := toIndicatorEMA([]byte(buf))
 	assert.NoError(t, err)
+	assertStrict(t, "EMA", buf, indicator)
 	assert.EqualStrings(t, "USDEUR", indicator.Metadata.Symbol)
 	assert.EqualInt(t, 5, len(indicator.TechnicalAnalysis))
 
@@ -48,3 +49,46 @@ func TestToIndicatorEMA(t *testing.T) {
 	}
 	assert.EqualFloat64(t, 0.9118, ta1.EMA)
 }
+
+func TestPriceSeriesEMAGolden(t *testing.T) {
+	var buf = `
+	{
+		"Meta Data": {
+			"1: Symbol": "STOCK1",
+			"2: Indicator": "Exponential Moving Average (EMA)",
+			"3: Last Refreshed": "2024-02-26",
+			"4: Interval": "daily",
+			"5: Time Period": 10,
+			"6: Series Type": "close",
+			"7: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: EMA": {
+			"2024-02-26": {
+				"EMA": "97.1169"
+			},
+			"2024-02-23": {
+				"EMA": "97.1006"
+			},
+			"2024-02-22": {
+				"EMA": "97.2363"
+			}
+		}
+	}
+`
+	golden, err := toIndicatorEMA([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+
+	ps := localIndicatorPriceSeries(t)
+	local, err := ps.EMA(10, SeriesTypeClose)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, golden.Metadata.Indicator, local.Metadata.Indicator)
+	assert.EqualStrings(t, golden.Metadata.LastRefreshed, local.Metadata.LastRefreshed)
+
+	for date, want := range golden.TechnicalAnalysis {
+		got, exists := local.TechnicalAnalysis[date]
+		if !exists {
+			t.Fatalf("entry for %s is missing", date)
+		}
+		assertEqualRounded(t, want.EMA, got.EMA)
+	}
+}
@@ -0,0 +1,553 @@
+package alphavantage
+
+import (
+	"fmt"
+	"math"
+)
+
+// The indicators below are computed locally from a PriceSeries and
+// follow the TA-Lib conventions used by the API (seeding, smoothing
+// and lookback), so the results can be used interchangeably with the
+// ones returned by the Indicator* endpoints.
+
+// checkIndicatorInput validates the period and the number of bars
+// needed to produce at least one value.
+func (ps *PriceSeries) checkIndicatorInput(name string, period int, needed int) error {
+	if period < 1 {
+		return fmt.Errorf("%s: time period must be positive, got %d", name, period)
+	}
+	if ps.Len() < needed {
+		return fmt.Errorf("%s: not enough data points: need %d, got %d", name, needed, ps.Len())
+	}
+	return nil
+}
+
+// smaValues computes the simple moving average.  Positions without
+// enough history (or with NaN input in the window) are NaN.
+func smaValues(values []float64, period int) []float64 {
+	out := nanSlice(len(values))
+	for i := period - 1; i < len(values); i++ {
+		sum := 0.0
+		for j := i - period + 1; j <= i; j++ {
+			sum += values[j]
+		}
+		out[i] = sum / float64(period)
+	}
+	return out
+}
+
+// emaValues computes the exponential moving average starting at index
+// start.  The first value is seeded with the simple average of the
+// first period values, like TA-Lib does.
+func emaValues(values []float64, period int, start int) []float64 {
+	out := nanSlice(len(values))
+	if start+period > len(values) {
+		return out
+	}
+	k := 2.0 / float64(period+1)
+	sum := 0.0
+	for i := start; i < start+period; i++ {
+		sum += values[i]
+	}
+	prev := sum / float64(period)
+	out[start+period-1] = prev
+	for i := start + period; i < len(values); i++ {
+		prev = (values[i]-prev)*k + prev
+		out[i] = prev
+	}
+	return out
+}
+
+// wmaValues computes the linearly weighted moving average.
+func wmaValues(values []float64, period int) []float64 {
+	out := nanSlice(len(values))
+	divider := float64(period*(period+1)) / 2
+	for i := period - 1; i < len(values); i++ {
+		sum := 0.0
+		for j := 0; j < period; j++ {
+			sum += values[i-period+1+j] * float64(j+1)
+		}
+		out[i] = sum / divider
+	}
+	return out
+}
+
+// trueRange returns the true range of bar i (which must be > 0).
+func trueRange(bars []Bar, i int) float64 {
+	prevClose := bars[i-1].Close
+	tr := bars[i].High - bars[i].Low
+	if v := math.Abs(bars[i].High - prevClose); v > tr {
+		tr = v
+	}
+	if v := math.Abs(bars[i].Low - prevClose); v > tr {
+		tr = v
+	}
+	return tr
+}
+
+// directionalMovement returns the +DM and -DM of bar i (which must be > 0).
+func directionalMovement(bars []Bar, i int) (float64, float64) {
+	diffP := bars[i].High - bars[i-1].High
+	diffM := bars[i-1].Low - bars[i].Low
+	if diffM > 0 && diffP < diffM {
+		return 0, diffM
+	} else if diffP > 0 && diffP > diffM {
+		return diffP, 0
+	}
+	return 0, 0
+}
+
+func nanSlice(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.NaN()
+	}
+	return out
+}
+
+// SMA computes the simple moving average of the series.
+func (ps *PriceSeries) SMA(timePeriod int, seriesType SeriesType) (*IndicatorSMA, error) {
+	if err := ps.checkIndicatorInput("SMA", timePeriod, timePeriod); err != nil {
+		return nil, err
+	}
+	values, err := ps.Values(seriesType)
+	if err != nil {
+		return nil, err
+	}
+	indicator := &IndicatorSMA{
+		Metadata: IndicatorSMAMetadata{
+			Symbol:        ps.Symbol,
+			Indicator:     "Simple Moving Average (SMA)",
+			LastRefreshed: ps.LastRefreshed,
+			Interval:      string(ps.Interval),
+			TimePeriod:    timePeriod,
+			SeriesType:    string(seriesType),
+			TimeZone:      ps.TimeZone,
+		},
+		TechnicalAnalysis: make(map[string]TechnicalSMAAnalysis),
+	}
+	for i, v := range smaValues(values, timePeriod) {
+		if !math.IsNaN(v) {
+			indicator.TechnicalAnalysis[ps.Bars[i].Date] = TechnicalSMAAnalysis{SMA: v}
+		}
+	}
+	return indicator, nil
+}
+
+// EMA computes the exponential moving average of the series.
+func (ps *PriceSeries) EMA(timePeriod int, seriesType SeriesType) (*IndicatorEMA, error) {
+	if err := ps.checkIndicatorInput("EMA", timePeriod, timePeriod); err != nil {
+		return nil, err
+	}
+	values, err := ps.Values(seriesType)
+	if err != nil {
+		return nil, err
+	}
+	indicator := &IndicatorEMA{
+		Metadata: IndicatorEMAMetadata{
+			Symbol:        ps.Symbol,
+			Indicator:     "Exponential Moving Average (EMA)",
+			LastRefreshed: ps.LastRefreshed,
+			Interval:      string(ps.Interval),
+			TimePeriod:    timePeriod,
+			SeriesType:    string(seriesType),
+			TimeZone:      ps.TimeZone,
+		},
+		TechnicalAnalysis: make(map[string]TechnicalEMAAnalysis),
+	}
+	for i, v := range emaValues(values, timePeriod, 0) {
+		if !math.IsNaN(v) {
+			indicator.TechnicalAnalysis[ps.Bars[i].Date] = TechnicalEMAAnalysis{EMA: v}
+		}
+	}
+	return indicator, nil
+}
+
+// WMA computes the weighted moving average of the series.
+func (ps *PriceSeries) WMA(timePeriod int, seriesType SeriesType) (*IndicatorWMA, error) {
+	if err := ps.checkIndicatorInput("WMA", timePeriod, timePeriod); err != nil {
+		return nil, err
+	}
+	values, err := ps.Values(seriesType)
+	if err != nil {
+		return nil, err
+	}
+	indicator := &IndicatorWMA{
+		Metadata: IndicatorWMAMetadata{
+			Symbol:        ps.Symbol,
+			Indicator:     "Weighted Moving Average (WMA)",
+			LastRefreshed: ps.LastRefreshed,
+			Interval:      string(ps.Interval),
+			TimePeriod:    timePeriod,
+			SeriesType:    string(seriesType),
+			TimeZone:      ps.TimeZone,
+		},
+		TechnicalAnalysis: make(map[string]TechnicalWMAAnalysis),
+	}
+	for i, v := range wmaValues(values, timePeriod) {
+		if !math.IsNaN(v) {
+			indicator.TechnicalAnalysis[ps.Bars[i].Date] = TechnicalWMAAnalysis{WMA: v}
+		}
+	}
+	return indicator, nil
+}
+
+// RSI computes the relative strength index of the series using
+// Wilder's smoothing.
+func (ps *PriceSeries) RSI(timePeriod int, seriesType SeriesType) (*IndicatorRSI, error) {
+	if err := ps.checkIndicatorInput("RSI", timePeriod, timePeriod+1); err != nil {
+		return nil, err
+	}
+	values, err := ps.Values(seriesType)
+	if err != nil {
+		return nil, err
+	}
+	indicator := &IndicatorRSI{
+		Metadata: IndicatorRSIMetadata{
+			Symbol:        ps.Symbol,
+			Indicator:     "Relative Strength Index (RSI)",
+			LastRefreshed: ps.LastRefreshed,
+			Interval:      string(ps.Interval),
+			TimePeriod:    timePeriod,
+			SeriesType:    string(seriesType),
+			TimeZone:      ps.TimeZone,
+		},
+		TechnicalAnalysis: make(map[string]TechnicalRSIAnalysis),
+	}
+	period := float64(timePeriod)
+	rsi := func(gain, loss float64) float64 {
+		if gain+loss == 0 {
+			return 0
+		}
+		return 100 * gain / (gain + loss)
+	}
+	var avgGain, avgLoss float64
+	for i := 1; i < len(values); i++ {
+		gain, loss := 0.0, 0.0
+		if diff := values[i] - values[i-1]; diff > 0 {
+			gain = diff
+		} else {
+			loss = -diff
+		}
+		switch {
+		case i < timePeriod:
+			avgGain += gain
+			avgLoss += loss
+			continue
+		case i == timePeriod:
+			avgGain = (avgGain + gain) / period
+			avgLoss = (avgLoss + loss) / period
+		default:
+			avgGain = (avgGain*(period-1) + gain) / period
+			avgLoss = (avgLoss*(period-1) + loss) / period
+		}
+		indicator.TechnicalAnalysis[ps.Bars[i].Date] = TechnicalRSIAnalysis{RSI: rsi(avgGain, avgLoss)}
+	}
+	return indicator, nil
+}
+
+// MACD computes the moving average convergence/divergence of the
+// series.  Both moving averages are aligned on the slow period like
+// TA-Lib does, which is why the result differs slightly from two
+// independent EMA calls.
+func (ps *PriceSeries) MACD(fastPeriod int, slowPeriod int, signalPeriod int, seriesType SeriesType) (*IndicatorMACD, error) {
+	if fastPeriod < 1 || signalPeriod < 1 {
+		return nil, fmt.Errorf("MACD: time periods must be positive, got %d/%d/%d", fastPeriod, slowPeriod, signalPeriod)
+	}
+	if fastPeriod > slowPeriod {
+		fastPeriod, slowPeriod = slowPeriod, fastPeriod
+	}
+	if err := ps.checkIndicatorInput("MACD", slowPeriod, slowPeriod+signalPeriod-1); err != nil {
+		return nil, err
+	}
+	values, err := ps.Values(seriesType)
+	if err != nil {
+		return nil, err
+	}
+	indicator := &IndicatorMACD{
+		Metadata: IndicatorMACDMetadata{
+			Symbol:        ps.Symbol,
+			Indicator:     "Moving Average Convergence/Divergence (MACD)",
+			LastRefreshed: ps.LastRefreshed,
+			Interval:      string(ps.Interval),
+			FastPeriod:    fastPeriod,
+			SlowPeriod:    slowPeriod,
+			SignalPeriod:  signalPeriod,
+			SeriesType:    string(seriesType),
+			TimeZone:      ps.TimeZone,
+		},
+		TechnicalAnalysis: make(map[string]TechnicalMACDAnalysis),
+	}
+	fast := emaValues(values, fastPeriod, slowPeriod-fastPeriod)
+	slow := emaValues(values, slowPeriod, 0)
+	macd := make([]float64, len(values))
+	for i := range values {
+		macd[i] = fast[i] - slow[i]
+	}
+	signal := emaValues(macd, signalPeriod, slowPeriod-1)
+	for i := range values {
+		if math.IsNaN(signal[i]) {
+			continue
+		}
+		indicator.TechnicalAnalysis[ps.Bars[i].Date] = TechnicalMACDAnalysis{
+			MACD:       macd[i],
+			MACDHist:   macd[i] - signal[i],
+			MACDSignal: signal[i],
+		}
+	}
+	return indicator, nil
+}
+
+// BBands computes the bollinger bands of the series around a simple
+// moving average, using the population standard deviation.
+func (ps *PriceSeries) BBands(timePeriod int, nbDevUp float64, nbDevDn float64, seriesType SeriesType) (*IndicatorBBands, error) {
+	if err := ps.checkIndicatorInput("BBANDS", timePeriod, timePeriod); err != nil {
+		return nil, err
+	}
+	values, err := ps.Values(seriesType)
+	if err != nil {
+		return nil, err
+	}
+	indicator := &IndicatorBBands{
+		Metadata: IndicatorBBandsMetadata{
+			Symbol:        ps.Symbol,
+			Indicator:     "Bollinger Bands (BBANDS)",
+			LastRefreshed: ps.LastRefreshed,
+			Interval:      string(ps.Interval),
+			TimePeriod:    timePeriod,
+			NbDevUp:       nbDevUp,
+			NbDevDn:       nbDevDn,
+			MAType:        0,
+			SeriesType:    string(seriesType),
+			TimeZone:      ps.TimeZone,
+		},
+		TechnicalAnalysis: make(map[string]TechnicalBBandsAnalysis),
+	}
+	middle := smaValues(values, timePeriod)
+	for i := timePeriod - 1; i < len(values); i++ {
+		variance := 0.0
+		for j := i - timePeriod + 1; j <= i; j++ {
+			variance += (values[j] - middle[i]) * (values[j] - middle[i])
+		}
+		stdDev := math.Sqrt(variance / float64(timePeriod))
+		indicator.TechnicalAnalysis[ps.Bars[i].Date] = TechnicalBBandsAnalysis{
+			RealUpperBand:  middle[i] + nbDevUp*stdDev,
+			RealMiddleBand: middle[i],
+			RealLowerBand:  middle[i] - nbDevDn*stdDev,
+		}
+	}
+	return indicator, nil
+}
+
+// ATR computes the average true range of the series using Wilder's
+// smoothing.
+func (ps *PriceSeries) ATR(timePeriod int) (*IndicatorATR, error) {
+	if err := ps.checkIndicatorInput("ATR", timePeriod, timePeriod+1); err != nil {
+		return nil, err
+	}
+	indicator := &IndicatorATR{
+		Metadata: IndicatorATRMetadata{
+			Symbol:        ps.Symbol,
+			Indicator:     "Average True Range (ATR)",
+			LastRefreshed: ps.LastRefreshed,
+			Interval:      string(ps.Interval),
+			TimePeriod:    timePeriod,
+			TimeZone:      ps.TimeZone,
+		},
+		TechnicalAnalysis: make(map[string]TechnicalATRAnalysis),
+	}
+	period := float64(timePeriod)
+	atr := 0.0
+	for i := 1; i < ps.Len(); i++ {
+		tr := trueRange(ps.Bars, i)
+		switch {
+		case i < timePeriod:
+			atr += tr
+			continue
+		case i == timePeriod:
+			atr = (atr + tr) / period
+		default:
+			atr = (atr*(period-1) + tr) / period
+		}
+		indicator.TechnicalAnalysis[ps.Bars[i].Date] = TechnicalATRAnalysis{ATR: atr}
+	}
+	return indicator, nil
+}
+
+// ADX computes the average directional movement index of the series.
+func (ps *PriceSeries) ADX(timePeriod int) (*IndicatorADX, error) {
+	if timePeriod < 2 {
+		return nil, fmt.Errorf("ADX: time period must be at least 2, got %d", timePeriod)
+	}
+	if err := ps.checkIndicatorInput("ADX", timePeriod, 2*timePeriod); err != nil {
+		return nil, err
+	}
+	indicator := &IndicatorADX{
+		Metadata: IndicatorADXMetadata{
+			Symbol:        ps.Symbol,
+			Indicator:     "Average Directional Movement Index (ADX)",
+			LastRefreshed: ps.LastRefreshed,
+			Interval:      string(ps.Interval),
+			TimePeriod:    timePeriod,
+			TimeZone:      ps.TimeZone,
+		},
+		TechnicalAnalysis: make(map[string]TechnicalADXAnalysis),
+	}
+	period := float64(timePeriod)
+	var plusDM, minusDM, tr float64
+	// The smoothed sums are seeded with the first period-1 movements.
+	for i := 1; i < timePeriod; i++ {
+		p, m := directionalMovement(ps.Bars, i)
+		plusDM += p
+		minusDM += m
+		tr += trueRange(ps.Bars, i)
+	}
+	dx := func() (float64, bool) {
+		if tr == 0 {
+			return 0, false
+		}
+		plusDI := 100 * plusDM / tr
+		minusDI := 100 * minusDM / tr
+		if plusDI+minusDI == 0 {
+			return 0, false
+		}
+		return 100 * math.Abs(plusDI-minusDI) / (plusDI + minusDI), true
+	}
+	adx := 0.0
+	for i := timePeriod; i < ps.Len(); i++ {
+		p, m := directionalMovement(ps.Bars, i)
+		plusDM = plusDM - plusDM/period + p
+		minusDM = minusDM - minusDM/period + m
+		tr = tr - tr/period + trueRange(ps.Bars, i)
+		value, ok := dx()
+		switch {
+		case i < 2*timePeriod-1:
+			adx += value
+			continue
+		case i == 2*timePeriod-1:
+			adx = (adx + value) / period
+		case ok:
+			adx = (adx*(period-1) + value) / period
+		}
+		indicator.TechnicalAnalysis[ps.Bars[i].Date] = TechnicalADXAnalysis{ADX: adx}
+	}
+	return indicator, nil
+}
+
+// Stoch computes the slow stochastic oscillator of the series, using
+// simple moving averages for the slow %K and %D lines.
+func (ps *PriceSeries) Stoch(fastKPeriod int, slowKPeriod int, slowDPeriod int) (*IndicatorStoch, error) {
+	if slowKPeriod < 1 || slowDPeriod < 1 {
+		return nil, fmt.Errorf("STOCH: time periods must be positive, got %d/%d/%d", fastKPeriod, slowKPeriod, slowDPeriod)
+	}
+	if err := ps.checkIndicatorInput("STOCH", fastKPeriod, fastKPeriod+slowKPeriod+slowDPeriod-2); err != nil {
+		return nil, err
+	}
+	indicator := &IndicatorStoch{
+		Metadata: IndicatorStochMetadata{
+			Symbol:        ps.Symbol,
+			Indicator:     "Stochastic (STOCH)",
+			LastRefreshed: ps.LastRefreshed,
+			Interval:      string(ps.Interval),
+			FastKPeriod:   fastKPeriod,
+			SlowKPeriod:   slowKPeriod,
+			SlowKMAType:   0,
+			SlowDPeriod:   slowDPeriod,
+			SlowDMAType:   0,
+			TimeZone:      ps.TimeZone,
+		},
+		TechnicalAnalysis: make(map[string]TechnicalStochAnalysis),
+	}
+	fastK := nanSlice(ps.Len())
+	for i := fastKPeriod - 1; i < ps.Len(); i++ {
+		lowest, highest := ps.Bars[i].Low, ps.Bars[i].High
+		for j := i - fastKPeriod + 1; j < i; j++ {
+			lowest = math.Min(lowest, ps.Bars[j].Low)
+			highest = math.Max(highest, ps.Bars[j].High)
+		}
+		fastK[i] = 0
+		if highest != lowest {
+			fastK[i] = 100 * (ps.Bars[i].Close - lowest) / (highest - lowest)
+		}
+	}
+	slowK := smaValues(fastK, slowKPeriod)
+	slowD := smaValues(slowK, slowDPeriod)
+	for i := range ps.Bars {
+		if math.IsNaN(slowD[i]) {
+			continue
+		}
+		indicator.TechnicalAnalysis[ps.Bars[i].Date] = TechnicalStochAnalysis{
+			SlowK: slowK[i],
+			SlowD: slowD[i],
+		}
+	}
+	return indicator, nil
+}
+
+// OBV computes the on balance volume of the series.
+func (ps *PriceSeries) OBV() (*IndicatorOBV, error) {
+	if err := ps.checkIndicatorInput("OBV", 1, 1); err != nil {
+		return nil, err
+	}
+	indicator := &IndicatorOBV{
+		Metadata: IndicatorOBVMetadata{
+			Symbol:        ps.Symbol,
+			Indicator:     "On Balance Volume (OBV)",
+			LastRefreshed: ps.LastRefreshed,
+			Interval:      string(ps.Interval),
+			TimeZone:      ps.TimeZone,
+		},
+		TechnicalAnalysis: make(map[string]TechnicalOBVAnalysis),
+	}
+	obv := float64(ps.Bars[0].Volume)
+	indicator.TechnicalAnalysis[ps.Bars[0].Date] = TechnicalOBVAnalysis{OBV: obv}
+	for i := 1; i < ps.Len(); i++ {
+		if ps.Bars[i].Close > ps.Bars[i-1].Close {
+			obv += float64(ps.Bars[i].Volume)
+		} else if ps.Bars[i].Close < ps.Bars[i-1].Close {
+			obv -= float64(ps.Bars[i].Volume)
+		}
+		indicator.TechnicalAnalysis[ps.Bars[i].Date] = TechnicalOBVAnalysis{OBV: obv}
+	}
+	return indicator, nil
+}
+
+// VWAP computes the volume weighted average price of the series based
+// on the typical price.  The accumulation restarts every trading day,
+// so for daily or longer intervals every bar is its own session.
+func (ps *PriceSeries) VWAP() (*IndicatorVWAP, error) {
+	if err := ps.checkIndicatorInput("VWAP", 1, 1); err != nil {
+		return nil, err
+	}
+	indicator := &IndicatorVWAP{
+		Metadata: IndicatorVWAPMetadata{
+			Symbol:        ps.Symbol,
+			Indicator:     "Volume Weighted Average Price (VWAP)",
+			LastRefreshed: ps.LastRefreshed,
+			Interval:      string(ps.Interval),
+			TimeZone:      ps.TimeZone,
+		},
+		TechnicalAnalysis: make(map[string]TechnicalVWAPAnalysis),
+	}
+	var session string
+	var sumPV, sumV float64
+	for _, bar := range ps.Bars {
+		day := bar.Date
+		if len(day) > len(DateFormat) {
+			day = day[:len(DateFormat)]
+		}
+		if day != session {
+			session = day
+			sumPV, sumV = 0, 0
+		}
+		typical := (bar.High + bar.Low + bar.Close) / 3
+		sumPV += typical * float64(bar.Volume)
+		sumV += float64(bar.Volume)
+		vwap := typical
+		if sumV > 0 {
+			vwap = sumPV / sumV
+		}
+		indicator.TechnicalAnalysis[bar.Date] = TechnicalVWAPAnalysis{VWAP: vwap}
+	}
+	return indicator, nil
+}
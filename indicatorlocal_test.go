@@ -0,0 +1,113 @@
+package alphavantage
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+// localIndicatorTimeSeries is the synthetic price history of the
+// TestPriceSeries*Golden tests.  Their expected values were produced by
+// the local indicators themselves, so they catch regressions, while
+// TestPriceSeriesIndicatorsCrossCheck compares with the API.
+var localIndicatorTimeSeries = `
+{
+	"Meta Data": {
+		"1. Information": "Daily Prices (open, high, low, close) and Volumes",
+		"2. Symbol": "STOCK1",
+		"3. Last Refreshed": "2024-02-26",
+		"4. Output Size": "Compact",
+		"5. Time Zone": "US/Eastern"
+	},
+	"Time Series (Daily)": {
+		"2024-02-26": {"1. open": "96.0600", "2. high": "97.4200", "3. low": "94.7900", "4. close": "97.1900", "5. volume": "973440"},
+		"2024-02-23": {"1. open": "95.3300", "2. high": "97.8800", "3. low": "94.0900", "4. close": "96.4900", "5. volume": "527272"},
+		"2024-02-22": {"1. open": "96.1800", "2. high": "97.5500", "3. low": "95.7000", "4. close": "96.0800", "5. volume": "144964"},
+		"2024-02-21": {"1. open": "98.7200", "2. high": "98.7700", "3. low": "96.6100", "4. close": "97.0200", "5. volume": "667667"},
+		"2024-02-20": {"1. open": "101.3600", "2. high": "102.7000", "3. low": "98.4800", "4. close": "99.5000", "5. volume": "830067"},
+		"2024-02-19": {"1. open": "101.2700", "2. high": "103.1300", "3. low": "100.7700", "4. close": "101.8700", "5. volume": "822611"},
+		"2024-02-16": {"1. open": "99.0100", "2. high": "101.6700", "3. low": "97.6500", "4. close": "100.7800", "5. volume": "110509"},
+		"2024-02-15": {"1. open": "96.8300", "2. high": "99.7700", "3. low": "96.4100", "4. close": "98.5300", "5. volume": "194419"},
+		"2024-02-14": {"1. open": "95.7200", "2. high": "96.5800", "3. low": "94.8200", "4. close": "96.4000", "5. volume": "627120"},
+		"2024-02-13": {"1. open": "93.5400", "2. high": "95.5800", "3. low": "93.5000", "4. close": "95.5000", "5. volume": "385511"},
+		"2024-02-12": {"1. open": "91.5100", "2. high": "94.6800", "3. low": "90.5200", "4. close": "93.2800", "5. volume": "548101"},
+		"2024-02-09": {"1. open": "91.8400", "2. high": "92.8200", "3. low": "89.2300", "4. close": "90.6100", "5. volume": "810841"},
+		"2024-02-08": {"1. open": "92.2300", "2. high": "92.7600", "3. low": "91.8600", "4. close": "91.9300", "5. volume": "493604"},
+		"2024-02-07": {"1. open": "91.4400", "2. high": "92.3000", "3. low": "90.4900", "4. close": "91.4300", "5. volume": "774055"},
+		"2024-02-06": {"1. open": "91.4000", "2. high": "91.9700", "3. low": "90.0000", "4. close": "91.1800", "5. volume": "921288"},
+		"2024-02-05": {"1. open": "93.3800", "2. high": "93.8100", "3. low": "90.6100", "4. close": "91.7000", "5. volume": "300080"},
+		"2024-02-02": {"1. open": "95.0100", "2. high": "95.8500", "3. low": "92.1400", "4. close": "93.6200", "5. volume": "488316"},
+		"2024-02-01": {"1. open": "96.6600", "2. high": "97.6700", "3. low": "94.6800", "4. close": "95.5800", "5. volume": "892431"},
+		"2024-01-31": {"1. open": "97.2200", "2. high": "97.5900", "3. low": "95.9900", "4. close": "97.1000", "5. volume": "742552"},
+		"2024-01-30": {"1. open": "99.6900", "2. high": "100.8800", "3. low": "97.3400", "4. close": "97.7800", "5. volume": "216912"},
+		"2024-01-29": {"1. open": "99.2900", "2. high": "100.5800", "3. low": "98.3800", "4. close": "99.1300", "5. volume": "247072"},
+		"2024-01-26": {"1. open": "100.2300", "2. high": "100.5900", "3. low": "97.5100", "4. close": "98.4700", "5. volume": "242123"},
+		"2024-01-25": {"1. open": "97.8400", "2. high": "99.9300", "3. low": "97.6000", "4. close": "99.8800", "5. volume": "995057"},
+		"2024-01-24": {"1. open": "97.7800", "2. high": "99.9900", "3. low": "97.5100", "4. close": "98.7400", "5. volume": "728670"},
+		"2024-01-23": {"1. open": "99.1200", "2. high": "100.5600", "3. low": "97.0400", "4. close": "97.2800", "5. volume": "414115"},
+		"2024-01-22": {"1. open": "100.4200", "2. high": "101.8400", "3. low": "97.8000", "4. close": "98.9900", "5. volume": "490861"},
+		"2024-01-19": {"1. open": "101.0900", "2. high": "101.2400", "3. low": "99.9900", "4. close": "100.6700", "5. volume": "377624"},
+		"2024-01-18": {"1. open": "102.6900", "2. high": "102.9900", "3. low": "99.7600", "4. close": "101.1100", "5. volume": "594259"},
+		"2024-01-17": {"1. open": "103.2300", "2. high": "104.1100", "3. low": "103.0100", "4. close": "103.5500", "5. volume": "823331"},
+		"2024-01-16": {"1. open": "102.6000", "2. high": "104.9400", "3. low": "102.5800", "4. close": "104.0100", "5. volume": "437484"},
+		"2024-01-15": {"1. open": "102.3000", "2. high": "103.6300", "3. low": "101.2500", "4. close": "102.8100", "5. volume": "913588"},
+		"2024-01-12": {"1. open": "101.0800", "2. high": "104.3000", "3. low": "100.4900", "4. close": "103.0500", "5. volume": "734386"},
+		"2024-01-11": {"1. open": "101.8000", "2. high": "102.0700", "3. low": "100.5000", "4. close": "101.4700", "5. volume": "744889"},
+		"2024-01-10": {"1. open": "103.5300", "2. high": "104.4300", "3. low": "101.4000", "4. close": "101.7700", "5. volume": "600815"},
+		"2024-01-09": {"1. open": "102.0500", "2. high": "104.0700", "3. low": "101.7900", "4. close": "102.9600", "5. volume": "114134"},
+		"2024-01-08": {"1. open": "101.2600", "2. high": "103.2300", "3. low": "99.7900", "4. close": "102.1600", "5. volume": "396119"},
+		"2024-01-05": {"1. open": "100.4800", "2. high": "102.4600", "3. low": "99.6700", "4. close": "101.7600", "5. volume": "662943"},
+		"2024-01-04": {"1. open": "100.7300", "2. high": "101.1800", "3. low": "98.5300", "4. close": "99.5000", "5. volume": "810689"},
+		"2024-01-03": {"1. open": "99.5900", "2. high": "100.6300", "3. low": "99.2000", "4. close": "100.0800", "5. volume": "436763"},
+		"2024-01-02": {"1. open": "100.3100", "2. high": "101.3200", "3. low": "99.4500", "4. close": "99.6100", "5. volume": "564917"}
+	}
+}
+`
+
+func localIndicatorPriceSeries(t *testing.T) *PriceSeries {
+	timeSeries, err := toTimeSeries([]byte(localIndicatorTimeSeries))
+	assert.NoError(t.Fatalf, err)
+	return timeSeries.PriceSeries()
+}
+
+// assertEqualRounded compares a locally computed value with a golden
+// value rounded to 4 decimals like the API.
+func assertEqualRounded(t *testing.T, want, got float64) {
+	t.Helper()
+	if math.Abs(want-got) > 0.00005+1e-9 {
+		t.Errorf("Expected %.4f, got %f", want, got)
+	}
+}
+
+func TestPriceSeriesIndicatorErrors(t *testing.T) {
+	ps := localIndicatorPriceSeries(t)
+
+	_, err := ps.SMA(0, SeriesTypeClose)
+	assert.ErrorIncludesMessage(t, "time period must be positive", err)
+
+	_, err = ps.SMA(41, SeriesTypeClose)
+	assert.ErrorIncludesMessage(t, "not enough data points: need 41, got 40", err)
+
+	_, err = ps.ADX(21)
+	assert.ErrorIncludesMessage(t, "not enough data points: need 42, got 40", err)
+
+	_, err = ps.EMA(10, SeriesType("volume"))
+	assert.ErrorIncludesMessage(t, "unknown series type", err)
+}
+
+func TestPriceSeriesVWAPIntraday(t *testing.T) {
+	ps := NewPriceSeries("STOCK1", Interval5Min, []Bar{
+		{Date: "2024-01-03 09:35:00", High: 11, Low: 9, Close: 10, Volume: 300},
+		{Date: "2024-01-02 16:00:00", High: 21, Low: 19, Close: 20, Volume: 100},
+		{Date: "2024-01-03 09:40:00", High: 14, Low: 12, Close: 13, Volume: 100},
+	})
+	indicator, err := ps.VWAP()
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 3, len(indicator.TechnicalAnalysis))
+
+	// the previous session does not leak into the next one
+	assert.EqualFloat64(t, 20, indicator.TechnicalAnalysis["2024-01-02 16:00:00"].VWAP)
+	assert.EqualFloat64(t, 10, indicator.TechnicalAnalysis["2024-01-03 09:35:00"].VWAP)
+	assert.EqualFloat64(t, 10.75, indicator.TechnicalAnalysis["2024-01-03 09:40:00"].VWAP)
+}
@@ -0,0 +1,29 @@
+package alphavantage
+
+// IndicatorMACD represents the overall struct for moving average convergence/divergence indicator,
+// computed by PriceSeries.MACD and shaped like the API response, e.g.
+// https://www.alphavantage.co/query?function=MACD&symbol=IBM&interval=daily&series_type=open&apikey=demo
+type IndicatorMACD struct {
+	Metadata          IndicatorMACDMetadata            `json:"Meta Data"`
+	TechnicalAnalysis map[string]TechnicalMACDAnalysis `json:"Technical Analysis: MACD"`
+}
+
+// IndicatorMACDMetadata is the metadata subset of IndicatorMACD
+type IndicatorMACDMetadata struct {
+	Symbol        string `json:"1: Symbol"`
+	Indicator     string `json:"2: Indicator"`
+	LastRefreshed string `json:"3: Last Refreshed"`
+	Interval      string `json:"4: Interval"`
+	FastPeriod    int    `json:"5.1: Fast Period"`
+	SlowPeriod    int    `json:"5.2: Slow Period"`
+	SignalPeriod  int    `json:"5.3: Signal Period"`
+	SeriesType    string `json:"6: Series Type"`
+	TimeZone      string `json:"7: Time Zone"`
+}
+
+// TechnicalMACDAnalysis is the MACD indicator subset of IndicatorMACD
+type TechnicalMACDAnalysis struct {
+	MACD       float64 `json:",string"`
+	MACDHist   float64 `json:"MACD_Hist,string"`
+	MACDSignal float64 `json:"MACD_Signal,string"`
+}
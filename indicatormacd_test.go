@@ -0,0 +1,62 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+var indicatorMACDSample = `
+	{
+		"Meta Data": {
+			"1: Symbol": "STOCK1",
+			"2: Indicator": "Moving Average Convergence/Divergence (MACD)",
+			"3: Last Refreshed": "2024-02-26",
+			"4: Interval": "daily",
+			"5.1: Fast Period": 12,
+			"5.2: Slow Period": 26,
+			"5.3: Signal Period": 9,
+			"6: Series Type": "close",
+			"7: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: MACD": {
+			"2024-02-26": {
+				"MACD": "-0.5533",
+				"MACD_Hist": "0.5642",
+				"MACD_Signal": "-1.1176"
+			},
+			"2024-02-23": {
+				"MACD": "-0.6075",
+				"MACD_Hist": "0.6512",
+				"MACD_Signal": "-1.2586"
+			},
+			"2024-02-22": {
+				"MACD": "-0.5965",
+				"MACD_Hist": "0.8250",
+				"MACD_Signal": "-1.4214"
+			}
+		}
+	}
+`
+
+func TestPriceSeriesMACDGolden(t *testing.T) {
+	golden := &IndicatorMACD{}
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(indicatorMACDSample), golden))
+
+	ps := localIndicatorPriceSeries(t)
+	local, err := ps.MACD(12, 26, 9, SeriesTypeClose)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, golden.Metadata.Indicator, local.Metadata.Indicator)
+	assert.EqualStrings(t, golden.Metadata.LastRefreshed, local.Metadata.LastRefreshed)
+
+	for date, want := range golden.TechnicalAnalysis {
+		got, exists := local.TechnicalAnalysis[date]
+		if !exists {
+			t.Fatalf("entry for %s is missing", date)
+		}
+		assertEqualRounded(t, want.MACD, got.MACD)
+		assertEqualRounded(t, want.MACDHist, got.MACDHist)
+		assertEqualRounded(t, want.MACDSignal, got.MACDSignal)
+	}
+}
@@ -0,0 +1,23 @@
+package alphavantage
+
+// IndicatorOBV represents the overall struct for on balance volume indicator,
+// computed by PriceSeries.OBV and shaped like the API response, e.g.
+// https://www.alphavantage.co/query?function=OBV&symbol=IBM&interval=weekly&apikey=demo
+type IndicatorOBV struct {
+	Metadata          IndicatorOBVMetadata            `json:"Meta Data"`
+	TechnicalAnalysis map[string]TechnicalOBVAnalysis `json:"Technical Analysis: OBV"`
+}
+
+// IndicatorOBVMetadata is the metadata subset of IndicatorOBV
+type IndicatorOBVMetadata struct {
+	Symbol        string `json:"1: Symbol"`
+	Indicator     string `json:"2: Indicator"`
+	LastRefreshed string `json:"3: Last Refreshed"`
+	Interval      string `json:"4: Interval"`
+	TimeZone      string `json:"5: Time Zone"`
+}
+
+// TechnicalOBVAnalysis is the OBV indicator subset of IndicatorOBV
+type TechnicalOBVAnalysis struct {
+	OBV float64 `json:",string"`
+}
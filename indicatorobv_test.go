@@ -0,0 +1,50 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+var indicatorOBVSample = `
+	{
+		"Meta Data": {
+			"1: Symbol": "STOCK1",
+			"2: Indicator": "On Balance Volume (OBV)",
+			"3: Last Refreshed": "2024-02-26",
+			"4: Interval": "daily",
+			"5: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: OBV": {
+			"2024-02-26": {
+				"OBV": "-1253225.0000"
+			},
+			"2024-02-23": {
+				"OBV": "-2226665.0000"
+			},
+			"2024-02-22": {
+				"OBV": "-2753937.0000"
+			}
+		}
+	}
+`
+
+func TestPriceSeriesOBVGolden(t *testing.T) {
+	golden := &IndicatorOBV{}
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(indicatorOBVSample), golden))
+
+	ps := localIndicatorPriceSeries(t)
+	local, err := ps.OBV()
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, golden.Metadata.Indicator, local.Metadata.Indicator)
+	assert.EqualStrings(t, golden.Metadata.LastRefreshed, local.Metadata.LastRefreshed)
+
+	for date, want := range golden.TechnicalAnalysis {
+		got, exists := local.TechnicalAnalysis[date]
+		if !exists {
+			t.Fatalf("entry for %s is missing", date)
+		}
+		assertEqualRounded(t, want.OBV, got.OBV)
+	}
+}
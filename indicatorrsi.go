@@ -0,0 +1,25 @@
+package alphavantage
+
+// IndicatorRSI represents the overall struct for relative strength index indicator,
+// computed by PriceSeries.RSI and shaped like the API response, e.g.
+// https://www.alphavantage.co/query?function=RSI&symbol=IBM&interval=weekly&time_period=10&series_type=open&apikey=demo
+type IndicatorRSI struct {
+	Metadata          IndicatorRSIMetadata            `json:"Meta Data"`
+	TechnicalAnalysis map[string]TechnicalRSIAnalysis `json:"Technical Analysis: RSI"`
+}
+
+// IndicatorRSIMetadata is the metadata subset of IndicatorRSI
+type IndicatorRSIMetadata struct {
+	Symbol        string `json:"1: Symbol"`
+	Indicator     string `json:"2: Indicator"`
+	LastRefreshed string `json:"3: Last Refreshed"`
+	Interval      string `json:"4: Interval"`
+	TimePeriod    int    `json:"5: Time Period"`
+	SeriesType    string `json:"6: Series Type"`
+	TimeZone      string `json:"7: Time Zone"`
+}
+
+// TechnicalRSIAnalysis is the RSI indicator subset of IndicatorRSI
+type TechnicalRSIAnalysis struct {
+	RSI float64 `json:",string"`
+}
@@ -0,0 +1,52 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+var indicatorRSISample = `
+	{
+		"Meta Data": {
+			"1: Symbol": "STOCK1",
+			"2: Indicator": "Relative Strength Index (RSI)",
+			"3: Last Refreshed": "2024-02-26",
+			"4: Interval": "daily",
+			"5: Time Period": 14,
+			"6: Series Type": "close",
+			"7: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: RSI": {
+			"2024-02-26": {
+				"RSI": "50.5658"
+			},
+			"2024-02-23": {
+				"RSI": "48.6410"
+			},
+			"2024-02-22": {
+				"RSI": "47.5299"
+			}
+		}
+	}
+`
+
+func TestPriceSeriesRSIGolden(t *testing.T) {
+	golden := &IndicatorRSI{}
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(indicatorRSISample), golden))
+
+	ps := localIndicatorPriceSeries(t)
+	local, err := ps.RSI(14, SeriesTypeClose)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, golden.Metadata.Indicator, local.Metadata.Indicator)
+	assert.EqualStrings(t, golden.Metadata.LastRefreshed, local.Metadata.LastRefreshed)
+
+	for date, want := range golden.TechnicalAnalysis {
+		got, exists := local.TechnicalAnalysis[date]
+		if !exists {
+			t.Fatalf("entry for %s is missing", date)
+		}
+		assertEqualRounded(t, want.RSI, got.RSI)
+	}
+}
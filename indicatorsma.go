@@ -52,6 +52,9 @@ func (c *Client) IndicatorSMA(symbol string, interval Interval, timePeriod int,
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	if err := c.checkResponse(functionName, body, indicator); err != nil {
+		return nil, err
+	}
 
 	return indicator, nil
 }
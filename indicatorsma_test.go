@@ -39,6 +39,7 @@ func TestToIndicatorSMA(t *testing.T) {
 `
 	indicator, err := toIndicatorSMA([]byte(buf))
 	assert.NoError(t, err)
+	assertStrict(t, "SMA", buf, indicator)
 	assert.EqualStrings(t, "USDEUR", indicator.Metadata.Symbol)
 	assert.EqualInt(t, 5, len(indicator.TechnicalAnalysis))
 
@@ -48,3 +49,50 @@ func TestToIndicatorSMA(t *testing.T) {
 	}
 	assert.EqualFloat64(t, 0.9118, ta1.SMA)
 }
+
+func TestPriceSeriesSMAGolden(t *testing.T) {
+	var buf = `
+	{
+		"Meta Data": {
+			"1: Symbol": "STOCK1",
+			"2: Indicator": "Simple Moving Average (SMA)",
+			"3: Last Refreshed": "2024-02-26",
+			"4: Interval": "daily",
+			"5: Time Period": 10,
+			"6: Series Type": "close",
+			"7: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: SMA": {
+			"2024-02-26": {
+				"SMA": "97.9360"
+			},
+			"2024-02-23": {
+				"SMA": "97.5450"
+			},
+			"2024-02-22": {
+				"SMA": "96.9570"
+			}
+		}
+	}
+`
+	golden, err := toIndicatorSMA([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+
+	ps := localIndicatorPriceSeries(t)
+	local, err := ps.SMA(10, SeriesTypeClose)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, golden.Metadata.Indicator, local.Metadata.Indicator)
+	assert.EqualStrings(t, golden.Metadata.LastRefreshed, local.Metadata.LastRefreshed)
+
+	for date, want := range golden.TechnicalAnalysis {
+		got, exists := local.TechnicalAnalysis[date]
+		if !exists {
+			t.Fatalf("entry for %s is missing", date)
+		}
+		assertEqualRounded(t, want.SMA, got.SMA)
+	}
+
+	// the mean of the last 10 closes, computed by hand
+	closes := 97.19 + 96.49 + 96.08 + 97.02 + 99.50 + 101.87 + 100.78 + 98.53 + 96.40 + 95.50
+	assertEqualRounded(t, closes/10, local.TechnicalAnalysis["2024-02-26"].SMA)
+}
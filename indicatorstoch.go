@@ -56,6 +56,9 @@ func (c *Client) IndicatorStoch(symbol string, interval Interval) (*IndicatorSto
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	if err := c.checkResponse(functionName, body, indicator); err != nil {
+		return nil, err
+	}
 
 	return indicator, nil
 }
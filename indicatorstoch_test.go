@@ -35,6 +35,7 @@ func TestToIndicatorStoch(t *testing.T) {
 `
 	indicator, err := toIndicatorStoch([]byte(buf))
 	assert.NoError(t, err)
+	assertStrict(t, "STOCH", buf, indicator)
 	assert.EqualStrings(t, "STOCK1", indicator.Metadata.Symbol)
 	assert.EqualInt(t, 2, len(indicator.TechnicalAnalysis))
 
@@ -45,3 +46,53 @@ func TestToIndicatorStoch(t *testing.T) {
 	assert.EqualFloat64(t, 77.3256, ta1.SlowK)
 	assert.EqualFloat64(t, 76.3691, ta1.SlowD)
 }
+
+func TestPriceSeriesStochGolden(t *testing.T) {
+	var buf = `
+	{
+		"Meta Data": {
+			"1: Symbol": "STOCK1",
+			"2: Indicator": "Stochastic (STOCH)",
+			"3: Last Refreshed": "2024-02-26",
+			"4: Interval": "daily",
+			"5.1: FastK Period": 5,
+			"5.2: SlowK Period": 3,
+			"5.3: SlowK MA Type": 0,
+			"5.4: SlowD Period": 3,
+			"5.5: SlowD MA Type": 0,
+			"6: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: STOCH": {
+			"2024-02-26": {
+				"SlowK": "22.5559",
+				"SlowD": "19.8797"
+			},
+			"2024-02-23": {
+				"SlowK": "13.5802",
+				"SlowD": "29.2845"
+			},
+			"2024-02-22": {
+				"SlowK": "23.5032",
+				"SlowD": "50.8969"
+			}
+		}
+	}
+`
+	golden, err := toIndicatorStoch([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+
+	ps := localIndicatorPriceSeries(t)
+	local, err := ps.Stoch(5, 3, 3)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, golden.Metadata.Indicator, local.Metadata.Indicator)
+	assert.EqualStrings(t, golden.Metadata.LastRefreshed, local.Metadata.LastRefreshed)
+
+	for date, want := range golden.TechnicalAnalysis {
+		got, exists := local.TechnicalAnalysis[date]
+		if !exists {
+			t.Fatalf("entry for %s is missing", date)
+		}
+		assertEqualRounded(t, want.SlowK, got.SlowK)
+		assertEqualRounded(t, want.SlowD, got.SlowD)
+	}
+}
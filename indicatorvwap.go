@@ -0,0 +1,23 @@
+package alphavantage
+
+// IndicatorVWAP represents the overall struct for volume weighted average price indicator,
+// computed by PriceSeries.VWAP and shaped like the API response, e.g.
+// https://www.alphavantage.co/query?function=VWAP&symbol=IBM&interval=15min&apikey=demo
+type IndicatorVWAP struct {
+	Metadata          IndicatorVWAPMetadata            `json:"Meta Data"`
+	TechnicalAnalysis map[string]TechnicalVWAPAnalysis `json:"Technical Analysis: VWAP"`
+}
+
+// IndicatorVWAPMetadata is the metadata subset of IndicatorVWAP
+type IndicatorVWAPMetadata struct {
+	Symbol        string `json:"1: Symbol"`
+	Indicator     string `json:"2: Indicator"`
+	LastRefreshed string `json:"3: Last Refreshed"`
+	Interval      string `json:"4: Interval"`
+	TimeZone      string `json:"5: Time Zone"`
+}
+
+// TechnicalVWAPAnalysis is the VWAP indicator subset of IndicatorVWAP
+type TechnicalVWAPAnalysis struct {
+	VWAP float64 `json:",string"`
+}
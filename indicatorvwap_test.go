@@ -0,0 +1,50 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+var indicatorVWAPSample = `
+	{
+		"Meta Data": {
+			"1: Symbol": "STOCK1",
+			"2: Indicator": "Volume Weighted Average Price (VWAP)",
+			"3: Last Refreshed": "2024-02-26",
+			"4: Interval": "daily",
+			"5: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: VWAP": {
+			"2024-02-26": {
+				"VWAP": "96.4667"
+			},
+			"2024-02-23": {
+				"VWAP": "96.1533"
+			},
+			"2024-02-22": {
+				"VWAP": "96.4433"
+			}
+		}
+	}
+`
+
+func TestPriceSeriesVWAPGolden(t *testing.T) {
+	golden := &IndicatorVWAP{}
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(indicatorVWAPSample), golden))
+
+	ps := localIndicatorPriceSeries(t)
+	local, err := ps.VWAP()
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, golden.Metadata.Indicator, local.Metadata.Indicator)
+	assert.EqualStrings(t, golden.Metadata.LastRefreshed, local.Metadata.LastRefreshed)
+
+	for date, want := range golden.TechnicalAnalysis {
+		got, exists := local.TechnicalAnalysis[date]
+		if !exists {
+			t.Fatalf("entry for %s is missing", date)
+		}
+		assertEqualRounded(t, want.VWAP, got.VWAP)
+	}
+}
@@ -0,0 +1,25 @@
+package alphavantage
+
+// IndicatorWMA represents the overall struct for weighted moving average indicator,
+// computed by PriceSeries.WMA and shaped like the API response, e.g.
+// https://www.alphavantage.co/query?function=WMA&symbol=IBM&interval=weekly&time_period=10&series_type=open&apikey=demo
+type IndicatorWMA struct {
+	Metadata          IndicatorWMAMetadata            `json:"Meta Data"`
+	TechnicalAnalysis map[string]TechnicalWMAAnalysis `json:"Technical Analysis: WMA"`
+}
+
+// IndicatorWMAMetadata is the metadata subset of IndicatorWMA
+type IndicatorWMAMetadata struct {
+	Symbol        string `json:"1: Symbol"`
+	Indicator     string `json:"2: Indicator"`
+	LastRefreshed string `json:"3: Last Refreshed"`
+	Interval      string `json:"4: Interval"`
+	TimePeriod    int    `json:"5: Time Period"`
+	SeriesType    string `json:"6: Series Type"`
+	TimeZone      string `json:"7: Time Zone"`
+}
+
+// TechnicalWMAAnalysis is the WMA indicator subset of IndicatorWMA
+type TechnicalWMAAnalysis struct {
+	WMA float64 `json:",string"`
+}
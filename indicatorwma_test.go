@@ -0,0 +1,52 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+var indicatorWMASample = `
+	{
+		"Meta Data": {
+			"1: Symbol": "STOCK1",
+			"2: Indicator": "Weighted Moving Average (WMA)",
+			"3: Last Refreshed": "2024-02-26",
+			"4: Interval": "daily",
+			"5: Time Period": 10,
+			"6: Series Type": "close",
+			"7: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: WMA": {
+			"2024-02-26": {
+				"WMA": "97.8445"
+			},
+			"2024-02-23": {
+				"WMA": "97.9091"
+			},
+			"2024-02-22": {
+				"WMA": "97.9940"
+			}
+		}
+	}
+`
+
+func TestPriceSeriesWMAGolden(t *testing.T) {
+	golden := &IndicatorWMA{}
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(indicatorWMASample), golden))
+
+	ps := localIndicatorPriceSeries(t)
+	local, err := ps.WMA(10, SeriesTypeClose)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, golden.Metadata.Indicator, local.Metadata.Indicator)
+	assert.EqualStrings(t, golden.Metadata.LastRefreshed, local.Metadata.LastRefreshed)
+
+	for date, want := range golden.TechnicalAnalysis {
+		got, exists := local.TechnicalAnalysis[date]
+		if !exists {
+			t.Fatalf("entry for %s is missing", date)
+		}
+		assertEqualRounded(t, want.WMA, got.WMA)
+	}
+}
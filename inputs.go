@@ -0,0 +1,74 @@
+package alphavantage
+
+import "fmt"
+
+// Inputs looks up the line items of Financials and keeps the first error,
+// so formulas can be written as expressions and the error is checked
+// once:
+//
+//	in := financials.Inputs()
+//	margin := in.Divide("gross margin", in.Value("grossProfit", 2024, 0), in.Value("totalRevenue", 2024, 0))
+//	if err := in.Err(); err != nil {
+//
+// After an error the lookups return 0.
+type Inputs struct {
+	f   *Financials
+	err error
+}
+
+// Inputs returns a lookup of the line items of the financials.
+func (f *Financials) Inputs() *Inputs {
+	return &Inputs{f: f}
+}
+
+// Err returns the first error of the lookups and divisions.
+func (in *Inputs) Err() error {
+	return in.err
+}
+
+// Fail keeps err as the error unless there is one already, e.g. for
+// inputs which don't come from the statements.
+func (in *Inputs) Fail(err error) {
+	if in.err == nil {
+		in.err = err
+	}
+}
+
+// Value returns the line item of the fiscal year and quarter, see
+// Financials.Value.
+func (in *Inputs) Value(item string, fiscalYear, fiscalQuarter int) float64 {
+	return in.lookup(in.f.Value, item, fiscalYear, fiscalQuarter)
+}
+
+// TTM returns the TTM sum of the line item, see Financials.TTM.
+func (in *Inputs) TTM(item string, fiscalYear, fiscalQuarter int) float64 {
+	return in.lookup(in.f.TTM, item, fiscalYear, fiscalQuarter)
+}
+
+// PointInTime returns the balance sheet line item, see
+// Financials.PointInTime.
+func (in *Inputs) PointInTime(item string, fiscalYear, fiscalQuarter int) float64 {
+	return in.lookup(in.f.PointInTime, item, fiscalYear, fiscalQuarter)
+}
+
+func (in *Inputs) lookup(get func(string, int, int) (float64, error), item string, fiscalYear, fiscalQuarter int) float64 {
+	if in.err != nil {
+		return 0
+	}
+	value, err := get(item, fiscalYear, fiscalQuarter)
+	in.Fail(err)
+	return value
+}
+
+// Divide divides numerator by denominator, the name is used for the error
+// of a zero denominator, which wraps ErrDivisionByZero.
+func (in *Inputs) Divide(name string, numerator, denominator float64) float64 {
+	if in.err != nil {
+		return 0
+	}
+	if denominator == 0 {
+		in.err = fmt.Errorf("%s: %w", name, ErrDivisionByZero)
+		return 0
+	}
+	return numerator / denominator
+}
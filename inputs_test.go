@@ -0,0 +1,36 @@
+package alphavantage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestInputs(t *testing.T) {
+	in := newTestFinancials(t).Inputs()
+	margin := in.Divide("net margin", in.Value("netIncome", 2023, 0), in.Value("totalRevenue", 2023, 0))
+	assetTurnover := in.Divide("asset turnover", in.TTM("totalRevenue", 2024, 1), in.PointInTime("totalAssets", 2024, 1))
+	assert.NoError(t, in.Err())
+	assert.EqualFloat64Tol(t, 40.0/470, margin, 1e-12)
+	assert.EqualFloat64(t, 0.5, assetTurnover)
+}
+
+func TestInputsKeepFirstError(t *testing.T) {
+	in := newTestFinancials(t).Inputs()
+	in.Value("totalRevenue", 2022, 3)
+	assert.True(t, errors.Is(in.Err(), ErrNoneValue))
+
+	// later lookups and divisions return 0 and keep the error
+	assert.EqualFloat64(t, 0, in.Value("netIncome", 2023, 0))
+	assert.EqualFloat64(t, 0, in.Divide("net margin", 1, 0))
+	in.Fail(errors.New("other"))
+	assert.True(t, errors.Is(in.Err(), ErrNoneValue))
+}
+
+func TestInputsDivisionByZero(t *testing.T) {
+	in := newTestFinancials(t).Inputs()
+	in.Divide("net margin", 1, in.Value("netIncome", 2022, 0))
+	assert.True(t, errors.Is(in.Err(), ErrDivisionByZero))
+	assert.ErrorIncludesMessage(t, "net margin: ", in.Err())
+}
@@ -40,5 +40,12 @@ func (c *Client) InsiderTransactions(symbol string) (*InsiderTransactions, error
 		return nil, err
 	}
 
-	return toInsiderTransactions(body)
+	insiderTransactions, err := toInsiderTransactions(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkResponse(function, body, insiderTransactions); err != nil {
+		return nil, err
+	}
+	return insiderTransactions, nil
 }
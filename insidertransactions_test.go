@@ -38,6 +38,7 @@ func TestToInsiderTransactions(t *testing.T) {
 
 	// Verify parsing was successful
 	assert.NoError(t, err)
+	assertStrict(t, "INSIDER_TRANSACTIONS", string(jsonData), result)
 	assert.EqualInt(t, len(result.Data), 2)
 
 	// Verify first transaction was parsed correctly
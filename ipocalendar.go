@@ -0,0 +1,94 @@
+package alphavantage
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// IPOEvent represents a single upcoming IPO
+type IPOEvent struct {
+	Symbol         string
+	Name           string
+	IPODate        time.Time
+	PriceRangeLow  float64
+	PriceRangeHigh float64
+	Currency       string
+	Exchange       string
+}
+
+// IPOCalendar represents the collection of IPO events
+type IPOCalendar struct {
+	Events []IPOEvent
+}
+
+func toIPOCalendar(data [][]string) (*IPOCalendar, error) {
+	var ic IPOCalendar
+	ic.Events = make([]IPOEvent, 0)
+
+	// Load Eastern Time location
+	est, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return nil, fmt.Errorf("error loading location: %w", err)
+	}
+
+	for idx, row := range data {
+		// skip header
+		if idx == 0 {
+			continue
+		}
+
+		if len(row) != 7 {
+			log.Printf("Invalid number of fields: %v", len(row))
+			continue
+		}
+
+		var event IPOEvent
+		event.Symbol = row[0]
+		event.Name = row[1]
+
+		// Parse IPO date
+		ipoDate, err := time.ParseInLocation("2006-01-02", row[2], est)
+		if err != nil {
+			log.Printf("Error parsing IPO date %v", row[2])
+			continue
+		}
+		event.IPODate = ipoDate
+
+		// Parse price range (if provided)
+		if row[3] != "" {
+			_, err := fmt.Sscanf(row[3], "%f", &event.PriceRangeLow)
+			if err != nil {
+				log.Printf("Error parsing price range low %v", row[3])
+				continue
+			}
+		}
+		if row[4] != "" {
+			_, err := fmt.Sscanf(row[4], "%f", &event.PriceRangeHigh)
+			if err != nil {
+				log.Printf("Error parsing price range high %v", row[4])
+				continue
+			}
+		}
+
+		event.Currency = row[5]
+		event.Exchange = row[6]
+		ic.Events = append(ic.Events, event)
+	}
+
+	return &ic, nil
+}
+
+// IPOCalendar fetches the IPOs expected in the next 3 months
+func (c *Client) IPOCalendar() (*IPOCalendar, error) {
+	const function = "IPO_CALENDAR"
+
+	url := fmt.Sprintf("%s/query?function=%s&apikey=%s", baseURL, function, c.apiKey)
+
+	data, err := c.makeHTTPRequestForCsv(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return toIPOCalendar(data)
+}
@@ -0,0 +1,38 @@
+package alphavantage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToIPOCalendar(t *testing.T) {
+	var buf = [][]string{
+		{"symbol", "name", "ipoDate", "priceRangeLow", "priceRangeHigh", "currency", "exchange"},
+		{"STOCK1", "Stock1 Holdings Inc", "2025-01-23", "16.00", "18.00", "USD", "NASDAQ"},
+		{"STOCK2U", "Stock2 Acquisition Corp - Units", "2025-01-24", "0", "0", "USD", "NYSE"},
+		{"STOCK3", "Stock3 Inc", "TBD", "10.00", "12.00", "USD", "NASDAQ"},
+	}
+
+	calendar, err := toIPOCalendar(buf)
+	assert.NoError(t.Fatalf, err)
+
+	// the row with an invalid date is skipped
+	assert.EqualInt(t, 2, len(calendar.Events))
+
+	event := calendar.Events[0]
+	assert.EqualStrings(t, "STOCK1", event.Symbol)
+	assert.EqualStrings(t, "Stock1 Holdings Inc", event.Name)
+	expectedIPODate := time.Date(2025, 1, 23, 0, 0, 0, 0, timeEST)
+	assert.True(t, event.IPODate.Equal(expectedIPODate))
+	assert.EqualFloat64(t, 16.0, event.PriceRangeLow)
+	assert.EqualFloat64(t, 18.0, event.PriceRangeHigh)
+	assert.EqualStrings(t, "USD", event.Currency)
+	assert.EqualStrings(t, "NASDAQ", event.Exchange)
+
+	event = calendar.Events[1]
+	assert.EqualStrings(t, "STOCK2U", event.Symbol)
+	assert.EqualFloat64(t, 0.0, event.PriceRangeLow)
+	assert.EqualStrings(t, "NYSE", event.Exchange)
+}
@@ -0,0 +1,67 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Market represents the trading status of a market in a region.
+type Market struct {
+	MarketType       string `json:"market_type"`
+	Region           string `json:"region"`
+	PrimaryExchanges string `json:"primary_exchanges"`
+	LocalOpen        string `json:"local_open"`  // "09:30"
+	LocalClose       string `json:"local_close"` // "16:15"
+	CurrentStatus    string `json:"current_status"`
+	Notes            string `json:"notes"`
+}
+
+// MarketStatus represents the response from the MARKET_STATUS API endpoint.
+type MarketStatus struct {
+	Endpoint string   `json:"endpoint"`
+	Markets  []Market `json:"markets"`
+}
+
+func toMarketStatus(buf []byte) (*MarketStatus, error) {
+	marketStatus := &MarketStatus{}
+	if err := json.Unmarshal(buf, marketStatus); err != nil {
+		return nil, err
+	}
+	return marketStatus, nil
+}
+
+// MarketStatus fetches the current open/closed status of the major
+// trading venues around the globe.
+func (c *Client) MarketStatus() (*MarketStatus, error) {
+	const function = "MARKET_STATUS"
+	url := fmt.Sprintf("%s/query?function=%s&apikey=%s", baseURL, function, c.apiKey)
+	body, err := c.makeHTTPRequest(url)
+	if err != nil {
+		return nil, err
+	}
+	marketStatus, err := toMarketStatus(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := c.checkResponse(function, body, marketStatus); err != nil {
+		return nil, err
+	}
+	return marketStatus, nil
+}
+
+// IsOpen returns true if the market is currently open.
+func (m *Market) IsOpen() bool {
+	return strings.EqualFold(m.CurrentStatus, "open")
+}
+
+// ByRegion returns the markets of the given region (e.g. "United States").
+func (ms *MarketStatus) ByRegion(region string) []Market {
+	markets := make([]Market, 0)
+	for _, market := range ms.Markets {
+		if strings.EqualFold(market.Region, region) {
+			markets = append(markets, market)
+		}
+	}
+	return markets
+}
@@ -0,0 +1,61 @@
+package alphavantage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToMarketStatus(t *testing.T) {
+	var buf = `
+{
+    "endpoint": "Global Market Open & Close Status",
+    "markets": [
+        {
+            "market_type": "Equity",
+            "region": "United States",
+            "primary_exchanges": "NASDAQ, NYSE, AMEX, BATS",
+            "local_open": "09:30",
+            "local_close": "16:15",
+            "current_status": "open",
+            "notes": ""
+        },
+        {
+            "market_type": "Equity",
+            "region": "Japan",
+            "primary_exchanges": "Tokyo",
+            "local_open": "09:00",
+            "local_close": "15:00",
+            "current_status": "closed",
+            "notes": ""
+        },
+        {
+            "market_type": "Forex",
+            "region": "Global",
+            "primary_exchanges": "Global",
+            "local_open": "N/A",
+            "local_close": "N/A",
+            "current_status": "open",
+            "notes": ""
+        }
+    ]
+}
+`
+	marketStatus, err := toMarketStatus([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "MARKET_STATUS", buf, marketStatus)
+	assert.EqualStrings(t, "Global Market Open & Close Status", marketStatus.Endpoint)
+	assert.EqualInt(t, 3, len(marketStatus.Markets))
+
+	us := marketStatus.ByRegion("united states")
+	assert.EqualInt(t, 1, len(us))
+	assert.EqualStrings(t, "09:30", us[0].LocalOpen)
+	assert.EqualStrings(t, "16:15", us[0].LocalClose)
+	assert.True(t, us[0].IsOpen())
+
+	japan := marketStatus.ByRegion("Japan")
+	assert.EqualInt(t, 1, len(japan))
+	assert.False(t, japan[0].IsOpen())
+
+	assert.EqualInt(t, 0, len(marketStatus.ByRegion("Atlantis")))
+}
@@ -69,5 +69,12 @@ func (c *Client) NewsSentiment(tickers string, sortType SortType, limit int, tim
 		return nil, err
 	}
 
-	return toNewsSentiment(body)
+	newsSentiment, err := toNewsSentiment(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkResponse(function, body, newsSentiment); err != nil {
+		return nil, err
+	}
+	return newsSentiment, nil
 }
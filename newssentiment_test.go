@@ -370,6 +370,7 @@ func TestToNewsSentiment(t *testing.T) {
 `
 	newsSentiment, err := toNewsSentiment([]byte(buf))
 	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "NEWS_SENTIMENT", buf, newsSentiment)
 
 	assert.EqualInt(t, 6, len(newsSentiment.Feed))
 }
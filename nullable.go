@@ -0,0 +1,214 @@
+package alphavantage
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NullableNumber is implemented by AVFloat64, AVPercent and AVInt.
+type NullableNumber interface {
+	IsNull() bool
+	Float64() float64
+}
+
+// NewAVFloat64 returns a valid AVFloat64, e.g. for a genuine 0.
+func NewAVFloat64(value float64) AVFloat64 {
+	return AVFloat64{Value: value, Valid: true}
+}
+
+// IsNull reports whether the value is "None".
+func (cf AVFloat64) IsNull() bool {
+	return !cf.Valid && cf.Value == 0
+}
+
+// Float64 returns the value, 0 for "None".
+func (cf AVFloat64) Float64() float64 {
+	return cf.Value
+}
+
+// Scan implements sql.Scanner, NULL is "None".
+func (cf *AVFloat64) Scan(src interface{}) error {
+	var n sql.NullFloat64
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*cf = AVFloat64{Value: n.Float64, Valid: n.Valid}
+	return nil
+}
+
+// NullFloat64 returns the value as sql.NullFloat64, which implements
+// driver.Valuer.  AVFloat64 can't implement it itself, the method would
+// clash with the Value field.
+func (cf AVFloat64) NullFloat64() sql.NullFloat64 {
+	return sql.NullFloat64{Float64: cf.Value, Valid: !cf.IsNull()}
+}
+
+// SQLValue returns the value as driver.Valuer for db.Exec and friends,
+// NULL for "None".
+func (cf AVFloat64) SQLValue() driver.Valuer {
+	return cf.NullFloat64()
+}
+
+// NewAVPercent returns a valid AVPercent, e.g. for a genuine 0%.
+func NewAVPercent(value float64) AVPercent {
+	return AVPercent{Value: value, Valid: true}
+}
+
+// IsNull reports whether the value is "None".
+func (cf AVPercent) IsNull() bool {
+	return !cf.Valid && cf.Value == 0
+}
+
+// Float64 returns the value in percent, 0 for "None".
+func (cf AVPercent) Float64() float64 {
+	return cf.Value
+}
+
+// Scan implements sql.Scanner, NULL is "None".  Strings may have a "%"
+// suffix.
+func (cf *AVPercent) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		src = strings.TrimSuffix(string(v), "%")
+	case string:
+		src = strings.TrimSuffix(v, "%")
+	}
+	var n sql.NullFloat64
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*cf = AVPercent{Value: n.Float64, Valid: n.Valid}
+	return nil
+}
+
+// NullFloat64 returns the value in percent as sql.NullFloat64, which
+// implements driver.Valuer.
+func (cf AVPercent) NullFloat64() sql.NullFloat64 {
+	return sql.NullFloat64{Float64: cf.Value, Valid: !cf.IsNull()}
+}
+
+// SQLValue returns the value in percent as driver.Valuer, NULL for "None".
+func (cf AVPercent) SQLValue() driver.Valuer {
+	return cf.NullFloat64()
+}
+
+// NewAVInt returns a valid AVInt, e.g. for a genuine 0.
+func NewAVInt(value int) AVInt {
+	return AVInt{Value: value, Valid: true}
+}
+
+// IsNull reports whether the value is "None".
+func (cf AVInt) IsNull() bool {
+	return !cf.Valid && cf.Value == 0
+}
+
+// Float64 returns the value as float64, 0 for "None".
+func (cf AVInt) Float64() float64 {
+	return float64(cf.Value)
+}
+
+// Scan implements sql.Scanner, NULL is "None".  Fractional values, e.g.
+// of a NUMERIC column, are truncated like in UnmarshalJSON.
+func (cf *AVInt) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case float64:
+		*cf = AVInt{Value: int(v), Valid: true}
+		return nil
+	case []byte:
+		src = string(v)
+	}
+	if s, ok := src.(string); ok {
+		if _, err := strconv.Atoi(s); err != nil {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return fmt.Errorf("unexpected string value: %s: %v", s, err)
+			}
+			*cf = AVInt{Value: int(f), Valid: true}
+			return nil
+		}
+	}
+	var n sql.NullInt64
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*cf = AVInt{Value: int(n.Int64), Valid: n.Valid}
+	return nil
+}
+
+// NullInt64 returns the value as sql.NullInt64, which implements
+// driver.Valuer.
+func (cf AVInt) NullInt64() sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(cf.Value), Valid: !cf.IsNull()}
+}
+
+// SQLValue returns the value as driver.Valuer, NULL for "None".
+func (cf AVInt) SQLValue() driver.Valuer {
+	return cf.NullInt64()
+}
+
+// NullableCount returns the number of values which aren't null.
+func NullableCount[T NullableNumber](values []T) int {
+	count := 0
+	for _, value := range values {
+		if !value.IsNull() {
+			count++
+		}
+	}
+	return count
+}
+
+// NullableSum returns the sum of the values which aren't null and their
+// count.
+func NullableSum[T NullableNumber](values []T) (sum float64, count int) {
+	for _, value := range values {
+		if !value.IsNull() {
+			sum += value.Float64()
+			count++
+		}
+	}
+	return sum, count
+}
+
+// NullableMean returns the mean of the values which aren't null.  ok is
+// false if all values are null.
+func NullableMean[T NullableNumber](values []T) (mean float64, ok bool) {
+	sum, count := NullableSum(values)
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+// NullableMin returns the smallest value which isn't null.  ok is false
+// if all values are null.
+func NullableMin[T NullableNumber](values []T) (lowest float64, ok bool) {
+	lowest = math.Inf(1)
+	for _, value := range values {
+		if !value.IsNull() {
+			lowest, ok = math.Min(lowest, value.Float64()), true
+		}
+	}
+	if !ok {
+		return 0, false
+	}
+	return lowest, true
+}
+
+// NullableMax returns the largest value which isn't null.  ok is false
+// if all values are null.
+func NullableMax[T NullableNumber](values []T) (highest float64, ok bool) {
+	highest = math.Inf(-1)
+	for _, value := range values {
+		if !value.IsNull() {
+			highest, ok = math.Max(highest, value.Float64()), true
+		}
+	}
+	if !ok {
+		return 0, false
+	}
+	return highest, true
+}
@@ -0,0 +1,129 @@
+package alphavantage
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestNullableRoundTrip(t *testing.T) {
+	var report struct {
+		Debt     AVInt     `json:"debt"`
+		Change   AVPercent `json:"change"`
+		Dividend AVFloat64 `json:"dividend"`
+		Missing  AVFloat64 `json:"missing"`
+		Dash     AVInt     `json:"dash"`
+		Null     AVPercent `json:"null"`
+	}
+	data := `{"debt":"0","change":"0.0000%","dividend":"0","missing":"None","dash":"-","null":null}`
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(data), &report))
+
+	assert.False(t, report.Debt.IsNull())
+	assert.False(t, report.Change.IsNull())
+	assert.False(t, report.Dividend.IsNull())
+	assert.True(t, report.Missing.IsNull())
+	assert.True(t, report.Dash.IsNull())
+	assert.True(t, report.Null.IsNull())
+
+	buf, err := json.Marshal(report)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, `{"debt":"0","change":"0.0000%","dividend":"0","missing":"None","dash":"None","null":"None"}`, string(buf))
+}
+
+func TestNullableLiterals(t *testing.T) {
+	// non-zero values are valid without setting Valid
+	assert.False(t, AVFloat64{Value: 1.5}.IsNull())
+	assert.True(t, AVFloat64{}.IsNull())
+	assert.False(t, NewAVFloat64(0).IsNull())
+	assert.False(t, NewAVInt(0).IsNull())
+	assert.False(t, NewAVPercent(0).IsNull())
+
+	buf, err := NewAVInt(0).MarshalCSV()
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "0", string(buf))
+}
+
+func TestNullableScan(t *testing.T) {
+	var f AVFloat64
+	assert.NoError(t.Fatalf, f.Scan(nil))
+	assert.True(t, f.IsNull())
+	assert.NoError(t.Fatalf, f.Scan(float64(0)))
+	assert.False(t, f.IsNull())
+	assert.NoError(t.Fatalf, f.Scan([]byte("2.5")))
+	assert.EqualFloat64(t, 2.5, f.Value)
+
+	var p AVPercent
+	assert.NoError(t.Fatalf, p.Scan("-1.25%"))
+	assert.EqualFloat64(t, -1.25, p.Value)
+	assert.NoError(t.Fatalf, p.Scan(nil))
+	assert.True(t, p.IsNull())
+
+	var i AVInt
+	assert.NoError(t.Fatalf, i.Scan(int64(0)))
+	assert.False(t, i.IsNull())
+	assert.NoError(t.Fatalf, i.Scan([]byte("12.9")))
+	assert.EqualInt(t, 12, i.Value)
+	assert.NoError(t.Fatalf, i.Scan(nil))
+	assert.True(t, i.IsNull())
+	assert.ErrorIncludesMessage(t, "unexpected string value: abc", i.Scan("abc"))
+}
+
+func TestNullableValuer(t *testing.T) {
+	value, err := NewAVFloat64(0).NullFloat64().Value()
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64(t, 0, value.(float64))
+
+	value, err = AVFloat64{}.NullFloat64().Value()
+	assert.NoError(t.Fatalf, err)
+	assert.True(t, value == nil)
+
+	value, err = AVPercent{Value: 3.5}.NullFloat64().Value()
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64(t, 3.5, value.(float64))
+
+	value, err = AVInt{Value: 7}.NullInt64().Value()
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 7, int(value.(int64)))
+
+	value, err = AVInt{}.NullInt64().Value()
+	assert.NoError(t.Fatalf, err)
+	assert.True(t, value == nil)
+
+	for _, valuer := range []driver.Valuer{NewAVFloat64(1.5).SQLValue(), AVPercent{Value: 2}.SQLValue(), AVInt{Value: 3}.SQLValue()} {
+		value, err = valuer.Value()
+		assert.NoError(t.Fatalf, err)
+		assert.True(t, value != nil && driver.IsValue(value))
+	}
+	for _, valuer := range []driver.Valuer{AVFloat64{}.SQLValue(), AVPercent{}.SQLValue(), AVInt{}.SQLValue()} {
+		value, err = valuer.Value()
+		assert.NoError(t.Fatalf, err)
+		assert.True(t, value == nil)
+	}
+}
+
+func TestNullableAggregates(t *testing.T) {
+	values := []AVFloat64{{Value: 4}, {}, NewAVFloat64(0), {Value: -2}}
+
+	assert.EqualInt(t, 3, NullableCount(values))
+	sum, count := NullableSum(values)
+	assert.EqualFloat64(t, 2, sum)
+	assert.EqualInt(t, 3, count)
+	mean, ok := NullableMean(values)
+	assert.True(t, ok)
+	assert.EqualFloat64Tol(t, 0.666667, mean, 0.000001)
+	lowest, ok := NullableMin(values)
+	assert.True(t, ok)
+	assert.EqualFloat64(t, -2, lowest)
+	highest, ok := NullableMax(values)
+	assert.True(t, ok)
+	assert.EqualFloat64(t, 4, highest)
+
+	_, ok = NullableMean([]AVInt{{}, {}})
+	assert.False(t, ok)
+	_, ok = NullableMin([]AVPercent{})
+	assert.False(t, ok)
+	_, ok = NullableMax([]AVPercent{{}})
+	assert.False(t, ok)
+}
@@ -0,0 +1,132 @@
+package alphavantage
+
+import (
+	"math"
+	"sort"
+)
+
+// OptionPair groups the call and the put sharing the same expiration
+// and strike.  Call or Put is nil if the chain lacks that side.
+type OptionPair struct {
+	Expiration string
+	Strike     float64
+	Call       *OptionContract
+	Put        *OptionContract
+}
+
+// OptionExpiration holds the pairs of one expiration ordered by strike.
+type OptionExpiration struct {
+	Expiration string
+	Pairs      []OptionPair
+}
+
+// OptionChain represents the option contracts of a symbol grouped by
+// expiration (nearest first) and strike (lowest first).
+type OptionChain struct {
+	Symbol      string
+	Expirations []OptionExpiration
+}
+
+// NewOptionChain groups the given contracts by expiration and strike
+// into call/put pairs.
+func NewOptionChain(symbol string, contracts []OptionContract) *OptionChain {
+	type key struct {
+		expiration string
+		strike     float64
+	}
+	pairs := make(map[key]*OptionPair)
+	for i := range contracts {
+		contract := &contracts[i]
+		k := key{contract.Expiration, contract.Strike.Value}
+		pair, exists := pairs[k]
+		if !exists {
+			pair = &OptionPair{Expiration: k.expiration, Strike: k.strike}
+			pairs[k] = pair
+		}
+		switch contract.Type {
+		case "call":
+			pair.Call = contract
+		case "put":
+			pair.Put = contract
+		}
+	}
+
+	byExpiration := make(map[string][]OptionPair)
+	for k, pair := range pairs {
+		byExpiration[k.expiration] = append(byExpiration[k.expiration], *pair)
+	}
+
+	chain := &OptionChain{Symbol: symbol}
+	for expiration, expirationPairs := range byExpiration {
+		sort.Slice(expirationPairs, func(i, j int) bool {
+			return expirationPairs[i].Strike < expirationPairs[j].Strike
+		})
+		chain.Expirations = append(chain.Expirations, OptionExpiration{
+			Expiration: expiration,
+			Pairs:      expirationPairs,
+		})
+	}
+	sort.Slice(chain.Expirations, func(i, j int) bool {
+		return chain.Expirations[i].Expiration < chain.Expirations[j].Expiration
+	})
+	return chain
+}
+
+// Chain groups the contracts by expiration and strike.
+func (d *HistoricalOptionsData) Chain() *OptionChain {
+	return NewOptionChain(d.Symbol, d.Data)
+}
+
+// ExpirationDates returns all expiration dates of the chain, nearest first.
+func (oc *OptionChain) ExpirationDates() []string {
+	dates := make([]string, len(oc.Expirations))
+	for i, expiration := range oc.Expirations {
+		dates[i] = expiration.Expiration
+	}
+	return dates
+}
+
+// ByExpiration returns the pairs of the given expiration date or nil.
+func (oc *OptionChain) ByExpiration(expiration string) *OptionExpiration {
+	for i := range oc.Expirations {
+		if oc.Expirations[i].Expiration == expiration {
+			return &oc.Expirations[i]
+		}
+	}
+	return nil
+}
+
+// Pair returns the call/put pair for the given expiration and strike or nil.
+func (oc *OptionChain) Pair(expiration string, strike float64) *OptionPair {
+	oe := oc.ByExpiration(expiration)
+	if oe == nil {
+		return nil
+	}
+	for i := range oe.Pairs {
+		if oe.Pairs[i].Strike == strike {
+			return &oe.Pairs[i]
+		}
+	}
+	return nil
+}
+
+// Strikes returns all strikes of the expiration, lowest first.
+func (oe *OptionExpiration) Strikes() []float64 {
+	strikes := make([]float64, len(oe.Pairs))
+	for i, pair := range oe.Pairs {
+		strikes[i] = pair.Strike
+	}
+	return strikes
+}
+
+// NearestStrike returns the pair whose strike is closest to price, e.g.
+// the at-the-money pair for the current underlying price.
+func (oe *OptionExpiration) NearestStrike(price float64) *OptionPair {
+	var nearest *OptionPair
+	for i := range oe.Pairs {
+		if nearest == nil || math.Abs(oe.Pairs[i].Strike-price) < math.Abs(nearest.Strike-price) {
+			nearest = &oe.Pairs[i]
+		}
+	}
+	return nearest
+}
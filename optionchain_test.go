@@ -0,0 +1,49 @@
+package alphavantage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestNewOptionChain(t *testing.T) {
+	contracts := []OptionContract{
+		{ContractID: "C2-110", Expiration: "2025-02-21", Strike: AVFloat64{Value: 110}, Type: "call"},
+		{ContractID: "C1-105", Expiration: "2025-01-17", Strike: AVFloat64{Value: 105}, Type: "call"},
+		{ContractID: "P1-95", Expiration: "2025-01-17", Strike: AVFloat64{Value: 95}, Type: "put"},
+		{ContractID: "C1-95", Expiration: "2025-01-17", Strike: AVFloat64{Value: 95}, Type: "call"},
+		{ContractID: "P1-105", Expiration: "2025-01-17", Strike: AVFloat64{Value: 105}, Type: "put"},
+		{ContractID: "P1-100", Expiration: "2025-01-17", Strike: AVFloat64{Value: 100}, Type: "put"},
+	}
+
+	chain := NewOptionChain("STOCK1", contracts)
+	assert.EqualStrings(t, "STOCK1", chain.Symbol)
+
+	dates := chain.ExpirationDates()
+	assert.EqualInt(t, 2, len(dates))
+	assert.EqualStrings(t, "2025-01-17", dates[0])
+	assert.EqualStrings(t, "2025-02-21", dates[1])
+
+	january := chain.ByExpiration("2025-01-17")
+	if january == nil {
+		t.Fatal("expiration 2025-01-17 is missing")
+	}
+	strikes := january.Strikes()
+	assert.EqualInt(t, 3, len(strikes))
+	assert.EqualFloat64(t, 95, strikes[0])
+	assert.EqualFloat64(t, 100, strikes[1])
+	assert.EqualFloat64(t, 105, strikes[2])
+
+	pair := chain.Pair("2025-01-17", 95)
+	assert.EqualStrings(t, "C1-95", pair.Call.ContractID)
+	assert.EqualStrings(t, "P1-95", pair.Put.ContractID)
+
+	// only the put is listed for this strike
+	pair = chain.Pair("2025-01-17", 100)
+	assert.True(t, pair.Call == nil)
+	assert.EqualStrings(t, "P1-100", pair.Put.ContractID)
+
+	assert.EqualFloat64(t, 105, january.NearestStrike(103.2).Strike)
+	assert.True(t, chain.Pair("2025-01-17", 90) == nil)
+	assert.True(t, chain.ByExpiration("2025-03-21") == nil)
+}
@@ -0,0 +1,337 @@
+package alphavantage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fiscalPeriodSlack is how many days after a month end a period may end
+// and still count as ending with that month, e.g. 52/53 week years which
+// end on 2021-01-02 instead of 2020-12-31.
+const fiscalPeriodSlack = 7
+
+// PeriodReport is implemented by the annual and quarterly reports of the
+// statements and earnings.
+type PeriodReport interface {
+	FiscalPeriodEnd() string
+}
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r BsAnnualReport) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r BsQuarterlyReport) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r IsAnnualReport) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r IsQuarterlyReport) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r CfAnnualReport) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r CfQuarterlyReport) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r AnnualEarnings) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r QuarterlyEarnings) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r BsAnnualReportDecimal) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r BsQuarterlyReportDecimal) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r IsAnnualReportDecimal) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r IsQuarterlyReportDecimal) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r CfAnnualReportDecimal) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r CfQuarterlyReportDecimal) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r AnnualEarningsDecimal) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// FiscalPeriodEnd returns the fiscal date ending.
+func (r QuarterlyEarningsDecimal) FiscalPeriodEnd() string { return r.FiscalDateEnding }
+
+// Period is a fiscal year or a quarter of it.  Fiscal years are named
+// after the calendar year they end in, e.g. Apple's fiscal year 2024 ends
+// in September 2024 and its first quarter in December 2023.
+type Period struct {
+	FiscalYear int
+	// FiscalQuarter is 1 to 4, 0 for a fiscal year
+	FiscalQuarter int
+	// End is the fiscal date ending of the report
+	End time.Time
+}
+
+// IsAnnual reports whether the period is a fiscal year.
+func (p Period) IsAnnual() bool {
+	return p.FiscalQuarter == 0
+}
+
+// String returns e.g. "FY2024" or "FY2024Q1".
+func (p Period) String() string {
+	if p.IsAnnual() {
+		return fmt.Sprintf("FY%d", p.FiscalYear)
+	}
+	return fmt.Sprintf("FY%dQ%d", p.FiscalYear, p.FiscalQuarter)
+}
+
+// Before reports whether the fiscal period p is before q.
+func (p Period) Before(q Period) bool {
+	if p.FiscalYear != q.FiscalYear {
+		return p.FiscalYear < q.FiscalYear
+	}
+	return p.FiscalQuarter < q.FiscalQuarter
+}
+
+// FiscalCalendar maps the end dates of reports to fiscal periods.
+type FiscalCalendar struct {
+	// YearEnd is the last month of the fiscal year
+	YearEnd time.Month
+}
+
+// CalendarYear is the calendar of companies whose fiscal year is the
+// calendar year.
+var CalendarYear = FiscalCalendar{YearEnd: time.December}
+
+// NewFiscalCalendar returns the calendar of the company, e.g. with the
+// fiscal year ending in "September".
+func NewFiscalCalendar(overview *CompanyOverview) (FiscalCalendar, error) {
+	month, err := time.Parse("January", strings.TrimSpace(overview.FiscalYearEnd))
+	if err != nil {
+		return FiscalCalendar{}, fmt.Errorf("invalid fiscal year end: %q", overview.FiscalYearEnd)
+	}
+	return FiscalCalendar{YearEnd: month.Month()}, nil
+}
+
+// Period returns the fiscal period ending on the date.  Periods may end a
+// few days after a month end, e.g. on the last Saturday of a month or the
+// first days of the next.
+func (fc FiscalCalendar) Period(end time.Time, annual bool) Period {
+	month := end.AddDate(0, 0, -fiscalPeriodSlack)
+	year, m := month.Year(), month.Month()
+	yearEnd := fc.YearEnd
+	if yearEnd == 0 {
+		yearEnd = time.December
+	}
+	period := Period{FiscalYear: year, End: end}
+	if m > yearEnd {
+		period.FiscalYear++
+	}
+	if !annual {
+		// 0 for the first month of the fiscal year, 11 for the last
+		offset := (int(m) - int(yearEnd) + 11) % 12
+		period.FiscalQuarter = offset/3 + 1
+	}
+	return period
+}
+
+// PeriodEntry is a report of a PeriodIndex.
+type PeriodEntry[R PeriodReport] struct {
+	Period Period
+	Report R
+}
+
+// PeriodIndex looks up reports by fiscal period, e.g. the quarterly
+// reports of an IncomeStatement.  The entries are sorted by end date,
+// oldest first.
+type PeriodIndex[R PeriodReport] struct {
+	calendar FiscalCalendar
+	entries  []PeriodEntry[R]
+}
+
+// NewAnnualIndex indexes annual reports by fiscal year.
+func NewAnnualIndex[R PeriodReport](reports []R, calendar FiscalCalendar) (*PeriodIndex[R], error) {
+	return newPeriodIndex(reports, calendar, true)
+}
+
+// NewQuarterlyIndex indexes quarterly reports by fiscal quarter.
+func NewQuarterlyIndex[R PeriodReport](reports []R, calendar FiscalCalendar) (*PeriodIndex[R], error) {
+	return newPeriodIndex(reports, calendar, false)
+}
+
+func newPeriodIndex[R PeriodReport](reports []R, calendar FiscalCalendar, annual bool) (*PeriodIndex[R], error) {
+	index := &PeriodIndex[R]{calendar: calendar}
+	seen := make(map[Period]bool, len(reports))
+	for _, report := range reports {
+		end, err := time.Parse(DateFormat, report.FiscalPeriodEnd())
+		if err != nil {
+			return nil, fmt.Errorf("invalid fiscal date ending: %w", err)
+		}
+		period := calendar.Period(end, annual)
+		key := periodKey(period)
+		if seen[key] {
+			// the API lists the latest report first, keep it
+			continue
+		}
+		seen[key] = true
+		index.entries = append(index.entries, PeriodEntry[R]{Period: period, Report: report})
+	}
+	sort.SliceStable(index.entries, func(i, j int) bool {
+		return index.entries[i].Period.End.Before(index.entries[j].Period.End)
+	})
+	return index, nil
+}
+
+// Calendar returns the fiscal calendar of the index.
+func (pi *PeriodIndex[R]) Calendar() FiscalCalendar {
+	return pi.calendar
+}
+
+// Len returns the number of reports.
+func (pi *PeriodIndex[R]) Len() int {
+	return len(pi.entries)
+}
+
+// Entries returns the reports with their periods, oldest first.
+func (pi *PeriodIndex[R]) Entries() []PeriodEntry[R] {
+	return append([]PeriodEntry[R]{}, pi.entries...)
+}
+
+// Periods returns the periods, oldest first.
+func (pi *PeriodIndex[R]) Periods() []Period {
+	periods := make([]Period, len(pi.entries))
+	for i, entry := range pi.entries {
+		periods[i] = entry.Period
+	}
+	return periods
+}
+
+// Latest returns the most recent report.
+func (pi *PeriodIndex[R]) Latest() (PeriodEntry[R], bool) {
+	if len(pi.entries) == 0 {
+		return PeriodEntry[R]{}, false
+	}
+	return pi.entries[len(pi.entries)-1], true
+}
+
+// Get returns the report of the fiscal year and quarter, quarter 0 for
+// annual reports.
+func (pi *PeriodIndex[R]) Get(fiscalYear, fiscalQuarter int) (PeriodEntry[R], bool) {
+	for _, entry := range pi.entries {
+		if entry.Period.FiscalYear == fiscalYear && entry.Period.FiscalQuarter == fiscalQuarter {
+			return entry, true
+		}
+	}
+	return PeriodEntry[R]{}, false
+}
+
+// Nearest returns the report which ended closest to the date, e.g. to
+// match reports with slightly different end dates.  ok is false if there
+// is no report within the tolerance.
+func (pi *PeriodIndex[R]) Nearest(date time.Time, tolerance time.Duration) (entry PeriodEntry[R], ok bool) {
+	var best time.Duration
+	for _, e := range pi.entries {
+		distance := e.Period.End.Sub(date)
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance <= tolerance && (!ok || distance < best) {
+			entry, best, ok = e, distance, true
+		}
+	}
+	return entry, ok
+}
+
+// AsOf returns the latest report which ended on or before the date.
+func (pi *PeriodIndex[R]) AsOf(date time.Time) (PeriodEntry[R], bool) {
+	i := sort.Search(len(pi.entries), func(i int) bool {
+		return pi.entries[i].Period.End.After(date)
+	})
+	if i == 0 {
+		return PeriodEntry[R]{}, false
+	}
+	return pi.entries[i-1], true
+}
+
+// Range returns the reports which ended between from and to, inclusive.
+func (pi *PeriodIndex[R]) Range(from, to time.Time) []PeriodEntry[R] {
+	var entries []PeriodEntry[R]
+	for _, entry := range pi.entries {
+		if !entry.Period.End.Before(from) && !entry.Period.End.After(to) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// FiscalYears returns the reports of the fiscal years first to last,
+// inclusive.
+func (pi *PeriodIndex[R]) FiscalYears(first, last int) []PeriodEntry[R] {
+	var entries []PeriodEntry[R]
+	for _, entry := range pi.entries {
+		if entry.Period.FiscalYear >= first && entry.Period.FiscalYear <= last {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// mostRecentReport returns the report with the latest fiscal date
+// ending, the first one of equal dates and an empty report if there are
+// none.  It's the lookup of the deprecated Get*MostRecent*Report helpers.
+func mostRecentReport[R PeriodReport](reports []R) (*R, error) {
+	var mostRecent R
+	mostRecentDate := time.Time{}
+	for _, report := range reports {
+		reportDate, err := time.Parse(DateFormat, report.FiscalPeriodEnd())
+		if err != nil {
+			return nil, fmt.Errorf("Parse error: %v", err)
+		}
+		if reportDate.After(mostRecentDate) {
+			mostRecent = report
+			mostRecentDate = reportDate
+		}
+	}
+	return &mostRecent, nil
+}
+
+// calendarQuarterReport returns the first quarterly report ending on the
+// last day of the calendar quarter 0 to 3 of the year.  It's the lookup
+// of the deprecated Get*HistoricalQuarterlyReport helpers.
+func calendarQuarterReport[R PeriodReport](reports []R, year int, quarter int) (*R, error) {
+	if quarter < 0 || quarter > 3 {
+		return nil, errors.New("Quarter must be between 0 and 3")
+	}
+	targetDates := []string{
+		fmt.Sprintf("%d-03-31", year),
+		fmt.Sprintf("%d-06-30", year),
+		fmt.Sprintf("%d-09-30", year),
+		fmt.Sprintf("%d-12-31", year),
+	}
+	for _, report := range reports {
+		if report.FiscalPeriodEnd() == targetDates[quarter] {
+			return &report, nil
+		}
+	}
+	return nil, errors.New("Not found")
+}
+
+// calendarYearReport returns the annual report ending in the year.
+func calendarYearReport[R PeriodReport](reports []R, year int) (*R, error) {
+	for _, report := range reports {
+		end, err := time.Parse(DateFormat, report.FiscalPeriodEnd())
+		if err != nil {
+			return nil, fmt.Errorf("Parse error: %v", err)
+		}
+		if end.Year() == year {
+			return &report, nil
+		}
+	}
+	return nil, errors.New("Not found")
+}
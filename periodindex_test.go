@@ -0,0 +1,144 @@
+package alphavantage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AMekss/assert"
+)
+
+// fiscal year ends on the last Saturday of September, like Apple's
+var testQuarterlyIncome = []IsQuarterlyReport{
+	{FiscalDateEnding: "2024-06-29", TotalRevenue: NewAVInt(85777000000)},
+	{FiscalDateEnding: "2024-03-30", TotalRevenue: NewAVInt(90753000000)},
+	{FiscalDateEnding: "2023-12-30", TotalRevenue: NewAVInt(119575000000)},
+	{FiscalDateEnding: "2023-09-30", TotalRevenue: NewAVInt(89498000000)},
+	{FiscalDateEnding: "2023-07-01", TotalRevenue: NewAVInt(81797000000)},
+}
+
+func testDate(t *testing.T, date string) time.Time {
+	d, err := time.Parse(DateFormat, date)
+	assert.NoError(t.Fatalf, err)
+	return d
+}
+
+func TestNewFiscalCalendar(t *testing.T) {
+	calendar, err := NewFiscalCalendar(&CompanyOverview{FiscalYearEnd: "September"})
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, int(time.September), int(calendar.YearEnd))
+
+	_, err = NewFiscalCalendar(&CompanyOverview{FiscalYearEnd: "None"})
+	assert.ErrorIncludesMessage(t, `invalid fiscal year end: "None"`, err)
+}
+
+func TestFiscalCalendarPeriod(t *testing.T) {
+	september := FiscalCalendar{YearEnd: time.September}
+	assert.EqualStrings(t, "FY2024Q1", september.Period(testDate(t, "2023-12-30"), false).String())
+	assert.EqualStrings(t, "FY2023Q3", september.Period(testDate(t, "2023-07-01"), false).String())
+	assert.EqualStrings(t, "FY2023Q4", september.Period(testDate(t, "2023-09-30"), false).String())
+	assert.EqualStrings(t, "FY2024", september.Period(testDate(t, "2024-09-28"), true).String())
+
+	june := FiscalCalendar{YearEnd: time.June}
+	assert.EqualStrings(t, "FY2024Q1", june.Period(testDate(t, "2023-09-30"), false).String())
+	assert.EqualStrings(t, "FY2024Q4", june.Period(testDate(t, "2024-06-30"), false).String())
+
+	// 53 week year ending in January
+	assert.EqualStrings(t, "FY2020Q4", CalendarYear.Period(testDate(t, "2021-01-02"), false).String())
+	assert.EqualStrings(t, "FY2023Q1", CalendarYear.Period(testDate(t, "2023-03-31"), false).String())
+}
+
+func TestPeriodIndex(t *testing.T) {
+	index, err := NewQuarterlyIndex(testQuarterlyIncome, FiscalCalendar{YearEnd: time.September})
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 5, index.Len())
+
+	periods := index.Periods()
+	assert.EqualStrings(t, "FY2023Q3", periods[0].String())
+	assert.EqualStrings(t, "FY2024Q3", periods[4].String())
+
+	latest, ok := index.Latest()
+	assert.True(t, ok)
+	assert.EqualStrings(t, "2024-06-29", latest.Report.FiscalDateEnding)
+
+	entry, ok := index.Get(2024, 1)
+	assert.True(t, ok)
+	assert.EqualInt(t, 119575000000, entry.Report.TotalRevenue.Value)
+	_, ok = index.Get(2022, 4)
+	assert.False(t, ok)
+
+	entry, ok = index.Nearest(testDate(t, "2023-12-31"), 7*24*time.Hour)
+	assert.True(t, ok)
+	assert.EqualStrings(t, "2023-12-30", entry.Report.FiscalDateEnding)
+	_, ok = index.Nearest(testDate(t, "2023-11-15"), 7*24*time.Hour)
+	assert.False(t, ok)
+
+	entry, ok = index.AsOf(testDate(t, "2024-03-29"))
+	assert.True(t, ok)
+	assert.EqualStrings(t, "2023-12-30", entry.Report.FiscalDateEnding)
+	entry, ok = index.AsOf(testDate(t, "2024-03-30"))
+	assert.True(t, ok)
+	assert.EqualStrings(t, "2024-03-30", entry.Report.FiscalDateEnding)
+	_, ok = index.AsOf(testDate(t, "2023-01-01"))
+	assert.False(t, ok)
+
+	entries := index.Range(testDate(t, "2023-09-30"), testDate(t, "2024-03-30"))
+	assert.EqualInt(t, 3, len(entries))
+	assert.EqualStrings(t, "FY2023Q4", entries[0].Period.String())
+
+	entries = index.FiscalYears(2024, 2024)
+	assert.EqualInt(t, 3, len(entries))
+}
+
+func TestPeriodIndexInvalidDate(t *testing.T) {
+	_, err := NewAnnualIndex([]AnnualEarnings{{FiscalDateEnding: "None"}}, CalendarYear)
+	assert.ErrorIncludesMessage(t, "invalid fiscal date ending", err)
+}
+
+func TestPeriodIndexDuplicates(t *testing.T) {
+	reports := []AnnualEarnings{
+		{FiscalDateEnding: "2023-12-31", ReportedEPS: NewAVFloat64(9.61)},
+		{FiscalDateEnding: "2023-12-30", ReportedEPS: NewAVFloat64(9.5)},
+		{FiscalDateEnding: "2022-12-31", ReportedEPS: NewAVFloat64(9.13)},
+	}
+	index, err := NewAnnualIndex(reports, CalendarYear)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 2, index.Len())
+	entry, ok := index.Get(2023, 0)
+	assert.True(t, ok)
+	assert.EqualFloat64(t, 9.61, entry.Report.ReportedEPS.Value)
+}
+
+func TestGetIncomeStatementHistoricalQuarterlyReport(t *testing.T) {
+	is := &IncomeStatement{QuarterlyReports: testQuarterlyIncome}
+	report, err := GetIncomeStatementHistoricalQuarterlyReport(is, 2023, 2)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "2023-09-30", report.FiscalDateEnding)
+
+	// only reports ending on the last day of the calendar quarter, unlike
+	// the index: 2023-07-01 isn't the second quarter, 2023-12-30 not the
+	// fourth
+	_, err = GetIncomeStatementHistoricalQuarterlyReport(is, 2023, 1)
+	assert.ErrorIncludesMessage(t, "Not found", err)
+	_, err = GetIncomeStatementHistoricalQuarterlyReport(is, 2023, 3)
+	assert.ErrorIncludesMessage(t, "Not found", err)
+	_, err = GetIncomeStatementHistoricalQuarterlyReport(is, 2023, 4)
+	assert.ErrorIncludesMessage(t, "Quarter must be between 0 and 3", err)
+
+	cf := &CashFlow{QuarterlyReports: []CfQuarterlyReport{
+		{FiscalDateEnding: "2023-06-15"},
+		{FiscalDateEnding: "2023-06-30", NetIncome: NewAVInt(1)},
+		{FiscalDateEnding: "2023-06-30", NetIncome: NewAVInt(2)},
+	}}
+	cfReport, err := GetCashFlowHistoricalQuarterlyReport(cf, 2023, 1)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 1, cfReport.NetIncome.Value)
+	_, err = GetCashFlowHistoricalQuarterlyReport(cf, 2023, 0)
+	assert.ErrorIncludesMessage(t, "Not found", err)
+
+	report, err = GetIncomeStatementMostRecentQuarterlyReport(is)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "2024-06-29", report.FiscalDateEnding)
+	cfReport, err = GetCashFlowMostRecentQuarterlyReport(cf)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 1, cfReport.NetIncome.Value)
+}
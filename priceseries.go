@@ -0,0 +1,136 @@
+package alphavantage
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Bar represents a single OHLCV data point of a price series.
+type Bar struct {
+	Date   string
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume uint64
+}
+
+// PriceSeries is a date ordered (oldest first) series of bars which
+// indicators and statistics can be computed from locally.
+type PriceSeries struct {
+	Symbol        string
+	LastRefreshed string
+	Interval      Interval
+	TimeZone      string
+	Bars          []Bar
+}
+
+// NewPriceSeries creates a PriceSeries from the given bars.  The
+// bars can be passed in any order, e.g. intraday bars collected
+// outside of this package.
+func NewPriceSeries(symbol string, interval Interval, bars []Bar) *PriceSeries {
+	sorted := make([]Bar, len(bars))
+	copy(sorted, bars)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+	ps := &PriceSeries{
+		Symbol:   symbol,
+		Interval: interval,
+		Bars:     sorted,
+	}
+	if len(sorted) > 0 {
+		ps.LastRefreshed = sorted[len(sorted)-1].Date
+	}
+	return ps
+}
+
+// PriceSeries converts the filled interval of the time series into a
+// date ordered PriceSeries.
+func (ts *TimeSeries) PriceSeries() *PriceSeries {
+	datasets := ts.getFilledData()
+	bars := make([]Bar, 0, len(datasets))
+	for date, data := range datasets {
+		bars = append(bars, Bar{
+			Date:   date,
+			Open:   data.Open,
+			High:   data.High,
+			Low:    data.Low,
+			Close:  data.Close,
+			Volume: data.Volume,
+		})
+	}
+	ps := NewPriceSeries(ts.Metadata.Symbol, ts.getFilledInterval(), bars)
+	ps.TimeZone = ts.Metadata.Zone()
+	if ts.Metadata.LastRefreshed != "" {
+		ps.LastRefreshed = ts.Metadata.LastRefreshed
+	}
+	return ps
+}
+
+// PriceSeries converts the filled interval of the adjusted time series
+// into a date ordered PriceSeries.  If adjusted is true, open, high and
+// low are scaled by the same factor as the adjusted close and the close
+// is replaced by the adjusted close.
+func (ts *TimeSeriesAdjusted) PriceSeries(adjusted bool) *PriceSeries {
+	datasets := ts.getFilledData()
+	bars := make([]Bar, 0, len(datasets))
+	for date, data := range datasets {
+		bar := Bar{
+			Date:   date,
+			Open:   data.Open,
+			High:   data.High,
+			Low:    data.Low,
+			Close:  data.Close,
+			Volume: data.Volume,
+		}
+		if adjusted && data.Close != 0 {
+			factor := data.AdjustedClose / data.Close
+			bar.Open *= factor
+			bar.High *= factor
+			bar.Low *= factor
+			bar.Close = data.AdjustedClose
+		}
+		bars = append(bars, bar)
+	}
+	ps := NewPriceSeries(ts.Metadata.Symbol, ts.getFilledInterval(), bars)
+	ps.TimeZone = ts.Metadata.Zone()
+	if ts.Metadata.LastRefreshed != "" {
+		ps.LastRefreshed = ts.Metadata.LastRefreshed
+	}
+	return ps
+}
+
+// Len returns the number of bars
+func (ps *PriceSeries) Len() int {
+	return len(ps.Bars)
+}
+
+// Dates returns the dates of all bars, oldest first.
+func (ps *PriceSeries) Dates() []string {
+	dates := make([]string, len(ps.Bars))
+	for i, bar := range ps.Bars {
+		dates[i] = bar.Date
+	}
+	return dates
+}
+
+// Values returns the prices selected by seriesType, oldest first.
+func (ps *PriceSeries) Values(seriesType SeriesType) ([]float64, error) {
+	values := make([]float64, len(ps.Bars))
+	for i, bar := range ps.Bars {
+		switch seriesType {
+		case SeriesTypeOpen:
+			values[i] = bar.Open
+		case SeriesTypeHigh:
+			values[i] = bar.High
+		case SeriesTypeLow:
+			values[i] = bar.Low
+		case SeriesTypeClose:
+			values[i] = bar.Close
+		default:
+			return nil, fmt.Errorf("unknown series type: %s", seriesType)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,63 @@
+package alphavantage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestTimeSeriesAdjustedPriceSeries(t *testing.T) {
+	var buf = `
+	{
+		"Meta Data": {
+			"1. Information": "Weekly Adjusted Prices and Volumes",
+			"2. Symbol": "STOCK2",
+			"3. Last Refreshed": "2020-02-14",
+			"4. Time Zone": "US/Eastern"
+		},
+		"Weekly Adjusted Time Series": {
+			"2020-02-14": {
+				"1. open": "100.0000",
+				"2. high": "110.0000",
+				"3. low": "90.0000",
+				"4. close": "100.0000",
+				"5. adjusted close": "50.0000",
+				"6. volume": "2000",
+				"7. dividend amount": "0.0000",
+				"8. split coefficient": "1.0000"
+			},
+			"2020-02-07": {
+				"1. open": "95.0000",
+				"2. high": "101.0000",
+				"3. low": "94.0000",
+				"4. close": "98.0000",
+				"5. adjusted close": "49.0000",
+				"6. volume": "1000",
+				"7. dividend amount": "0.0000",
+				"8. split coefficient": "1.0000"
+			}
+		}
+	}
+`
+	timeSeries, err := toTimeSeriesAdjusted([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "TIME_SERIES_WEEKLY_ADJUSTED", buf, timeSeries)
+
+	raw := timeSeries.PriceSeries(false)
+	assert.EqualStrings(t, "STOCK2", raw.Symbol)
+	assert.EqualStrings(t, string(IntervalWeekly), string(raw.Interval))
+	assert.EqualInt(t, 2, raw.Len())
+	assert.EqualStrings(t, "2020-02-07", raw.Bars[0].Date)
+	assert.EqualStrings(t, "2020-02-14", raw.Bars[1].Date)
+	assert.EqualFloat64(t, 100, raw.Bars[1].Close)
+
+	adjusted := timeSeries.PriceSeries(true)
+	assert.EqualFloat64(t, 50, adjusted.Bars[1].Close)
+	assert.EqualFloat64(t, 55, adjusted.Bars[1].High)
+	assert.EqualFloat64(t, 45, adjusted.Bars[1].Low)
+	assert.EqualInt(t, 2000, int(adjusted.Bars[1].Volume))
+
+	closes, err := adjusted.Values(SeriesTypeClose)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64(t, 49, closes[0])
+}
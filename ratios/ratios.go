@@ -0,0 +1,402 @@
+// Package ratios computes liquidity, leverage, profitability, efficiency
+// and valuation ratios from the statements of alphavantage.Financials:
+//
+//	financials, err := client.Financials("IBM", alphavantage.CalendarYear)
+//	r, err := ratios.New(financials, ratios.TTM, 2024, 1, price)
+//
+// Line items which are "None" or periods which aren't reported are errors
+// of the ratios which need them, see Ratios.Errors.
+package ratios
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sklinkert/alphavantage"
+)
+
+// Basis selects the statements ratios are computed from.
+type Basis int
+
+// Bases of ratios.  Balance sheet line items are the ones at the end of
+// the period, for TTM at the end of the last quarter.
+const (
+	// Annual uses the reports of a fiscal year.
+	Annual Basis = iota
+	// Quarterly uses the reports of a fiscal quarter, flows like revenue
+	// aren't annualized.
+	Quarterly
+	// TTM sums the income statement and cash flow line items of the last
+	// four quarters.
+	TTM
+)
+
+// String returns "annual", "quarterly" or "TTM".
+func (b Basis) String() string {
+	switch b {
+	case Annual:
+		return "annual"
+	case Quarterly:
+		return "quarterly"
+	case TTM:
+		return "TTM"
+	}
+	return fmt.Sprintf("Basis(%d)", int(b))
+}
+
+// days returns the number of days of the period of the basis
+func (b Basis) days() float64 {
+	if b == Quarterly {
+		return 365.0 / 4
+	}
+	return 365
+}
+
+// ErrNoPrice is returned for valuation ratios without a price.
+var ErrNoPrice = errors.New("no price")
+
+// Names of the ratios, the keys of Ratios.Errors
+const (
+	Current           = "currentRatio"
+	Quick             = "quickRatio"
+	DebtToEquity      = "debtToEquity"
+	InterestCoverage  = "interestCoverage"
+	ROIC              = "roic"
+	GrossMargin       = "grossMargin"
+	OperatingMargin   = "operatingMargin"
+	NetMargin         = "netMargin"
+	FreeCashFlow      = "freeCashFlow"
+	AssetTurnover     = "assetTurnover"
+	DSO               = "dso"
+	DIO               = "dio"
+	DPO               = "dpo"
+	MarketCap         = "marketCap"
+	FCFYield          = "fcfYield"
+	PriceToEarnings   = "priceToEarnings"
+	PriceToBook       = "priceToBook"
+	PriceToSales      = "priceToSales"
+	EnterpriseValue   = "enterpriseValue"
+	EVToEBITDA        = "evToEbitda"
+	EarningsPerShare  = "earningsPerShare"
+	BookValuePerShare = "bookValuePerShare"
+)
+
+// Ratios of a period.  Ratios which can't be computed, e.g. because a
+// line item is "None" or the price is missing, are "None" and their error
+// is in Errors by ratio name.
+type Ratios struct {
+	Period alphavantage.Period
+	Basis  Basis
+	Price  float64
+
+	// Liquidity and leverage
+	CurrentRatio     alphavantage.AVFloat64
+	QuickRatio       alphavantage.AVFloat64
+	DebtToEquity     alphavantage.AVFloat64
+	InterestCoverage alphavantage.AVFloat64
+
+	// Profitability
+	ROIC            alphavantage.AVFloat64
+	GrossMargin     alphavantage.AVFloat64
+	OperatingMargin alphavantage.AVFloat64
+	NetMargin       alphavantage.AVFloat64
+	FreeCashFlow    alphavantage.AVFloat64
+
+	// Efficiency, DSO, DIO and DPO are in days
+	AssetTurnover alphavantage.AVFloat64
+	DSO           alphavantage.AVFloat64
+	DIO           alphavantage.AVFloat64
+	DPO           alphavantage.AVFloat64
+
+	// Valuation, only with a price
+	MarketCap         alphavantage.AVFloat64
+	EnterpriseValue   alphavantage.AVFloat64
+	FCFYield          alphavantage.AVFloat64
+	PriceToEarnings   alphavantage.AVFloat64
+	PriceToBook       alphavantage.AVFloat64
+	PriceToSales      alphavantage.AVFloat64
+	EVToEBITDA        alphavantage.AVFloat64
+	EarningsPerShare  alphavantage.AVFloat64
+	BookValuePerShare alphavantage.AVFloat64
+
+	Errors map[string]error
+}
+
+// ratioInputs looks up the line items of a period, see
+// alphavantage.Inputs.
+type ratioInputs struct {
+	*alphavantage.Inputs
+	basis         Basis
+	fiscalYear    int
+	fiscalQuarter int
+	price         float64
+}
+
+// flow returns an income statement or cash flow line item of the period.
+func (in *ratioInputs) flow(item string) float64 {
+	if in.basis == TTM {
+		return in.TTM(item, in.fiscalYear, in.fiscalQuarter)
+	}
+	return in.Value(item, in.fiscalYear, in.fiscalQuarter)
+}
+
+// stock returns a balance sheet line item at the end of the period.
+func (in *ratioInputs) stock(item string) float64 {
+	return in.PointInTime(item, in.fiscalYear, in.fiscalQuarter)
+}
+
+// sharePrice returns the price, ErrNoPrice without one.
+func (in *ratioInputs) sharePrice() float64 {
+	if in.price <= 0 {
+		in.Fail(ErrNoPrice)
+	}
+	return in.price
+}
+
+// currentRatio = totalCurrentAssets / totalCurrentLiabilities
+func currentRatio(in *ratioInputs) float64 {
+	return in.Divide(Current, in.stock("totalCurrentAssets"), in.stock("totalCurrentLiabilities"))
+}
+
+// quickRatio = (cashAndShortTermInvestments + currentNetReceivables) /
+// totalCurrentLiabilities, the current assets without inventory and
+// other current assets.
+func quickRatio(in *ratioInputs) float64 {
+	quickAssets := in.stock("cashAndShortTermInvestments") + in.stock("currentNetReceivables")
+	return in.Divide(Quick, quickAssets, in.stock("totalCurrentLiabilities"))
+}
+
+// debtToEquity = shortLongTermDebtTotal / totalShareholderEquity, with the
+// short and long term debt including the current portion.
+func debtToEquity(in *ratioInputs) float64 {
+	return in.Divide(DebtToEquity, in.stock("shortLongTermDebtTotal"), in.stock("totalShareholderEquity"))
+}
+
+// interestCoverage = ebit / interestExpense
+func interestCoverage(in *ratioInputs) float64 {
+	return in.Divide(InterestCoverage, in.flow("ebit"), in.flow("interestExpense"))
+}
+
+// roic = NOPAT / invested capital, with
+//
+//	NOPAT = operatingIncome * (1 - incomeTaxExpense / incomeBeforeTax)
+//	invested capital = shortLongTermDebtTotal + totalShareholderEquity - cashAndShortTermInvestments
+//
+// The effective tax rate is limited to 0 to 1, e.g. for tax benefits.
+// The quarterly ROIC isn't annualized.
+func roic(in *ratioInputs) float64 {
+	operatingIncome := in.flow("operatingIncome")
+	taxRate := in.Divide("tax rate", in.flow("incomeTaxExpense"), in.flow("incomeBeforeTax"))
+	if taxRate < 0 {
+		taxRate = 0
+	} else if taxRate > 1 {
+		taxRate = 1
+	}
+	investedCapital := in.stock("shortLongTermDebtTotal") + in.stock("totalShareholderEquity") - in.stock("cashAndShortTermInvestments")
+	return in.Divide(ROIC, operatingIncome*(1-taxRate), investedCapital)
+}
+
+// grossMargin = grossProfit / totalRevenue
+func grossMargin(in *ratioInputs) float64 {
+	return in.Divide(GrossMargin, in.flow("grossProfit"), in.flow("totalRevenue"))
+}
+
+// operatingMargin = operatingIncome / totalRevenue
+func operatingMargin(in *ratioInputs) float64 {
+	return in.Divide(OperatingMargin, in.flow("operatingIncome"), in.flow("totalRevenue"))
+}
+
+// netMargin = netIncome / totalRevenue, with the net income of the income
+// statement
+func netMargin(in *ratioInputs) float64 {
+	return in.Divide(NetMargin, in.flow("netIncome"), in.flow("totalRevenue"))
+}
+
+// freeCashFlow = operatingCashflow - capitalExpenditures, the capital
+// expenditures are reported as a positive number.
+func freeCashFlow(in *ratioInputs) float64 {
+	return in.flow("operatingCashflow") - in.flow("capitalExpenditures")
+}
+
+// assetTurnover = totalRevenue / totalAssets, with the total assets at the
+// end of the period
+func assetTurnover(in *ratioInputs) float64 {
+	return in.Divide(AssetTurnover, in.flow("totalRevenue"), in.stock("totalAssets"))
+}
+
+// dso = currentNetReceivables / totalRevenue * days, days sales
+// outstanding with 365 days per year and 91.25 per quarter
+func dso(in *ratioInputs) float64 {
+	return in.Divide(DSO, in.stock("currentNetReceivables"), in.flow("totalRevenue")) * in.basis.days()
+}
+
+// dio = inventory / costOfRevenue * days, days inventory outstanding
+func dio(in *ratioInputs) float64 {
+	return in.Divide(DIO, in.stock("inventory"), in.flow("costOfRevenue")) * in.basis.days()
+}
+
+// dpo = currentAccountsPayable / costOfRevenue * days, days payables
+// outstanding
+func dpo(in *ratioInputs) float64 {
+	return in.Divide(DPO, in.stock("currentAccountsPayable"), in.flow("costOfRevenue")) * in.basis.days()
+}
+
+// marketCap = price * commonStockSharesOutstanding, with the shares at the
+// end of the period
+func marketCap(in *ratioInputs) float64 {
+	return in.sharePrice() * in.stock("commonStockSharesOutstanding")
+}
+
+// enterpriseValue = market cap + shortLongTermDebtTotal -
+// cashAndShortTermInvestments
+func enterpriseValue(in *ratioInputs) float64 {
+	return marketCap(in) + in.stock("shortLongTermDebtTotal") - in.stock("cashAndShortTermInvestments")
+}
+
+// fcfYield = free cash flow / market cap
+func fcfYield(in *ratioInputs) float64 {
+	return in.Divide(FCFYield, freeCashFlow(in), marketCap(in))
+}
+
+// earningsPerShare = netIncome / commonStockSharesOutstanding
+func earningsPerShare(in *ratioInputs) float64 {
+	return in.Divide(EarningsPerShare, in.flow("netIncome"), in.stock("commonStockSharesOutstanding"))
+}
+
+// priceToEarnings = price / earnings per share
+func priceToEarnings(in *ratioInputs) float64 {
+	eps := earningsPerShare(in)
+	return in.Divide(PriceToEarnings, in.sharePrice(), eps)
+}
+
+// bookValuePerShare = totalShareholderEquity / commonStockSharesOutstanding
+func bookValuePerShare(in *ratioInputs) float64 {
+	return in.Divide(BookValuePerShare, in.stock("totalShareholderEquity"), in.stock("commonStockSharesOutstanding"))
+}
+
+// priceToBook = market cap / totalShareholderEquity
+func priceToBook(in *ratioInputs) float64 {
+	return in.Divide(PriceToBook, marketCap(in), in.stock("totalShareholderEquity"))
+}
+
+// priceToSales = market cap / totalRevenue
+func priceToSales(in *ratioInputs) float64 {
+	return in.Divide(PriceToSales, marketCap(in), in.flow("totalRevenue"))
+}
+
+// evToEBITDA = enterprise value / ebitda
+func evToEBITDA(in *ratioInputs) float64 {
+	return in.Divide(EVToEBITDA, enterpriseValue(in), in.flow("ebitda"))
+}
+
+// ratioDefinitions are the ratios by name with their field of Ratios
+var ratioDefinitions = []struct {
+	name    string
+	compute func(*ratioInputs) float64
+	field   func(*Ratios) *alphavantage.AVFloat64
+}{
+	{Current, currentRatio, func(r *Ratios) *alphavantage.AVFloat64 { return &r.CurrentRatio }},
+	{Quick, quickRatio, func(r *Ratios) *alphavantage.AVFloat64 { return &r.QuickRatio }},
+	{DebtToEquity, debtToEquity, func(r *Ratios) *alphavantage.AVFloat64 { return &r.DebtToEquity }},
+	{InterestCoverage, interestCoverage, func(r *Ratios) *alphavantage.AVFloat64 { return &r.InterestCoverage }},
+	{ROIC, roic, func(r *Ratios) *alphavantage.AVFloat64 { return &r.ROIC }},
+	{GrossMargin, grossMargin, func(r *Ratios) *alphavantage.AVFloat64 { return &r.GrossMargin }},
+	{OperatingMargin, operatingMargin, func(r *Ratios) *alphavantage.AVFloat64 { return &r.OperatingMargin }},
+	{NetMargin, netMargin, func(r *Ratios) *alphavantage.AVFloat64 { return &r.NetMargin }},
+	{FreeCashFlow, freeCashFlow, func(r *Ratios) *alphavantage.AVFloat64 { return &r.FreeCashFlow }},
+	{AssetTurnover, assetTurnover, func(r *Ratios) *alphavantage.AVFloat64 { return &r.AssetTurnover }},
+	{DSO, dso, func(r *Ratios) *alphavantage.AVFloat64 { return &r.DSO }},
+	{DIO, dio, func(r *Ratios) *alphavantage.AVFloat64 { return &r.DIO }},
+	{DPO, dpo, func(r *Ratios) *alphavantage.AVFloat64 { return &r.DPO }},
+	{MarketCap, marketCap, func(r *Ratios) *alphavantage.AVFloat64 { return &r.MarketCap }},
+	{EnterpriseValue, enterpriseValue, func(r *Ratios) *alphavantage.AVFloat64 { return &r.EnterpriseValue }},
+	{FCFYield, fcfYield, func(r *Ratios) *alphavantage.AVFloat64 { return &r.FCFYield }},
+	{PriceToEarnings, priceToEarnings, func(r *Ratios) *alphavantage.AVFloat64 { return &r.PriceToEarnings }},
+	{PriceToBook, priceToBook, func(r *Ratios) *alphavantage.AVFloat64 { return &r.PriceToBook }},
+	{PriceToSales, priceToSales, func(r *Ratios) *alphavantage.AVFloat64 { return &r.PriceToSales }},
+	{EVToEBITDA, evToEBITDA, func(r *Ratios) *alphavantage.AVFloat64 { return &r.EVToEBITDA }},
+	{EarningsPerShare, earningsPerShare, func(r *Ratios) *alphavantage.AVFloat64 { return &r.EarningsPerShare }},
+	{BookValuePerShare, bookValuePerShare, func(r *Ratios) *alphavantage.AVFloat64 { return &r.BookValuePerShare }},
+}
+
+// New computes the ratios of the fiscal year (quarter 0, Annual) or
+// quarter (Quarterly, TTM).  The price is used for the valuation ratios, 0
+// if there is none, see QuotePrice and CloseAsOf.
+func New(f *alphavantage.Financials, basis Basis, fiscalYear, fiscalQuarter int, price float64) (*Ratios, error) {
+	period, err := ratioPeriod(f, basis, fiscalYear, fiscalQuarter)
+	if err != nil {
+		return nil, err
+	}
+	ratios := &Ratios{Period: period, Basis: basis, Price: price, Errors: make(map[string]error)}
+	for _, definition := range ratioDefinitions {
+		value, err := compute(f, definition.compute, basis, fiscalYear, fiscalQuarter, price)
+		if err != nil {
+			ratios.Errors[definition.name] = err
+			continue
+		}
+		*definition.field(ratios) = alphavantage.NewAVFloat64(value)
+	}
+	return ratios, nil
+}
+
+// Compute computes a single ratio by name, e.g. Current.
+func Compute(f *alphavantage.Financials, name string, basis Basis, fiscalYear, fiscalQuarter int, price float64) (float64, error) {
+	for _, definition := range ratioDefinitions {
+		if definition.name != name {
+			continue
+		}
+		if _, err := ratioPeriod(f, basis, fiscalYear, fiscalQuarter); err != nil {
+			return 0, err
+		}
+		return compute(f, definition.compute, basis, fiscalYear, fiscalQuarter, price)
+	}
+	return 0, fmt.Errorf("unknown ratio: %q", name)
+}
+
+// compute evaluates the formula for the period
+func compute(f *alphavantage.Financials, formula func(*ratioInputs) float64, basis Basis, fiscalYear, fiscalQuarter int, price float64) (float64, error) {
+	in := &ratioInputs{Inputs: f.Inputs(), basis: basis, fiscalYear: fiscalYear, fiscalQuarter: fiscalQuarter, price: price}
+	value := formula(in)
+	if err := in.Err(); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
+// ratioPeriod checks the period of the basis
+func ratioPeriod(f *alphavantage.Financials, basis Basis, fiscalYear, fiscalQuarter int) (alphavantage.Period, error) {
+	switch {
+	case basis == Annual && fiscalQuarter != 0:
+		return alphavantage.Period{}, fmt.Errorf("annual ratios need fiscal quarter 0, got %d", fiscalQuarter)
+	case basis != Annual && (fiscalQuarter < 1 || fiscalQuarter > 4):
+		return alphavantage.Period{}, fmt.Errorf("invalid fiscal quarter: %d", fiscalQuarter)
+	}
+	fp, ok := f.Get(fiscalYear, fiscalQuarter)
+	if !ok {
+		period := alphavantage.Period{FiscalYear: fiscalYear, FiscalQuarter: fiscalQuarter}
+		return alphavantage.Period{}, fmt.Errorf("%s: %w", period, alphavantage.ErrMissingPeriod)
+	}
+	return fp.Period, nil
+}
+
+// QuotePrice returns the price of the quote for valuation ratios.
+func QuotePrice(quote *alphavantage.GlobalQuote) (float64, error) {
+	if quote == nil || quote.Price <= 0 {
+		return 0, ErrNoPrice
+	}
+	return quote.Price, nil
+}
+
+// CloseAsOf returns the latest close on or before the date, e.g. the end
+// of a fiscal period, for valuation ratios, and its date.
+func CloseAsOf(ts *alphavantage.TimeSeries, date time.Time) (float64, string, error) {
+	day := date.Format(alphavantage.DateFormat)
+	bars := ts.PriceSeries().Bars
+	for i := len(bars) - 1; i >= 0; i-- {
+		if bars[i].Date <= day {
+			return bars[i].Close, bars[i].Date, nil
+		}
+	}
+	return 0, "", fmt.Errorf("%s: %w", day, ErrNoPrice)
+}
@@ -0,0 +1,173 @@
+package ratios
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AMekss/assert"
+	"github.com/sklinkert/alphavantage"
+)
+
+func newRatiosFinancials(t *testing.T) *alphavantage.Financials {
+	balanceSheet := &alphavantage.BalanceSheet{
+		AnnualReports: []alphavantage.BsAnnualReport{{
+			FiscalDateEnding:             "2023-12-31",
+			TotalAssets:                  alphavantage.NewAVInt(2000),
+			TotalCurrentAssets:           alphavantage.NewAVInt(800),
+			CashAndShortTermInvestments:  alphavantage.NewAVInt(300),
+			CurrentNetReceivables:        alphavantage.NewAVInt(200),
+			Inventory:                    alphavantage.NewAVInt(150),
+			TotalCurrentLiabilities:      alphavantage.NewAVInt(400),
+			CurrentAccountsPayable:       alphavantage.NewAVInt(120),
+			ShortLongTermDebtTotal:       alphavantage.NewAVInt(500),
+			TotalShareholderEquity:       alphavantage.NewAVInt(1000),
+			CommonStockSharesOutstanding: alphavantage.NewAVInt(100),
+		}},
+	}
+	incomeStatement := &alphavantage.IncomeStatement{
+		AnnualReports: []alphavantage.IsAnnualReport{{
+			FiscalDateEnding: "2023-12-31",
+			TotalRevenue:     alphavantage.NewAVInt(1460),
+			CostOfRevenue:    alphavantage.NewAVInt(730),
+			GrossProfit:      alphavantage.NewAVInt(730),
+			OperatingIncome:  alphavantage.NewAVInt(300),
+			Ebit:             alphavantage.NewAVInt(280),
+			Ebitda:           alphavantage.NewAVInt(350),
+			InterestExpense:  alphavantage.NewAVInt(20),
+			IncomeBeforeTax:  alphavantage.NewAVInt(260),
+			IncomeTaxExpense: alphavantage.NewAVInt(65),
+			NetIncome:        alphavantage.NewAVInt(195),
+		}},
+	}
+	cashFlow := &alphavantage.CashFlow{
+		AnnualReports: []alphavantage.CfAnnualReport{{
+			FiscalDateEnding:    "2023-12-31",
+			OperatingCashflow:   alphavantage.NewAVInt(320),
+			CapitalExpenditures: alphavantage.NewAVInt(120),
+		}},
+	}
+	for i, date := range []string{"2023-12-31", "2023-09-30", "2023-06-30", "2023-03-31"} {
+		balanceSheet.QuarterlyReports = append(balanceSheet.QuarterlyReports, alphavantage.BsQuarterlyReport(balanceSheet.AnnualReports[0]))
+		balanceSheet.QuarterlyReports[i].FiscalDateEnding = date
+		incomeStatement.QuarterlyReports = append(incomeStatement.QuarterlyReports, alphavantage.IsQuarterlyReport{
+			FiscalDateEnding: date,
+			TotalRevenue:     alphavantage.NewAVInt(365),
+			CostOfRevenue:    alphavantage.NewAVInt(182),
+			GrossProfit:      alphavantage.NewAVInt(183),
+			NetIncome:        alphavantage.NewAVInt(50),
+		})
+		cashFlow.QuarterlyReports = append(cashFlow.QuarterlyReports, alphavantage.CfQuarterlyReport{
+			FiscalDateEnding:    date,
+			OperatingCashflow:   alphavantage.NewAVInt(80),
+			CapitalExpenditures: alphavantage.NewAVInt(30),
+		})
+	}
+	financials, err := alphavantage.NewFinancials("STOCK1", alphavantage.CalendarYear, balanceSheet, incomeStatement, cashFlow, nil)
+	assert.NoError(t.Fatalf, err)
+	return financials
+}
+
+func TestNewAnnual(t *testing.T) {
+	financials := newRatiosFinancials(t)
+	ratios, err := New(financials, Annual, 2023, 0, 30)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 0, len(ratios.Errors))
+	assert.EqualStrings(t, "FY2023", ratios.Period.String())
+
+	const tol = 1e-12
+	assert.EqualFloat64Tol(t, 2, ratios.CurrentRatio.Value, tol)
+	assert.EqualFloat64Tol(t, 1.25, ratios.QuickRatio.Value, tol)
+	assert.EqualFloat64Tol(t, 0.5, ratios.DebtToEquity.Value, tol)
+	assert.EqualFloat64Tol(t, 14, ratios.InterestCoverage.Value, tol)
+	// 300 * (1 - 0.25) / (500 + 1000 - 300)
+	assert.EqualFloat64Tol(t, 0.1875, ratios.ROIC.Value, tol)
+	assert.EqualFloat64Tol(t, 0.5, ratios.GrossMargin.Value, tol)
+	assert.EqualFloat64Tol(t, 300.0/1460, ratios.OperatingMargin.Value, tol)
+	assert.EqualFloat64Tol(t, 195.0/1460, ratios.NetMargin.Value, tol)
+	assert.EqualFloat64Tol(t, 200, ratios.FreeCashFlow.Value, tol)
+	assert.EqualFloat64Tol(t, 0.73, ratios.AssetTurnover.Value, tol)
+	assert.EqualFloat64Tol(t, 50, ratios.DSO.Value, tol)
+	assert.EqualFloat64Tol(t, 75, ratios.DIO.Value, tol)
+	assert.EqualFloat64Tol(t, 60, ratios.DPO.Value, tol)
+
+	assert.EqualFloat64Tol(t, 3000, ratios.MarketCap.Value, tol)
+	assert.EqualFloat64Tol(t, 3200, ratios.EnterpriseValue.Value, tol)
+	assert.EqualFloat64Tol(t, 200.0/3000, ratios.FCFYield.Value, tol)
+	assert.EqualFloat64Tol(t, 1.95, ratios.EarningsPerShare.Value, tol)
+	assert.EqualFloat64Tol(t, 30/1.95, ratios.PriceToEarnings.Value, tol)
+	assert.EqualFloat64Tol(t, 10, ratios.BookValuePerShare.Value, tol)
+	assert.EqualFloat64Tol(t, 3, ratios.PriceToBook.Value, tol)
+	assert.EqualFloat64Tol(t, 3000.0/1460, ratios.PriceToSales.Value, tol)
+	assert.EqualFloat64Tol(t, 3200.0/350, ratios.EVToEBITDA.Value, tol)
+}
+
+func TestNewQuarterlyAndTTM(t *testing.T) {
+	financials := newRatiosFinancials(t)
+
+	ratios, err := New(financials, Quarterly, 2023, 4, 0)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64Tol(t, 200.0/365*91.25, ratios.DSO.Value, 1e-9)
+	assert.EqualFloat64Tol(t, 50, ratios.FreeCashFlow.Value, 1e-12)
+	// the quarterly income statements don't report these
+	assert.True(t, ratios.InterestCoverage.IsNull())
+	assert.True(t, errors.Is(ratios.Errors[InterestCoverage], alphavantage.ErrNoneValue))
+	assert.True(t, ratios.MarketCap.IsNull())
+	assert.True(t, errors.Is(ratios.Errors[MarketCap], ErrNoPrice))
+
+	ratios, err = New(financials, TTM, 2023, 4, 30)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64Tol(t, 200, ratios.FreeCashFlow.Value, 1e-12)
+	assert.EqualFloat64Tol(t, 200.0/1460*365, ratios.DSO.Value, 1e-9)
+	assert.EqualFloat64Tol(t, 30/2.0, ratios.PriceToEarnings.Value, 1e-9)
+
+	// FY2022Q4 is missing from the TTM sums
+	ratios, err = New(financials, TTM, 2023, 3, 30)
+	assert.NoError(t.Fatalf, err)
+	assert.True(t, ratios.FreeCashFlow.IsNull())
+	assert.True(t, errors.Is(ratios.Errors[FreeCashFlow], alphavantage.ErrMissingPeriod))
+	assert.EqualFloat64(t, 2, ratios.CurrentRatio.Value)
+
+	_, err = New(financials, Quarterly, 2022, 4, 30)
+	assert.True(t, errors.Is(err, alphavantage.ErrMissingPeriod))
+	_, err = New(financials, TTM, 2023, 5, 30)
+	assert.ErrorIncludesMessage(t, "invalid fiscal quarter: 5", err)
+
+	_, err = New(financials, Annual, 2023, 4, 30)
+	assert.ErrorIncludesMessage(t, "annual ratios need fiscal quarter 0", err)
+}
+
+func TestCompute(t *testing.T) {
+	financials := newRatiosFinancials(t)
+
+	value, err := Compute(financials, Current, Annual, 2023, 0, 0)
+	assert.NoError(t, err)
+	assert.EqualFloat64(t, 2, value)
+
+	_, err = Compute(financials, FCFYield, Annual, 2023, 0, 0)
+	assert.True(t, errors.Is(err, ErrNoPrice))
+
+	_, err = Compute(financials, "unknown", Annual, 2023, 0, 0)
+	assert.ErrorIncludesMessage(t, `unknown ratio: "unknown"`, err)
+}
+
+func TestCloseAsOf(t *testing.T) {
+	ts := &alphavantage.TimeSeries{TimeSeriesDaily: map[string]alphavantage.TimeSeriesData{
+		"2023-12-28": {Close: 29},
+		"2023-12-29": {Close: 30},
+		"2024-01-02": {Close: 31},
+	}}
+	price, date, err := CloseAsOf(ts, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.EqualFloat64(t, 30, price)
+	assert.EqualStrings(t, "2023-12-29", date)
+
+	_, _, err = CloseAsOf(ts, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, errors.Is(err, ErrNoPrice))
+
+	price, err = QuotePrice(&alphavantage.GlobalQuote{Price: 190.96})
+	assert.NoError(t, err)
+	assert.EqualFloat64(t, 190.96, price)
+	_, err = QuotePrice(&alphavantage.GlobalQuote{})
+	assert.True(t, errors.Is(err, ErrNoPrice))
+}
@@ -0,0 +1,89 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// realtimeBulkQuotesMaxSymbols is the maximum number of symbols the
+// API accepts per REALTIME_BULK_QUOTES request.
+const realtimeBulkQuotesMaxSymbols = 100
+
+// RealtimeQuote represents the realtime quote of a single symbol.
+type RealtimeQuote struct {
+	Symbol                     string    `json:"symbol"`
+	Timestamp                  string    `json:"timestamp"`
+	Open                       AVFloat64 `json:"open"`
+	High                       AVFloat64 `json:"high"`
+	Low                        AVFloat64 `json:"low"`
+	Close                      AVFloat64 `json:"close"`
+	Volume                     AVInt     `json:"volume"`
+	PreviousClose              AVFloat64 `json:"previous_close"`
+	Change                     AVFloat64 `json:"change"`
+	ChangePercent              AVPercent `json:"change_percent"`
+	ExtendedHoursQuote         AVFloat64 `json:"extended_hours_quote"`
+	ExtendedHoursChange        AVFloat64 `json:"extended_hours_change"`
+	ExtendedHoursChangePercent AVPercent `json:"extended_hours_change_percent"`
+}
+
+// RealtimeBulkQuotes represents the response from the REALTIME_BULK_QUOTES API endpoint.
+type RealtimeBulkQuotes struct {
+	Endpoint string          `json:"endpoint"`
+	Message  string          `json:"message"`
+	Data     []RealtimeQuote `json:"data"`
+}
+
+func toRealtimeBulkQuotes(buf []byte) (*RealtimeBulkQuotes, error) {
+	quotes := &RealtimeBulkQuotes{}
+	if err := json.Unmarshal(buf, quotes); err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
+
+// RealtimeBulkQuotes fetches the realtime quotes for the given symbols.
+// The API accepts up to 100 symbols per call, longer lists are split
+// into several requests and the results are merged.
+func (c *Client) RealtimeBulkQuotes(symbols []string) (*RealtimeBulkQuotes, error) {
+	const function = "REALTIME_BULK_QUOTES"
+	if len(symbols) == 0 {
+		return nil, errors.New("no symbols given")
+	}
+
+	result := &RealtimeBulkQuotes{}
+	for start := 0; start < len(symbols); start += realtimeBulkQuotesMaxSymbols {
+		end := start + realtimeBulkQuotesMaxSymbols
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+		url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s",
+			baseURL, function, strings.Join(symbols[start:end], ","), c.apiKey)
+		body, err := c.makeHTTPRequest(url)
+		if err != nil {
+			return nil, err
+		}
+		quotes, err := toRealtimeBulkQuotes(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		if err := c.checkResponse(function, body, quotes); err != nil {
+			return nil, err
+		}
+		result.Endpoint = quotes.Endpoint
+		result.Message = quotes.Message
+		result.Data = append(result.Data, quotes.Data...)
+	}
+	return result, nil
+}
+
+// BySymbol returns the quote for the given symbol or nil if it is missing.
+func (q *RealtimeBulkQuotes) BySymbol(symbol string) *RealtimeQuote {
+	for i := range q.Data {
+		if q.Data[i].Symbol == symbol {
+			return &q.Data[i]
+		}
+	}
+	return nil
+}
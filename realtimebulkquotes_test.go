@@ -0,0 +1,69 @@
+package alphavantage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToRealtimeBulkQuotes(t *testing.T) {
+	var buf = `
+{
+    "endpoint": "Realtime Bulk Quotes",
+    "message": "",
+    "data": [
+        {
+            "symbol": "STOCK1",
+            "timestamp": "2024-05-03 16:00:00.000",
+            "open": "402.28000",
+            "high": "407.15000",
+            "low": "401.86000",
+            "close": "406.66000",
+            "volume": "17446720",
+            "previous_close": "397.84000",
+            "change": "8.82000",
+            "change_percent": "2.21697",
+            "extended_hours_quote": "406.41000",
+            "extended_hours_change": "-0.25000",
+            "extended_hours_change_percent": "-0.06148"
+        },
+        {
+            "symbol": "STOCK2",
+            "timestamp": "2024-05-03 16:00:00.000",
+            "open": "186.65000",
+            "high": "187.00000",
+            "low": "182.66000",
+            "close": "183.38000",
+            "volume": "163224109",
+            "previous_close": "173.03000",
+            "change": "10.35000",
+            "change_percent": "5.98162",
+            "extended_hours_quote": "-",
+            "extended_hours_change": "-",
+            "extended_hours_change_percent": "-"
+        }
+    ]
+}
+`
+	quotes, err := toRealtimeBulkQuotes([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "REALTIME_BULK_QUOTES", buf, quotes)
+	assert.EqualStrings(t, "Realtime Bulk Quotes", quotes.Endpoint)
+	assert.EqualInt(t, 2, len(quotes.Data))
+
+	quote := quotes.BySymbol("STOCK1")
+	if quote == nil {
+		t.Fatal("quote for STOCK1 is missing")
+	}
+	assert.EqualFloat64(t, 406.66, quote.Close.Value)
+	assert.EqualInt(t, 17446720, quote.Volume.Value)
+	assert.EqualFloat64(t, 2.21697, quote.ChangePercent.Value)
+	assert.EqualFloat64(t, -0.06148, quote.ExtendedHoursChangePercent.Value)
+
+	quote = quotes.BySymbol("STOCK2")
+	if quote == nil {
+		t.Fatal("quote for STOCK2 is missing")
+	}
+	assert.EqualFloat64(t, 0, quote.ExtendedHoursQuote.Value)
+	assert.True(t, quotes.BySymbol("STOCK3") == nil)
+}
@@ -0,0 +1,58 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RealtimeOptionsData represents the realtime options data for a given symbol.
+type RealtimeOptionsData struct {
+	Symbol   string           `json:"symbol"`
+	Endpoint string           `json:"endpoint"`
+	Message  string           `json:"message"`
+	Data     []OptionContract `json:"data"`
+}
+
+// toRealtimeOptionsData parses the JSON response into the RealtimeOptionsData struct.
+func toRealtimeOptionsData(buf []byte) (*RealtimeOptionsData, error) {
+	optionsData := &RealtimeOptionsData{}
+	if err := json.Unmarshal(buf, optionsData); err != nil {
+		return nil, err
+	}
+	return optionsData, nil
+}
+
+// RealtimeOptions fetches and returns the realtime option chain for the
+// specified company symbol.  If contract is not empty only that contract
+// is returned.  The greeks and implied volatility are only filled if
+// requireGreeks is true.
+func (c *Client) RealtimeOptions(symbol string, contract string, requireGreeks bool) (*RealtimeOptionsData, error) {
+	const function = "REALTIME_OPTIONS"
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s", baseURL, function, symbol, c.apiKey)
+
+	if contract != "" {
+		url = fmt.Sprintf("%s&contract=%s", url, contract)
+	}
+	if requireGreeks {
+		url = fmt.Sprintf("%s&require_greeks=true", url)
+	}
+
+	body, err := c.makeHTTPRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	optionsData, err := toRealtimeOptionsData(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkResponse(function, body, optionsData); err != nil {
+		return nil, err
+	}
+	return optionsData, nil
+}
+
+// Chain groups the contracts by expiration and strike.
+func (d *RealtimeOptionsData) Chain() *OptionChain {
+	return NewOptionChain(d.Symbol, d.Data)
+}
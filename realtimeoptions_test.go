@@ -0,0 +1,81 @@
+package alphavantage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToRealtimeOptionsData(t *testing.T) {
+	var buf = `
+{
+    "endpoint": "Realtime Options",
+    "message": "success",
+    "data": [
+        {
+            "contractID": "STOCK1250117C00100000",
+            "symbol": "STOCK1",
+            "expiration": "2025-01-17",
+            "strike": "100.00",
+            "type": "call",
+            "last": "6.10",
+            "mark": "6.05",
+            "bid": "6.00",
+            "bid_size": "10",
+            "ask": "6.10",
+            "ask_size": "12",
+            "volume": "253",
+            "open_interest": "1840",
+            "date": "2025-01-02",
+            "implied_volatility": "0.24510",
+            "delta": "0.56210",
+            "gamma": "0.04120",
+            "theta": "-0.08930",
+            "vega": "0.09870",
+            "rho": "0.01950"
+        },
+        {
+            "contractID": "STOCK1250117P00100000",
+            "symbol": "STOCK1",
+            "expiration": "2025-01-17",
+            "strike": "100.00",
+            "type": "put",
+            "last": "4.90",
+            "mark": "4.95",
+            "bid": "4.90",
+            "bid_size": "8",
+            "ask": "5.00",
+            "ask_size": "15",
+            "volume": "120",
+            "open_interest": "960",
+            "date": "2025-01-02",
+            "implied_volatility": "0.25120",
+            "delta": "-0.43790",
+            "gamma": "0.04120",
+            "theta": "-0.07810",
+            "vega": "0.09870",
+            "rho": "-0.01720"
+        }
+    ]
+}
+`
+	optionsData, err := toRealtimeOptionsData([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "REALTIME_OPTIONS", buf, optionsData)
+	assert.EqualStrings(t, "Realtime Options", optionsData.Endpoint)
+	assert.EqualInt(t, 2, len(optionsData.Data))
+
+	call := optionsData.Data[0]
+	assert.EqualStrings(t, "STOCK1250117C00100000", call.ContractID)
+	assert.EqualFloat64(t, 100.00, call.Strike.Value)
+	assert.EqualFloat64(t, 0.56210, call.Delta.Value)
+	assert.EqualInt(t, 1840, call.OpenInterest.Value)
+
+	chain := optionsData.Chain()
+	pair := chain.Pair("2025-01-17", 100)
+	if pair == nil {
+		t.Fatal("pair for 2025-01-17/100 is missing")
+	}
+	assert.EqualStrings(t, "STOCK1250117C00100000", pair.Call.ContractID)
+	assert.EqualStrings(t, "STOCK1250117P00100000", pair.Put.ContractID)
+}
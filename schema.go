@@ -0,0 +1,279 @@
+package alphavantage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Paths of fields are the JSON names joined by "/".  In Drift elements of
+// maps are "*" and elements of slices "[]", e.g.
+// "Time Series (Daily)/*/1. open" or "annualReports/[]/totalAssets".
+
+// Drift is the difference between a response and the type it's parsed
+// into.
+type Drift struct {
+	Endpoint string
+	// Unknown are the fields of the response which the type doesn't have,
+	// e.g. new fields.
+	Unknown []string
+	// Missing are the fields of the type which the response doesn't have,
+	// e.g. vanished fields.  Maps, slices and fields tagged omitempty may
+	// be absent and aren't reported.
+	Missing []string
+}
+
+// SchemaError is returned in strict mode for responses with unknown
+// fields or without required fields.
+type SchemaError struct {
+	Endpoint string
+	Err      error
+}
+
+// Error implements error.
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("strict mode: %s: %v", e.Endpoint, e.Err)
+}
+
+// Unwrap returns the decoding or validation error.
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// SetStrict enables the strict mode: responses with fields the types
+// don't have or without the fields tagged `validate:"required"` are
+// rejected with a SchemaError.
+func (c *Client) SetStrict(strict bool) {
+	c.Lock()
+	defer c.Unlock()
+	c.strict = strict
+}
+
+// OnDrift sets a callback which is called with the drift of responses
+// which don't match their types, e.g. to log when Alpha Vantage changes a
+// payload.  It isn't called in strict mode.
+func (c *Client) OnDrift(callback func(Drift)) {
+	c.Lock()
+	defer c.Unlock()
+	c.onDrift = callback
+}
+
+// checkResponse checks the response body of the endpoint against the type
+// of v, the value it was parsed into.
+func (c *Client) checkResponse(endpoint string, body []byte, v interface{}) error {
+	c.Lock()
+	strict, onDrift := c.strict, c.onDrift
+	c.Unlock()
+
+	t := reflect.TypeOf(v)
+	if strict {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(reflect.New(t.Elem()).Interface()); err != nil {
+			return &SchemaError{Endpoint: endpoint, Err: err}
+		}
+		var data interface{}
+		if err := json.Unmarshal(body, &data); err != nil {
+			return &SchemaError{Endpoint: endpoint, Err: err}
+		}
+		if missing := requiredFields(t, data, ""); len(missing) > 0 {
+			return &SchemaError{Endpoint: endpoint, Err: fmt.Errorf("missing required field %q", missing[0])}
+		}
+		return nil
+	}
+	if onDrift == nil {
+		return nil
+	}
+	drift, err := DetectDrift(endpoint, body, v)
+	if err != nil {
+		return err
+	}
+	if len(drift.Unknown) > 0 || len(drift.Missing) > 0 {
+		onDrift(drift)
+	}
+	return nil
+}
+
+// DetectDrift compares the response body with the type of v, e.g.
+// &BalanceSheet{}.
+func DetectDrift(endpoint string, body []byte, v interface{}) (Drift, error) {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return Drift{}, err
+	}
+	unknown := make(map[string]bool)
+	missing := make(map[string]bool)
+	compareFields(reflect.TypeOf(v), data, "", unknown, missing)
+	return Drift{Endpoint: endpoint, Unknown: sortedKeys(unknown), Missing: sortedKeys(missing)}, nil
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// isLeaf reports whether values of the type aren't compared by field,
+// e.g. AVFloat64.
+func isLeaf(t reflect.Type) bool {
+	return t.Implements(unmarshalerType) || reflect.PtrTo(t).Implements(unmarshalerType)
+}
+
+// jsonField is a field of a struct with its JSON name
+type jsonField struct {
+	name     string
+	typ      reflect.Type
+	required bool
+	// optional fields are tagged omitempty
+	optional bool
+}
+
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		options := strings.Split(field.Tag.Get("json"), ",")
+		name := options[0]
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, jsonField{
+			name:     name,
+			typ:      field.Type,
+			required: field.Tag.Get("validate") == "required",
+			optional: containsString(options[1:], "omitempty"),
+		})
+	}
+	return fields
+}
+
+// lookup returns the value of the field, case-insensitive like
+// json.Unmarshal.
+func lookup(object map[string]interface{}, name string) (interface{}, bool) {
+	if value, ok := object[name]; ok {
+		return value, true
+	}
+	for key, value := range object {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func compareFields(t reflect.Type, data interface{}, path string, unknown, missing map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if isLeaf(t) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		known := make(map[string]bool, len(fields))
+		for _, field := range fields {
+			known[strings.ToLower(field.name)] = true
+			value, ok := lookup(object, field.name)
+			if !ok {
+				if kind := field.typ.Kind(); kind != reflect.Map && kind != reflect.Slice && !field.optional {
+					missing[joinPath(path, field.name)] = true
+				}
+				continue
+			}
+			compareFields(field.typ, value, joinPath(path, field.name), unknown, missing)
+		}
+		for key := range object {
+			if !known[strings.ToLower(key)] {
+				unknown[joinPath(path, key)] = true
+			}
+		}
+	case reflect.Map:
+		object, _ := data.(map[string]interface{})
+		for _, value := range object {
+			compareFields(t.Elem(), value, joinPath(path, "*"), unknown, missing)
+		}
+	case reflect.Slice:
+		elements, _ := data.([]interface{})
+		for _, value := range elements {
+			compareFields(t.Elem(), value, joinPath(path, "[]"), unknown, missing)
+		}
+	}
+}
+
+// requiredFields returns the fields tagged `validate:"required"` which
+// are absent, null, empty or "None".
+func requiredFields(t reflect.Type, data interface{}, path string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if isLeaf(t) {
+		return nil
+	}
+	var missing []string
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for _, field := range jsonFields(t) {
+			value, ok := lookup(object, field.name)
+			if field.required && (!ok || value == nil || value == "" || value == "None") {
+				missing = append(missing, joinPath(path, field.name))
+				continue
+			}
+			missing = append(missing, requiredFields(field.typ, value, joinPath(path, field.name))...)
+		}
+	case reflect.Map:
+		object, _ := data.(map[string]interface{})
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			missing = append(missing, requiredFields(t.Elem(), object[key], joinPath(path, key))...)
+		}
+	case reflect.Slice:
+		elements, _ := data.([]interface{})
+		for i, value := range elements {
+			missing = append(missing, requiredFields(t.Elem(), value, joinPath(path, fmt.Sprint(i)))...)
+		}
+	}
+	return missing
+}
+
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "/" + name
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
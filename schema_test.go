@@ -0,0 +1,126 @@
+package alphavantage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+const schemaGlobalQuote = `{
+    "Global Quote": {
+        "01. symbol": "IBM",
+        "02. open": "190.9400",
+        "03. high": "191.9300",
+        "04. low": "190.3400",
+        "05. price": "190.9600",
+        "06. volume": "3742169",
+        "07. latest trading day": "2024-03-28",
+        "08. previous close": "190.8000",
+        "09. change": "0.1600",
+        "10. change percent": "0.0839%"
+    }
+}`
+
+// assertStrict checks that the fixture of the endpoint passes the strict
+// mode, i.e. that the type of v models the real response.
+func assertStrict(t *testing.T, endpoint, body string, v interface{}) {
+	t.Helper()
+	client := New("demo")
+	client.SetStrict(true)
+	assert.NoError(t, client.checkResponse(endpoint, []byte(body), v))
+}
+
+func TestCheckResponseStrict(t *testing.T) {
+	client := New("demo")
+	client.SetStrict(true)
+
+	assert.NoError(t, client.checkResponse("GLOBAL_QUOTE", []byte(schemaGlobalQuote), &GlobalQuoteResponse{}))
+
+	body := strings.Replace(schemaGlobalQuote, `"01. symbol": "IBM",`, `"01. symbol": "IBM", "11. currency": "USD",`, 1)
+	err := client.checkResponse("GLOBAL_QUOTE", []byte(body), &GlobalQuoteResponse{})
+	var schemaErr *SchemaError
+	assert.True(t, errors.As(err, &schemaErr))
+	assert.EqualStrings(t, "GLOBAL_QUOTE", schemaErr.Endpoint)
+	assert.ErrorIncludesMessage(t, `unknown field "11. currency"`, err)
+
+	err = client.checkResponse("GLOBAL_QUOTE", []byte(`{"Global Quote": {}}`), &GlobalQuoteResponse{})
+	assert.ErrorIncludesMessage(t, `strict mode: GLOBAL_QUOTE: missing required field "Global Quote/01. symbol"`, err)
+}
+
+func TestCheckResponseStrictNested(t *testing.T) {
+	client := New("demo")
+	client.SetStrict(true)
+
+	body := `{
+    "symbol": "IBM",
+    "annualEarnings": [
+        {"fiscalDateEnding": "2023-12-31", "reportedEPS": "9.61"},
+        {"fiscalDateEnding": "None", "reportedEPS": "7.61"}
+    ],
+    "quarterlyEarnings": []
+}`
+	err := client.checkResponse("EARNINGS", []byte(body), &Earnings{})
+	assert.ErrorIncludesMessage(t, `missing required field "annualEarnings/1/fiscalDateEnding"`, err)
+}
+
+func TestCheckResponseDrift(t *testing.T) {
+	client := New("demo")
+	var drifts []Drift
+	client.OnDrift(func(drift Drift) {
+		drifts = append(drifts, drift)
+	})
+
+	assert.NoError(t, client.checkResponse("GLOBAL_QUOTE", []byte(schemaGlobalQuote), &GlobalQuoteResponse{}))
+	assert.EqualInt(t, 0, len(drifts))
+
+	body := strings.Replace(schemaGlobalQuote, `"09. change": "0.1600",`, `"11. currency": "USD",`, 1)
+	assert.NoError(t, client.checkResponse("GLOBAL_QUOTE", []byte(body), &GlobalQuoteResponse{}))
+	assert.EqualInt(t, 1, len(drifts))
+	assert.EqualStrings(t, "GLOBAL_QUOTE", drifts[0].Endpoint)
+	assert.EqualStrings(t, "Global Quote/11. currency", strings.Join(drifts[0].Unknown, ","))
+	assert.EqualStrings(t, "Global Quote/09. change", strings.Join(drifts[0].Missing, ","))
+}
+
+func TestDetectDrift(t *testing.T) {
+	body := `{
+    "Meta Data": {
+        "1. Information": "Daily Prices (open, high, low, close) and Volumes",
+        "2. Symbol": "IBM",
+        "3. Last Refreshed": "2024-03-28",
+        "4. Output Size": "Compact",
+        "5. Time Zone": "US/Eastern"
+    },
+    "Time Series (Daily)": {
+        "2024-03-28": {
+            "1. open": "190.9400",
+            "2. high": "191.9300",
+            "3. low": "190.3400",
+            "4. close": "190.9600",
+            "5. volume": "3742169"
+        },
+        "2024-03-27": {
+            "1. open": "190.9400",
+            "2. high": "191.9300",
+            "3. low": "190.3400",
+            "4. close": "190.9600",
+            "5. volume": "3742169",
+            "6. vwap": "191.0100"
+        }
+    }
+}`
+	drift, err := DetectDrift("TIME_SERIES_DAILY", []byte(body), &TimeSeries{})
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "Time Series (Daily)/*/6. vwap", strings.Join(drift.Unknown, ","))
+	// optional fields, e.g. the time zone of weekly responses, aren't missing
+	assert.EqualInt(t, 0, len(drift.Missing))
+
+	_, err = DetectDrift("TIME_SERIES_DAILY", []byte(`{`), &TimeSeries{})
+	assert.ErrorIncludesMessage(t, "unexpected end of JSON input", err)
+}
+
+func TestCheckResponseDisabled(t *testing.T) {
+	client := New("demo")
+	assert.NoError(t, client.checkResponse("GLOBAL_QUOTE", []byte(`{"Information": "rate limit"}`), &GlobalQuoteResponse{}))
+}
@@ -0,0 +1,310 @@
+package alphavantage
+
+import (
+	"fmt"
+)
+
+// Zones of the Altman Z-score
+const (
+	AltmanSafe     = "safe"
+	AltmanGrey     = "grey"
+	AltmanDistress = "distress"
+)
+
+// BeneishThreshold is the M-score above which earnings are likely
+// manipulated, the one of the eight variable model.
+const BeneishThreshold = -1.78
+
+// PiotroskiFScore is the Piotroski F-score of a fiscal year: nine signals
+// which are 1 if the company improved over the year before, else 0.
+// Total assets are the ones at the end of the year, so two fiscal years
+// are enough.
+type PiotroskiFScore struct {
+	Period Period
+
+	// Profitability
+	// ROA is 1 for netIncome / totalAssets > 0
+	ROA int
+	// OperatingCashFlow is 1 for operatingCashflow > 0
+	OperatingCashFlow int
+	// DeltaROA is 1 if the ROA increased
+	DeltaROA int
+	// Accrual is 1 for operatingCashflow > netIncome
+	Accrual int
+
+	// Leverage, liquidity and source of funds
+	// DeltaLeverage is 1 if longTermDebt / totalAssets decreased
+	DeltaLeverage int
+	// DeltaLiquidity is 1 if the current ratio increased
+	DeltaLiquidity int
+	// NoDilution is 1 if commonStockSharesOutstanding didn't increase
+	NoDilution int
+
+	// Operating efficiency
+	// DeltaGrossMargin is 1 if grossProfit / totalRevenue increased
+	DeltaGrossMargin int
+	// DeltaAssetTurnover is 1 if totalRevenue / totalAssets increased
+	DeltaAssetTurnover int
+
+	// Score is the sum of the signals, 0 to 9
+	Score int
+}
+
+// AltmanZScore is the Altman Z-score of a fiscal year, the one for public
+// manufacturers:
+//
+//	Z = 1.2 X1 + 1.4 X2 + 3.3 X3 + 0.6 X4 + 1.0 X5
+type AltmanZScore struct {
+	Period Period
+
+	// X1 = (totalCurrentAssets - totalCurrentLiabilities) / totalAssets
+	WorkingCapitalToAssets float64
+	// X2 = retainedEarnings / totalAssets
+	RetainedEarningsToAssets float64
+	// X3 = ebit / totalAssets
+	EBITToAssets float64
+	// X4 = market capitalization / totalLiabilities
+	MarketValueToLiabilities float64
+	// X5 = totalRevenue / totalAssets
+	SalesToAssets float64
+
+	Score float64
+	// Zone is AltmanSafe above 2.99, AltmanDistress below 1.81, else
+	// AltmanGrey
+	Zone string
+}
+
+// BeneishMScore is the eight variable Beneish M-score of a fiscal year.
+// Each index compares the year t with the year before, t-1:
+//
+//	M = -4.84 + 0.92 DSRI + 0.528 GMI + 0.404 AQI + 0.892 SGI + 0.115 DEPI
+//	    - 0.172 SGAI + 4.679 TATA - 0.327 LVGI
+type BeneishMScore struct {
+	Period Period
+
+	// DSRI, days sales in receivables index:
+	// (currentNetReceivables / totalRevenue)_t / (...)_t-1
+	DSRI float64
+	// GMI, gross margin index: (grossProfit / totalRevenue)_t-1 / (...)_t
+	GMI float64
+	// AQI, asset quality index, with
+	// AQ = 1 - (totalCurrentAssets + propertyPlantEquipment) / totalAssets:
+	// AQ_t / AQ_t-1
+	AQI float64
+	// SGI, sales growth index: totalRevenue_t / totalRevenue_t-1
+	SGI float64
+	// DEPI, depreciation index, with
+	// DEP = depreciationAndAmortization / (depreciationAndAmortization + propertyPlantEquipment):
+	// DEP_t-1 / DEP_t
+	DEPI float64
+	// SGAI, SG&A index:
+	// (sellingGeneralAndAdministrative / totalRevenue)_t / (...)_t-1
+	SGAI float64
+	// TATA, total accruals to total assets:
+	// (netIncomeFromContinuingOperations - operatingCashflow) / totalAssets
+	TATA float64
+	// LVGI, leverage index, with
+	// LVG = (totalCurrentLiabilities + longTermDebt) / totalAssets:
+	// LVG_t / LVG_t-1
+	LVGI float64
+
+	Score float64
+	// LikelyManipulator is true for scores above BeneishThreshold
+	LikelyManipulator bool
+}
+
+// signal returns 1 if the condition holds, else 0.
+func signal(condition bool) int {
+	if condition {
+		return 1
+	}
+	return 0
+}
+
+// scorePeriod returns the period of the fiscal year, which has to be
+// reported.
+func (f *Financials) scorePeriod(fiscalYear int) (Period, error) {
+	fp, ok := f.Get(fiscalYear, 0)
+	if !ok {
+		return Period{}, fmt.Errorf("%s: %w", periodName(fiscalYear, 0), ErrMissingPeriod)
+	}
+	return fp.Period, nil
+}
+
+// PiotroskiFScore computes the F-score of the fiscal year.  It needs the
+// fiscal year before, all line items which are "None" are errors.
+func (f *Financials) PiotroskiFScore(fiscalYear int) (*PiotroskiFScore, error) {
+	period, err := f.scorePeriod(fiscalYear)
+	if err != nil {
+		return nil, fmt.Errorf("Piotroski F-score: %w", err)
+	}
+	in := f.Inputs()
+	t, p := fiscalYear, fiscalYear-1
+
+	roa := in.Divide("ROA", in.Value("netIncome", t, 0), in.Value("totalAssets", t, 0))
+	previousROA := in.Divide("ROA", in.Value("netIncome", p, 0), in.Value("totalAssets", p, 0))
+	operatingCashFlow := in.Value("operatingCashflow", t, 0)
+	netIncome := in.Value("netIncome", t, 0)
+	leverage := in.Divide("leverage", in.Value("longTermDebt", t, 0), in.Value("totalAssets", t, 0))
+	previousLeverage := in.Divide("leverage", in.Value("longTermDebt", p, 0), in.Value("totalAssets", p, 0))
+	currentRatio := in.Divide("current ratio", in.Value("totalCurrentAssets", t, 0), in.Value("totalCurrentLiabilities", t, 0))
+	previousCurrentRatio := in.Divide("current ratio", in.Value("totalCurrentAssets", p, 0), in.Value("totalCurrentLiabilities", p, 0))
+	shares := in.Value("commonStockSharesOutstanding", t, 0)
+	previousShares := in.Value("commonStockSharesOutstanding", p, 0)
+	grossMargin := in.Divide("gross margin", in.Value("grossProfit", t, 0), in.Value("totalRevenue", t, 0))
+	previousGrossMargin := in.Divide("gross margin", in.Value("grossProfit", p, 0), in.Value("totalRevenue", p, 0))
+	turnover := in.Divide("asset turnover", in.Value("totalRevenue", t, 0), in.Value("totalAssets", t, 0))
+	previousTurnover := in.Divide("asset turnover", in.Value("totalRevenue", p, 0), in.Value("totalAssets", p, 0))
+	if err := in.Err(); err != nil {
+		return nil, fmt.Errorf("Piotroski F-score: %w", err)
+	}
+
+	score := &PiotroskiFScore{
+		Period:             period,
+		ROA:                signal(roa > 0),
+		OperatingCashFlow:  signal(operatingCashFlow > 0),
+		DeltaROA:           signal(roa > previousROA),
+		Accrual:            signal(operatingCashFlow > netIncome),
+		DeltaLeverage:      signal(leverage < previousLeverage),
+		DeltaLiquidity:     signal(currentRatio > previousCurrentRatio),
+		NoDilution:         signal(shares <= previousShares),
+		DeltaGrossMargin:   signal(grossMargin > previousGrossMargin),
+		DeltaAssetTurnover: signal(turnover > previousTurnover),
+	}
+	score.Score = score.ROA + score.OperatingCashFlow + score.DeltaROA + score.Accrual +
+		score.DeltaLeverage + score.DeltaLiquidity + score.NoDilution +
+		score.DeltaGrossMargin + score.DeltaAssetTurnover
+	return score, nil
+}
+
+// AltmanZScore computes the Z-score of the fiscal year with the market
+// capitalization of the overview, which is the current one, not the one
+// at the end of the fiscal year.
+func (f *Financials) AltmanZScore(fiscalYear int, overview *CompanyOverview) (*AltmanZScore, error) {
+	period, err := f.scorePeriod(fiscalYear)
+	if err != nil {
+		return nil, fmt.Errorf("Altman Z-score: %w", err)
+	}
+	if overview == nil || overview.MarketCapitalization.IsNull() {
+		return nil, fmt.Errorf("Altman Z-score: MarketCapitalization: %w", ErrNoneValue)
+	}
+	in := f.Inputs()
+	t := fiscalYear
+	assets := in.Value("totalAssets", t, 0)
+
+	score := &AltmanZScore{
+		Period:                   period,
+		WorkingCapitalToAssets:   in.Divide("X1", in.Value("totalCurrentAssets", t, 0)-in.Value("totalCurrentLiabilities", t, 0), assets),
+		RetainedEarningsToAssets: in.Divide("X2", in.Value("retainedEarnings", t, 0), assets),
+		EBITToAssets:             in.Divide("X3", in.Value("ebit", t, 0), assets),
+		MarketValueToLiabilities: in.Divide("X4", overview.MarketCapitalization.Float64(), in.Value("totalLiabilities", t, 0)),
+		SalesToAssets:            in.Divide("X5", in.Value("totalRevenue", t, 0), assets),
+	}
+	if err := in.Err(); err != nil {
+		return nil, fmt.Errorf("Altman Z-score: %w", err)
+	}
+	score.Score = 1.2*score.WorkingCapitalToAssets + 1.4*score.RetainedEarningsToAssets +
+		3.3*score.EBITToAssets + 0.6*score.MarketValueToLiabilities + 1.0*score.SalesToAssets
+	switch {
+	case score.Score > 2.99:
+		score.Zone = AltmanSafe
+	case score.Score < 1.81:
+		score.Zone = AltmanDistress
+	default:
+		score.Zone = AltmanGrey
+	}
+	return score, nil
+}
+
+// BeneishMScore computes the M-score of the fiscal year.  It needs the
+// fiscal year before, all line items which are "None" are errors.
+func (f *Financials) BeneishMScore(fiscalYear int) (*BeneishMScore, error) {
+	period, err := f.scorePeriod(fiscalYear)
+	if err != nil {
+		return nil, fmt.Errorf("Beneish M-score: %w", err)
+	}
+	in := f.Inputs()
+	t, p := fiscalYear, fiscalYear-1
+
+	receivables := func(year int) float64 {
+		return in.Divide("DSRI", in.Value("currentNetReceivables", year, 0), in.Value("totalRevenue", year, 0))
+	}
+	grossMargin := func(year int) float64 {
+		return in.Divide("GMI", in.Value("grossProfit", year, 0), in.Value("totalRevenue", year, 0))
+	}
+	assetQuality := func(year int) float64 {
+		return 1 - in.Divide("AQI", in.Value("totalCurrentAssets", year, 0)+in.Value("propertyPlantEquipment", year, 0), in.Value("totalAssets", year, 0))
+	}
+	depreciation := func(year int) float64 {
+		amortization := in.Value("depreciationAndAmortization", year, 0)
+		return in.Divide("DEPI", amortization, amortization+in.Value("propertyPlantEquipment", year, 0))
+	}
+	sga := func(year int) float64 {
+		return in.Divide("SGAI", in.Value("sellingGeneralAndAdministrative", year, 0), in.Value("totalRevenue", year, 0))
+	}
+	leverage := func(year int) float64 {
+		return in.Divide("LVGI", in.Value("totalCurrentLiabilities", year, 0)+in.Value("longTermDebt", year, 0), in.Value("totalAssets", year, 0))
+	}
+
+	score := &BeneishMScore{
+		Period: period,
+		DSRI:   in.Divide("DSRI", receivables(t), receivables(p)),
+		GMI:    in.Divide("GMI", grossMargin(p), grossMargin(t)),
+		AQI:    in.Divide("AQI", assetQuality(t), assetQuality(p)),
+		SGI:    in.Divide("SGI", in.Value("totalRevenue", t, 0), in.Value("totalRevenue", p, 0)),
+		DEPI:   in.Divide("DEPI", depreciation(p), depreciation(t)),
+		SGAI:   in.Divide("SGAI", sga(t), sga(p)),
+		TATA:   in.Divide("TATA", in.Value("netIncomeFromContinuingOperations", t, 0)-in.Value("operatingCashflow", t, 0), in.Value("totalAssets", t, 0)),
+		LVGI:   in.Divide("LVGI", leverage(t), leverage(p)),
+	}
+	if err := in.Err(); err != nil {
+		return nil, fmt.Errorf("Beneish M-score: %w", err)
+	}
+	score.Score = -4.84 + 0.92*score.DSRI + 0.528*score.GMI + 0.404*score.AQI + 0.892*score.SGI +
+		0.115*score.DEPI - 0.172*score.SGAI + 4.679*score.TATA - 0.327*score.LVGI
+	score.LikelyManipulator = score.Score > BeneishThreshold
+	return score, nil
+}
+
+// latestFiscalYear joins the statements and returns the latest fiscal
+// year of their annual reports.
+func latestFiscalYear(balanceSheet *BalanceSheet, incomeStatement *IncomeStatement, cashFlow *CashFlow) (*Financials, int, error) {
+	financials, err := NewFinancials("", CalendarYear, balanceSheet, incomeStatement, cashFlow, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(financials.Annual) == 0 {
+		return nil, 0, fmt.Errorf("no annual reports: %w", ErrMissingPeriod)
+	}
+	return financials, financials.Annual[len(financials.Annual)-1].Period.FiscalYear, nil
+}
+
+// NewPiotroskiFScore computes the F-score of the latest fiscal year of the
+// annual reports, see Financials.PiotroskiFScore.
+func NewPiotroskiFScore(balanceSheet *BalanceSheet, incomeStatement *IncomeStatement, cashFlow *CashFlow) (*PiotroskiFScore, error) {
+	financials, fiscalYear, err := latestFiscalYear(balanceSheet, incomeStatement, cashFlow)
+	if err != nil {
+		return nil, fmt.Errorf("Piotroski F-score: %w", err)
+	}
+	return financials.PiotroskiFScore(fiscalYear)
+}
+
+// NewAltmanZScore computes the Z-score of the latest fiscal year of the
+// annual reports, see Financials.AltmanZScore.
+func NewAltmanZScore(balanceSheet *BalanceSheet, incomeStatement *IncomeStatement, overview *CompanyOverview) (*AltmanZScore, error) {
+	financials, fiscalYear, err := latestFiscalYear(balanceSheet, incomeStatement, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Altman Z-score: %w", err)
+	}
+	return financials.AltmanZScore(fiscalYear, overview)
+}
+
+// NewBeneishMScore computes the M-score of the latest fiscal year of the
+// annual reports, see Financials.BeneishMScore.
+func NewBeneishMScore(balanceSheet *BalanceSheet, incomeStatement *IncomeStatement, cashFlow *CashFlow) (*BeneishMScore, error) {
+	financials, fiscalYear, err := latestFiscalYear(balanceSheet, incomeStatement, cashFlow)
+	if err != nil {
+		return nil, fmt.Errorf("Beneish M-score: %w", err)
+	}
+	return financials.BeneishMScore(fiscalYear)
+}
@@ -0,0 +1,166 @@
+package alphavantage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func newScoreStatements() (*BalanceSheet, *IncomeStatement, *CashFlow) {
+	balanceSheet := &BalanceSheet{AnnualReports: []BsAnnualReport{{
+		FiscalDateEnding:             "2023-12-31",
+		TotalAssets:                  NewAVInt(1000),
+		TotalCurrentAssets:           NewAVInt(400),
+		TotalCurrentLiabilities:      NewAVInt(200),
+		PropertyPlantEquipment:       NewAVInt(300),
+		CurrentNetReceivables:        NewAVInt(100),
+		LongTermDebt:                 NewAVInt(200),
+		TotalLiabilities:             NewAVInt(500),
+		RetainedEarnings:             NewAVInt(300),
+		CommonStockSharesOutstanding: NewAVInt(100),
+	}, {
+		FiscalDateEnding:             "2022-12-31",
+		TotalAssets:                  NewAVInt(1000),
+		TotalCurrentAssets:           NewAVInt(300),
+		TotalCurrentLiabilities:      NewAVInt(200),
+		PropertyPlantEquipment:       NewAVInt(300),
+		CurrentNetReceivables:        NewAVInt(100),
+		LongTermDebt:                 NewAVInt(250),
+		TotalLiabilities:             NewAVInt(550),
+		RetainedEarnings:             NewAVInt(250),
+		CommonStockSharesOutstanding: NewAVInt(110),
+	}}}
+	incomeStatement := &IncomeStatement{AnnualReports: []IsAnnualReport{{
+		FiscalDateEnding:                  "2023-12-31",
+		TotalRevenue:                      NewAVInt(1000),
+		GrossProfit:                       NewAVInt(400),
+		SellingGeneralAndAdministrative:   NewAVInt(100),
+		DepreciationAndAmortization:       NewAVInt(100),
+		Ebit:                              NewAVInt(150),
+		NetIncome:                         NewAVInt(100),
+		NetIncomeFromContinuingOperations: NewAVInt(100),
+	}, {
+		FiscalDateEnding:                  "2022-12-31",
+		TotalRevenue:                      NewAVInt(800),
+		GrossProfit:                       NewAVInt(300),
+		SellingGeneralAndAdministrative:   NewAVInt(100),
+		DepreciationAndAmortization:       NewAVInt(100),
+		Ebit:                              NewAVInt(100),
+		NetIncome:                         NewAVInt(50),
+		NetIncomeFromContinuingOperations: NewAVInt(50),
+	}}}
+	cashFlow := &CashFlow{AnnualReports: []CfAnnualReport{{
+		FiscalDateEnding:  "2023-12-31",
+		OperatingCashflow: NewAVInt(150),
+	}, {
+		FiscalDateEnding:  "2022-12-31",
+		OperatingCashflow: NewAVInt(80),
+	}}}
+	return balanceSheet, incomeStatement, cashFlow
+}
+
+func TestPiotroskiFScore(t *testing.T) {
+	score, err := NewPiotroskiFScore(newScoreStatements())
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "FY2023", score.Period.String())
+	assert.EqualInt(t, 1, score.ROA)
+	assert.EqualInt(t, 1, score.OperatingCashFlow)
+	assert.EqualInt(t, 1, score.DeltaROA)
+	assert.EqualInt(t, 1, score.Accrual)
+	assert.EqualInt(t, 1, score.DeltaLeverage)
+	assert.EqualInt(t, 1, score.DeltaLiquidity)
+	assert.EqualInt(t, 1, score.NoDilution)
+	// 0.4 over 0.375
+	assert.EqualInt(t, 1, score.DeltaGrossMargin)
+	assert.EqualInt(t, 1, score.DeltaAssetTurnover)
+	assert.EqualInt(t, 9, score.Score)
+
+	balanceSheet, incomeStatement, cashFlow := newScoreStatements()
+	balanceSheet.AnnualReports[0].CommonStockSharesOutstanding = NewAVInt(120)
+	cashFlow.AnnualReports[0].OperatingCashflow = NewAVInt(90)
+	score, err = NewPiotroskiFScore(balanceSheet, incomeStatement, cashFlow)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 0, score.NoDilution)
+	assert.EqualInt(t, 0, score.Accrual)
+	assert.EqualInt(t, 7, score.Score)
+}
+
+func TestPiotroskiFScoreErrors(t *testing.T) {
+	balanceSheet, incomeStatement, cashFlow := newScoreStatements()
+	balanceSheet.AnnualReports[1].LongTermDebt = AVInt{}
+	_, err := NewPiotroskiFScore(balanceSheet, incomeStatement, cashFlow)
+	assert.True(t, errors.Is(err, ErrNoneValue))
+	assert.EqualErrors(t, errors.New("Piotroski F-score: FY2022 longTermDebt: value is None"), err)
+
+	balanceSheet, incomeStatement, cashFlow = newScoreStatements()
+	balanceSheet.AnnualReports = balanceSheet.AnnualReports[:1]
+	_, err = NewPiotroskiFScore(balanceSheet, incomeStatement, cashFlow)
+	assert.True(t, errors.Is(err, ErrMissingPeriod))
+
+	_, err = NewPiotroskiFScore(nil, nil, nil)
+	assert.EqualErrors(t, errors.New("Piotroski F-score: no annual reports: missing period"), err)
+}
+
+func TestAltmanZScore(t *testing.T) {
+	balanceSheet, incomeStatement, _ := newScoreStatements()
+	overview := &CompanyOverview{MarketCapitalization: NewAVInt(1500)}
+	score, err := NewAltmanZScore(balanceSheet, incomeStatement, overview)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64Tol(t, 0.2, score.WorkingCapitalToAssets, 1e-12)
+	assert.EqualFloat64Tol(t, 0.3, score.RetainedEarningsToAssets, 1e-12)
+	assert.EqualFloat64Tol(t, 0.15, score.EBITToAssets, 1e-12)
+	assert.EqualFloat64Tol(t, 3, score.MarketValueToLiabilities, 1e-12)
+	assert.EqualFloat64Tol(t, 1, score.SalesToAssets, 1e-12)
+	assert.EqualFloat64Tol(t, 1.2*0.2+1.4*0.3+3.3*0.15+0.6*3+1, score.Score, 1e-12)
+	assert.EqualStrings(t, AltmanSafe, score.Zone)
+
+	overview.MarketCapitalization = NewAVInt(100)
+	score, err = NewAltmanZScore(balanceSheet, incomeStatement, overview)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, AltmanGrey, score.Zone)
+
+	_, err = NewAltmanZScore(balanceSheet, incomeStatement, &CompanyOverview{})
+	assert.EqualErrors(t, errors.New("Altman Z-score: MarketCapitalization: value is None"), err)
+
+	balanceSheet.AnnualReports[0].TotalLiabilities = NewAVInt(0)
+	_, err = NewAltmanZScore(balanceSheet, incomeStatement, overview)
+	assert.True(t, errors.Is(err, ErrDivisionByZero))
+	assert.ErrorIncludesMessage(t, "X4", err)
+}
+
+func TestBeneishMScore(t *testing.T) {
+	score, err := NewBeneishMScore(newScoreStatements())
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64Tol(t, 0.8, score.DSRI, 1e-12)
+	assert.EqualFloat64Tol(t, 0.375/0.4, score.GMI, 1e-12)
+	assert.EqualFloat64Tol(t, 0.3/0.4, score.AQI, 1e-12)
+	assert.EqualFloat64Tol(t, 1.25, score.SGI, 1e-12)
+	assert.EqualFloat64Tol(t, 1, score.DEPI, 1e-12)
+	assert.EqualFloat64Tol(t, 0.8, score.SGAI, 1e-12)
+	assert.EqualFloat64Tol(t, -0.05, score.TATA, 1e-12)
+	assert.EqualFloat64Tol(t, 0.4/0.45, score.LVGI, 1e-12)
+	expected := -4.84 + 0.92*0.8 + 0.528*0.375/0.4 + 0.404*0.3/0.4 + 0.892*1.25 +
+		0.115*1 - 0.172*0.8 + 4.679*-0.05 - 0.327*0.4/0.45
+	assert.EqualFloat64Tol(t, expected, score.Score, 1e-12)
+	assert.False(t, score.LikelyManipulator)
+
+	balanceSheet, incomeStatement, cashFlow := newScoreStatements()
+	incomeStatement.AnnualReports[0].NetIncomeFromContinuingOperations = AVInt{}
+	_, err = NewBeneishMScore(balanceSheet, incomeStatement, cashFlow)
+	assert.EqualErrors(t, errors.New("Beneish M-score: FY2023 netIncomeFromContinuingOperations: value is None"), err)
+}
+
+func TestFinancialsScores(t *testing.T) {
+	balanceSheet, incomeStatement, cashFlow := newScoreStatements()
+	financials, err := NewFinancials("STOCK1", CalendarYear, balanceSheet, incomeStatement, cashFlow, nil)
+	assert.NoError(t.Fatalf, err)
+
+	_, err = financials.PiotroskiFScore(2022)
+	assert.EqualErrors(t, errors.New("Piotroski F-score: FY2021: missing period"), err)
+	_, err = financials.BeneishMScore(2024)
+	assert.EqualErrors(t, errors.New("Beneish M-score: FY2024: missing period"), err)
+	score, err := financials.AltmanZScore(2022, &CompanyOverview{MarketCapitalization: NewAVInt(110)})
+	assert.NoError(t, err)
+	assert.EqualStrings(t, AltmanDistress, score.Zone)
+}
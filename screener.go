@@ -0,0 +1,163 @@
+package alphavantage
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Factor ranks overviews by a numeric field of CompanyOverview.
+type Factor struct {
+	// Field is the Go or JSON name, e.g. "PERatio"
+	Field string
+	// LowerIsBetter ranks low values first, e.g. for PERatio
+	LowerIsBetter bool
+	// Weight of the factor in the score, 0 is 1
+	Weight float64
+}
+
+// ScreenResult is an overview which matched the filter.
+type ScreenResult struct {
+	Overview *CompanyOverview
+	// Rank is 1 for the best score
+	Rank int
+	// Score is the weighted mean of the percentiles, 0 to 100, NaN if all
+	// factors are missing
+	Score float64
+	// Percentiles are by factor field, 100 for the best value.  Missing
+	// fields have no percentile.
+	Percentiles map[string]float64
+}
+
+// Screener filters overviews and ranks them by percentiles of factors.
+// Percentiles are computed over the overviews which match the filter.
+type Screener struct {
+	filter  *Filter
+	factors []Factor
+	fields  []overviewField
+}
+
+// NewScreener parses the filter expression, see Filter, "" for all
+// overviews.
+func NewScreener(expression string, factors ...Factor) (*Screener, error) {
+	screener := &Screener{factors: factors}
+	if expression != "" {
+		filter, err := ParseFilter(expression)
+		if err != nil {
+			return nil, err
+		}
+		screener.filter = filter
+	}
+	for _, factor := range factors {
+		field, ok := overviewFields[factor.Field]
+		if !ok || field.valueType != typeNumber {
+			return nil, fmt.Errorf("invalid factor: %q isn't a numeric field", factor.Field)
+		}
+		if factor.Weight < 0 {
+			return nil, fmt.Errorf("invalid factor: %q has a negative weight", factor.Field)
+		}
+		screener.fields = append(screener.fields, field)
+	}
+	return screener, nil
+}
+
+// Screen returns the overviews which match the filter, ranked by their
+// scores.  Overviews without a score come last, ties are ordered by
+// symbol.
+func (s *Screener) Screen(overviews []*CompanyOverview) []ScreenResult {
+	var results []ScreenResult
+	for _, overview := range overviews {
+		if overview == nil || (s.filter != nil && !s.filter.Match(overview)) {
+			continue
+		}
+		results = append(results, ScreenResult{Overview: overview, Score: math.NaN(), Percentiles: make(map[string]float64)})
+	}
+
+	for i, factor := range s.factors {
+		values := make([]filterValue, len(results))
+		for j := range results {
+			values[j] = s.fields[i].eval(reflect.ValueOf(results[j].Overview).Elem())
+		}
+		for j, percentile := range percentiles(values) {
+			if math.IsNaN(percentile) {
+				continue
+			}
+			if factor.LowerIsBetter {
+				percentile = 100 - percentile
+			}
+			results[j].Percentiles[factor.Field] = percentile
+		}
+	}
+
+	for i := range results {
+		var sum, weights float64
+		for _, factor := range s.factors {
+			percentile, ok := results[i].Percentiles[factor.Field]
+			if !ok {
+				continue
+			}
+			weight := factor.Weight
+			if weight == 0 {
+				weight = 1
+			}
+			sum += weight * percentile
+			weights += weight
+		}
+		if weights > 0 {
+			results[i].Score = sum / weights
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i].Score, results[j].Score
+		switch {
+		case math.IsNaN(a) != math.IsNaN(b):
+			return !math.IsNaN(a)
+		case !math.IsNaN(a) && a != b:
+			return a > b
+		}
+		return results[i].Overview.Symbol < results[j].Overview.Symbol
+	})
+	for i := range results {
+		results[i].Rank = i + 1
+	}
+	return results
+}
+
+// Screen filters and ranks the overviews, see NewScreener.
+func Screen(overviews []*CompanyOverview, expression string, factors ...Factor) ([]ScreenResult, error) {
+	screener, err := NewScreener(expression, factors...)
+	if err != nil {
+		return nil, err
+	}
+	return screener.Screen(overviews), nil
+}
+
+// percentiles returns the percentile ranks of the values, 0 for the
+// lowest and 100 for the highest, ties get the mean of their ranks.  A
+// single value is 50, missing values are NaN.
+func percentiles(values []filterValue) []float64 {
+	var present []float64
+	for _, v := range values {
+		if !v.missing {
+			present = append(present, v.num)
+		}
+	}
+	sort.Float64s(present)
+
+	result := make([]float64, len(values))
+	for i, v := range values {
+		switch {
+		case v.missing:
+			result[i] = math.NaN()
+		case len(present) == 1:
+			result[i] = 50
+		default:
+			below := sort.SearchFloat64s(present, v.num)
+			equal := sort.SearchFloat64s(present, math.Nextafter(v.num, math.Inf(1))) - below
+			result[i] = (float64(below) + float64(equal-1)/2) / float64(len(present)-1) * 100
+		}
+	}
+	return result
+}
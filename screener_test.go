@@ -0,0 +1,100 @@
+package alphavantage
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func newScreenUniverse() []*CompanyOverview {
+	return []*CompanyOverview{
+		{Symbol: "AAA", Sector: "TECHNOLOGY", PERatio: NewAVFloat64(30), DividendYield: NewAVFloat64(0.01), MarketCapitalization: NewAVInt(3e10)},
+		{Symbol: "BBB", Sector: "TECHNOLOGY", PERatio: NewAVFloat64(10), DividendYield: NewAVFloat64(0.03), MarketCapitalization: NewAVInt(2e10)},
+		{Symbol: "CCC", Sector: "TECHNOLOGY", PERatio: NewAVFloat64(20), DividendYield: AVFloat64{}, MarketCapitalization: NewAVInt(5e10)},
+		{Symbol: "DDD", Sector: "TECHNOLOGY", PERatio: AVFloat64{}, DividendYield: AVFloat64{}, MarketCapitalization: NewAVInt(4e10)},
+		{Symbol: "EEE", Sector: "ENERGY", PERatio: NewAVFloat64(5), DividendYield: NewAVFloat64(0.05), MarketCapitalization: NewAVInt(6e10)},
+		nil,
+	}
+}
+
+func TestScreen(t *testing.T) {
+	results, err := Screen(newScreenUniverse(), `Sector == "TECHNOLOGY"`,
+		Factor{Field: "PERatio", LowerIsBetter: true},
+		Factor{Field: "DividendYield", Weight: 2})
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 4, len(results))
+
+	// BBB: PE 100th and yield 100th percentile
+	assert.EqualStrings(t, "BBB", results[0].Overview.Symbol)
+	assert.EqualInt(t, 1, results[0].Rank)
+	assert.EqualFloat64(t, 100, results[0].Percentiles["PERatio"])
+	assert.EqualFloat64(t, 100, results[0].Percentiles["DividendYield"])
+	assert.EqualFloat64(t, 100, results[0].Score)
+
+	// CCC: PE 50th percentile, no yield
+	assert.EqualStrings(t, "CCC", results[1].Overview.Symbol)
+	assert.EqualFloat64(t, 50, results[1].Score)
+	_, ok := results[1].Percentiles["DividendYield"]
+	assert.False(t, ok)
+
+	// AAA: PE 0th and yield 0th percentile
+	assert.EqualStrings(t, "AAA", results[2].Overview.Symbol)
+	assert.EqualFloat64(t, 0, results[2].Score)
+
+	// DDD has neither, it comes last
+	assert.EqualStrings(t, "DDD", results[3].Overview.Symbol)
+	assert.EqualInt(t, 4, results[3].Rank)
+	assert.True(t, math.IsNaN(results[3].Score))
+	assert.EqualInt(t, 0, len(results[3].Percentiles))
+}
+
+func TestScreenWeights(t *testing.T) {
+	results, err := Screen(newScreenUniverse(), `has(PERatio) && has(DividendYield)`,
+		Factor{Field: "PERatio", LowerIsBetter: true, Weight: 1},
+		Factor{Field: "MarketCapitalization", Weight: 3})
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 3, len(results))
+	// EEE has the lowest PE and the highest market cap
+	assert.EqualStrings(t, "EEE", results[0].Overview.Symbol)
+	assert.EqualFloat64(t, 100, results[0].Score)
+	// AAA: PE 0th, market cap 50th percentile
+	assert.EqualStrings(t, "AAA", results[1].Overview.Symbol)
+	assert.EqualFloat64(t, 37.5, results[1].Score)
+	// BBB: PE 50th, market cap 0th percentile
+	assert.EqualStrings(t, "BBB", results[2].Overview.Symbol)
+	assert.EqualFloat64(t, 12.5, results[2].Score)
+}
+
+func TestScreenWithoutFactors(t *testing.T) {
+	screener, err := NewScreener("")
+	assert.NoError(t.Fatalf, err)
+	results := screener.Screen(newScreenUniverse())
+	assert.EqualInt(t, 5, len(results))
+	assert.EqualStrings(t, "AAA", results[0].Overview.Symbol)
+	assert.EqualStrings(t, "EEE", results[4].Overview.Symbol)
+	assert.EqualInt(t, 5, results[4].Rank)
+}
+
+func TestNewScreenerErrors(t *testing.T) {
+	_, err := NewScreener("PERatio <")
+	assert.ErrorIncludesMessage(t, "unexpected end", err)
+	_, err = NewScreener("", Factor{Field: "Sector"})
+	assert.EqualErrors(t, errors.New(`invalid factor: "Sector" isn't a numeric field`), err)
+	_, err = NewScreener("", Factor{Field: "Beta", Weight: -1})
+	assert.EqualErrors(t, errors.New(`invalid factor: "Beta" has a negative weight`), err)
+}
+
+func TestPercentiles(t *testing.T) {
+	values := []filterValue{{num: 3}, {num: 1}, missingValue, {num: 3}, {num: 2}}
+	result := percentiles(values)
+	// ties get the mean of their ranks 2 and 3 out of 0 to 3
+	assert.EqualFloat64Tol(t, 250.0/3, result[0], 1e-12)
+	assert.EqualFloat64(t, 0, result[1])
+	assert.True(t, math.IsNaN(result[2]))
+	assert.EqualFloat64Tol(t, 250.0/3, result[3], 1e-12)
+	assert.EqualFloat64Tol(t, 100.0/3, result[4], 1e-12)
+
+	assert.EqualFloat64(t, 50, percentiles([]filterValue{{num: 7}})[0])
+}
@@ -0,0 +1,578 @@
+package alphavantage
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Filter expressions select company overviews, e.g.
+//
+//	Sector == "TECHNOLOGY" && PERatio < 20 && MarketCapitalization > 1e10
+//
+// Fields are the ones of CompanyOverview by Go or JSON name, including
+// JSON names which start with digits, e.g. 52WeekHigh.  Numbers
+// support + - * /, numbers and strings the comparisons == != < <= > >=,
+// which combine with && || ! and parentheses.  has(Field) reports whether
+// a field isn't "None".
+//
+// "None" fields, and empty strings, are missing, not 0: comparisons with
+// them are unknown, and so are && and || unless the other side decides
+// them, e.g. "PEGRatio < 1 || Beta < 1" matches overviews without a
+// PEG ratio but with a low beta.  Unknown filters don't match.
+
+// FilterError is returned for invalid filter expressions.
+type FilterError struct {
+	Expression string
+	// Offset is the byte offset of the error in the expression
+	Offset  int
+	Message string
+}
+
+// Error implements error.
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("filter %q: %s at offset %d", e.Expression, e.Message, e.Offset)
+}
+
+// Filter is a parsed filter expression.
+type Filter struct {
+	expression string
+	root       filterNode
+}
+
+// ParseFilter parses the filter expression, see Filter.
+func ParseFilter(expression string) (*Filter, error) {
+	tokens, err := lexFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{expression: expression, tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+	if root.typ() != typeBool {
+		return nil, &FilterError{Expression: expression, Message: "expression isn't a condition"}
+	}
+	return &Filter{expression: expression, root: root}, nil
+}
+
+// MustParseFilter is like ParseFilter but panics on errors, e.g. for
+// filters of constants.
+func MustParseFilter(expression string) *Filter {
+	filter, err := ParseFilter(expression)
+	if err != nil {
+		panic(err)
+	}
+	return filter
+}
+
+// String returns the expression.
+func (f *Filter) String() string {
+	return f.expression
+}
+
+// Match reports whether the overview matches the filter.  Filters which
+// are unknown because of missing fields don't match.
+func (f *Filter) Match(overview *CompanyOverview) bool {
+	if overview == nil {
+		return false
+	}
+	result := f.root.eval(reflect.ValueOf(overview).Elem())
+	return !result.missing && result.b
+}
+
+// valueType is the type of an expression
+type valueType int
+
+const (
+	typeNumber valueType = iota
+	typeString
+	typeBool
+)
+
+func (t valueType) String() string {
+	return [...]string{"number", "string", "condition"}[t]
+}
+
+// filterValue is the value of an expression, missing for "None" fields
+// and unknown conditions.
+type filterValue struct {
+	num     float64
+	str     string
+	b       bool
+	missing bool
+}
+
+var missingValue = filterValue{missing: true}
+
+type filterNode interface {
+	typ() valueType
+	eval(overview reflect.Value) filterValue
+}
+
+// overviewFields are the fields of CompanyOverview which filters can use,
+// by Go and JSON name
+var overviewFields = func() map[string]overviewField {
+	fields := make(map[string]overviewField)
+	t := reflect.TypeOf(CompanyOverview{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		var typ valueType
+		switch field.Type {
+		case reflect.TypeOf(""):
+			typ = typeString
+		case reflect.TypeOf(AVInt{}), reflect.TypeOf(AVFloat64{}), reflect.TypeOf(AVPercent{}):
+			typ = typeNumber
+		default:
+			continue
+		}
+		f := overviewField{name: field.Name, index: i, valueType: typ}
+		fields[field.Name] = f
+		if name := jsonName(field); name != "" {
+			fields[name] = f
+		}
+	}
+	return fields
+}()
+
+// overviewField is a field of CompanyOverview
+type overviewField struct {
+	name      string
+	index     int
+	valueType valueType
+}
+
+func (f overviewField) typ() valueType {
+	return f.valueType
+}
+
+func (f overviewField) eval(overview reflect.Value) filterValue {
+	v := overview.Field(f.index).Interface()
+	switch v := v.(type) {
+	case string:
+		if v == "" || v == "None" || v == "-" {
+			return missingValue
+		}
+		return filterValue{str: v}
+	case NullableNumber:
+		if v.IsNull() {
+			return missingValue
+		}
+		return filterValue{num: v.Float64()}
+	}
+	return missingValue
+}
+
+// hasNode is has(Field)
+type hasNode struct {
+	field overviewField
+}
+
+func (n hasNode) typ() valueType { return typeBool }
+
+func (n hasNode) eval(overview reflect.Value) filterValue {
+	return filterValue{b: !n.field.eval(overview).missing}
+}
+
+type literalNode struct {
+	valueType valueType
+	value     filterValue
+}
+
+func (n literalNode) typ() valueType { return n.valueType }
+
+func (n literalNode) eval(reflect.Value) filterValue { return n.value }
+
+type negateNode struct {
+	operand filterNode
+}
+
+func (n negateNode) typ() valueType { return typeNumber }
+
+func (n negateNode) eval(overview reflect.Value) filterValue {
+	v := n.operand.eval(overview)
+	v.num = -v.num
+	return v
+}
+
+type notNode struct {
+	operand filterNode
+}
+
+func (n notNode) typ() valueType { return typeBool }
+
+func (n notNode) eval(overview reflect.Value) filterValue {
+	v := n.operand.eval(overview)
+	v.b = !v.b
+	return v
+}
+
+// binaryNode is an arithmetic, comparison or logical operation
+type binaryNode struct {
+	op          string
+	left, right filterNode
+}
+
+func (n binaryNode) typ() valueType {
+	switch n.op {
+	case "+", "-", "*", "/":
+		return typeNumber
+	}
+	return typeBool
+}
+
+func (n binaryNode) eval(overview reflect.Value) filterValue {
+	left := n.left.eval(overview)
+	switch n.op {
+	case "&&":
+		// false decides && even if the other side is unknown
+		if !left.missing && !left.b {
+			return left
+		}
+		right := n.right.eval(overview)
+		if !right.missing && !right.b {
+			return right
+		}
+		return filterValue{b: true, missing: left.missing || right.missing}
+	case "||":
+		if !left.missing && left.b {
+			return left
+		}
+		right := n.right.eval(overview)
+		if !right.missing && right.b {
+			return right
+		}
+		return filterValue{missing: left.missing || right.missing}
+	}
+
+	right := n.right.eval(overview)
+	if left.missing || right.missing {
+		return missingValue
+	}
+	switch n.op {
+	case "+":
+		return filterValue{num: left.num + right.num}
+	case "-":
+		return filterValue{num: left.num - right.num}
+	case "*":
+		return filterValue{num: left.num * right.num}
+	case "/":
+		if right.num == 0 {
+			return missingValue
+		}
+		return filterValue{num: left.num / right.num}
+	}
+
+	var c int
+	if n.left.typ() == typeString {
+		c = strings.Compare(left.str, right.str)
+	} else if left.num < right.num {
+		c = -1
+	} else if left.num > right.num {
+		c = 1
+	}
+	var b bool
+	switch n.op {
+	case "==":
+		b = c == 0
+	case "!=":
+		b = c != 0
+	case "<":
+		b = c < 0
+	case "<=":
+		b = c <= 0
+	case ">":
+		b = c > 0
+	case ">=":
+		b = c >= 0
+	}
+	return filterValue{b: b}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOperator
+)
+
+type filterToken struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+// filterOperators are the operators, longest first
+var filterOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "(", ")"}
+
+func lexFilter(expression string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expression); {
+		r := rune(expression[i])
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			i = identEnd(expression, i)
+			tokens = append(tokens, filterToken{kind: tokenIdent, text: expression[start:i], offset: start})
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(expression) && (unicode.IsDigit(rune(expression[i])) || expression[i] == '.') {
+				i++
+			}
+			if i < len(expression) && (expression[i] == 'e' || expression[i] == 'E') {
+				i++
+				if i < len(expression) && (expression[i] == '+' || expression[i] == '-') {
+					i++
+				}
+				for i < len(expression) && unicode.IsDigit(rune(expression[i])) {
+					i++
+				}
+			}
+			// JSON names like 52WeekHigh start with digits, they are
+			// identifiers if the word is longer than the number
+			if word := identEnd(expression, start); word > i {
+				tokens = append(tokens, filterToken{kind: tokenIdent, text: expression[start:word], offset: start})
+				i = word
+				continue
+			}
+			tokens = append(tokens, filterToken{kind: tokenNumber, text: expression[start:i], offset: start})
+		case r == '"':
+			start := i
+			for i++; i < len(expression) && expression[i] != '"'; i++ {
+				if expression[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(expression) {
+				return nil, &FilterError{Expression: expression, Offset: start, Message: "unterminated string"}
+			}
+			i++
+			tokens = append(tokens, filterToken{kind: tokenString, text: expression[start:i], offset: start})
+		default:
+			operator := ""
+			for _, op := range filterOperators {
+				if strings.HasPrefix(expression[i:], op) {
+					operator = op
+					break
+				}
+			}
+			if operator == "" {
+				return nil, &FilterError{Expression: expression, Offset: i, Message: fmt.Sprintf("unexpected %q", r)}
+			}
+			tokens = append(tokens, filterToken{kind: tokenOperator, text: operator, offset: i})
+			i += len(operator)
+		}
+	}
+	return append(tokens, filterToken{kind: tokenEOF, offset: len(expression)}), nil
+}
+
+// identEnd returns the end of the letters, digits and underscores from i
+func identEnd(expression string, i int) int {
+	for i < len(expression) && (expression[i] == '_' || unicode.IsLetter(rune(expression[i])) || unicode.IsDigit(rune(expression[i]))) {
+		i++
+	}
+	return i
+}
+
+// filterParser parses the tokens by recursive descent, from the lowest
+// precedence: || && ! comparisons + - * / and unary -.
+type filterParser struct {
+	expression string
+	tokens     []filterToken
+	pos        int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the operator if it's next
+func (p *filterParser) accept(operators ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return "", false
+	}
+	for _, op := range operators {
+		if t.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *filterParser) errorf(t filterToken, format string, args ...interface{}) error {
+	return &FilterError{Expression: p.expression, Offset: t.offset, Message: fmt.Sprintf(format, args...)}
+}
+
+// binary parses operands of the operators with the next precedence,
+// checking their types
+func (p *filterParser) binary(operand func() (filterNode, error), operandType valueType, operators ...string) (filterNode, error) {
+	start := p.peek()
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(operators...)
+		if !ok {
+			return left, nil
+		}
+		rightStart := p.peek()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != operandType {
+			return nil, p.errorf(start, "%s of %s", left.typ(), op)
+		}
+		if right.typ() != operandType {
+			return nil, p.errorf(rightStart, "%s of %s", right.typ(), op)
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	return p.binary(p.parseAnd, typeBool, "||")
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	return p.binary(p.parseNot, typeBool, "&&")
+}
+
+func (p *filterParser) parseNot() (filterNode, error) {
+	t := p.peek()
+	if _, ok := p.accept("!"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if operand.typ() != typeBool {
+			return nil, p.errorf(t, "%s of !", operand.typ())
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	op, ok := p.accept("==", "!=", "<", "<=", ">", ">=")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if left.typ() == typeBool || left.typ() != right.typ() {
+		return nil, p.errorf(t, "can't compare %s and %s", left.typ(), right.typ())
+	}
+	return binaryNode{op: op, left: left, right: right}, nil
+}
+
+func (p *filterParser) parseSum() (filterNode, error) {
+	return p.binary(p.parseProduct, typeNumber, "+", "-")
+}
+
+func (p *filterParser) parseProduct() (filterNode, error) {
+	return p.binary(p.parseUnary, typeNumber, "*", "/")
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	t := p.peek()
+	if _, ok := p.accept("-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if operand.typ() != typeNumber {
+			return nil, p.errorf(t, "%s of -", operand.typ())
+		}
+		return negateNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		num, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, p.errorf(t, "invalid number %q", t.text)
+		}
+		return literalNode{valueType: typeNumber, value: filterValue{num: num}}, nil
+	case tokenString:
+		str, err := strconv.Unquote(t.text)
+		if err != nil {
+			return nil, p.errorf(t, "invalid string %s", t.text)
+		}
+		return literalNode{valueType: typeString, value: filterValue{str: str}}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true", "false":
+			return literalNode{valueType: typeBool, value: filterValue{b: t.text == "true"}}, nil
+		case "has":
+			return p.parseHas()
+		}
+		field, ok := overviewFields[t.text]
+		if !ok {
+			return nil, p.errorf(t, "unknown field %q", t.text)
+		}
+		return field, nil
+	case tokenOperator:
+		if t.text == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.accept(")"); !ok {
+				return nil, p.errorf(p.peek(), "missing )")
+			}
+			return node, nil
+		}
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+	return nil, p.errorf(t, "unexpected end")
+}
+
+// parseHas parses has(Field)
+func (p *filterParser) parseHas() (filterNode, error) {
+	if _, ok := p.accept("("); !ok {
+		return nil, p.errorf(p.peek(), "missing ( after has")
+	}
+	arg := p.next()
+	field, ok := overviewFields[arg.text]
+	if arg.kind != tokenIdent || !ok {
+		return nil, p.errorf(arg, "has needs a field")
+	}
+	if _, ok := p.accept(")"); !ok {
+		return nil, p.errorf(p.peek(), "missing )")
+	}
+	return hasNode{field: field}, nil
+}
@@ -0,0 +1,108 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func newScreenOverview(t *testing.T, data string) *CompanyOverview {
+	overview := &CompanyOverview{}
+	assert.NoError(t.Fatalf, json.Unmarshal([]byte(data), overview))
+	return overview
+}
+
+func TestFilterMatch(t *testing.T) {
+	overview := newScreenOverview(t, `{
+		"Symbol": "IBM",
+		"Sector": "TECHNOLOGY",
+		"LatestQuarter": "2024-03-31",
+		"MarketCapitalization": "176297582000",
+		"PERatio": "17.5",
+		"PEGRatio": "None",
+		"Beta": "0.7",
+		"DividendYield": "0",
+		"AnalystRatingStrongBuy": "3",
+		"AnalystRatingBuy": "5",
+		"52WeekHigh": "199.18",
+		"50DayMovingAverage": "190.5",
+		"200DayMovingAverage": "180.25"
+	}`)
+
+	for _, tc := range []struct {
+		expression string
+		match      bool
+	}{
+		{`Sector == "TECHNOLOGY" && PERatio < 20 && MarketCapitalization > 1e10`, true},
+		{`Sector == "TECHNOLOGY" && PERatio < 15`, false},
+		{`Sector != "TECHNOLOGY" || Beta < 1`, true},
+		{`!(PERatio >= 20)`, true},
+		{`AnalystRatingStrongBuy + AnalystRatingBuy >= 8`, true},
+		{`MarketCapitalization / 1e9 > 170 && MarketCapitalization / 1e9 < 180`, true},
+		{`-Beta < -0.5`, true},
+		{`LatestQuarter >= "2024-01-01"`, true},
+		{`Week52High > 199`, true},
+		// JSON names which start with digits
+		{`52WeekHigh > 199`, true},
+		{`50DayMovingAverage > 200DayMovingAverage`, true},
+		{`200DayMovingAverage*2 > 360`, true},
+		{`MarketCapitalization > 1.7E+11 && Beta < 7e-1 + .1`, true},
+		// a genuine 0 isn't missing
+		{`DividendYield == 0`, true},
+		{`has(DividendYield) && !has(PEGRatio)`, true},
+		// comparisons with "None" are unknown, not 0
+		{`PEGRatio < 1`, false},
+		{`!(PEGRatio < 1)`, false},
+		{`PEGRatio >= 1`, false},
+		{`PEGRatio < 1 || Beta < 1`, true},
+		{`PEGRatio < 1 && Beta < 1`, false},
+		{`PEGRatio < 1 && Beta > 1`, false},
+		{`PERatio / DividendYield > 0`, false},
+		{`Industry == ""`, false},
+		{`true`, true},
+	} {
+		filter, err := ParseFilter(tc.expression)
+		assert.NoError(t.Fatalf, err)
+		if filter.Match(overview) != tc.match {
+			t.Errorf("%s: want %v", tc.expression, tc.match)
+		}
+	}
+	assert.False(t, MustParseFilter("true").Match(nil))
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	for _, tc := range []struct {
+		expression string
+		message    string
+	}{
+		{`PERatio < `, "unexpected end at offset 10"},
+		{`Price < 20`, `unknown field "Price" at offset 0`},
+		{`Sector < 20`, "can't compare string and number at offset 7"},
+		{`PERatio`, "expression isn't a condition at offset 0"},
+		{`PERatio < 20 &&`, "unexpected end"},
+		{`(PERatio < 20`, "missing ) at offset 13"},
+		{`Sector == "TECH`, "unterminated string at offset 10"},
+		{`PERatio < 20 # 1`, `unexpected '#' at offset 13`},
+		{`Sector + 1 > 2`, "string of + at offset 0"},
+		{`PERatio < 20 && Beta`, "number of && at offset 16"},
+		{`!Beta`, "number of ! at offset 0"},
+		{`has(20)`, "has needs a field at offset 4"},
+		{`1 < 2 < 3`, `unexpected "<" at offset 6`},
+		{`52WeekHighs > 100`, `unknown field "52WeekHighs" at offset 0`},
+		{`Beta < 1eps`, `unknown field "1eps" at offset 7`},
+	} {
+		_, err := ParseFilter(tc.expression)
+		var filterError *FilterError
+		if !errors.As(err, &filterError) {
+			t.Errorf("%s: want FilterError, got %v", tc.expression, err)
+			continue
+		}
+		assert.ErrorIncludesMessage(t, tc.message, err)
+	}
+	func() {
+		defer assert.Panic(t, `filter "PERatio": expression isn't a condition at offset 0`)
+		MustParseFilter("PERatio")
+	}()
+}
@@ -0,0 +1,209 @@
+package alphavantage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// defaultRestatementTolerance is the relative change of the adjustment
+// factor (adjusted close / close) of a date that counts as restatement.
+// Adjusted closes are rounded to 4 decimals by the API.
+const defaultRestatementTolerance = 0.0001
+
+// TimeSeriesAdjustedFetcher fetches adjusted time series, e.g. Client.
+type TimeSeriesAdjustedFetcher interface {
+	TimeSeriesAdjusted(symbol string, interval TimeSeriesIntervalAdjusted, outputSize OutputSize) (*TimeSeriesAdjusted, error)
+}
+
+// SeriesUpdate describes what an update of a SeriesStore did.
+type SeriesUpdate struct {
+	Symbol string
+	// FullFetch is true if the full history was downloaded
+	FullFetch bool
+	// Restated is true if the compact data changed the adjustment of
+	// known dates, e.g. after a split or a dividend, which caused the
+	// full history to be downloaded again
+	Restated bool
+	// Added is the number of new dates
+	Added int
+	// Updated is the number of known dates with changed prices, e.g.
+	// the latest bar fetched during the session
+	Updated int
+}
+
+// SeriesStore keeps the full history of adjusted time series.  The
+// full history is only downloaded once per symbol, later updates fetch
+// the compact output size (latest 100 data points) and merge it into
+// the history.  If the compact data restates the adjustment of known
+// dates or doesn't overlap with the history, the full history is
+// fetched again.
+type SeriesStore struct {
+	fetcher  TimeSeriesAdjustedFetcher
+	interval TimeSeriesIntervalAdjusted
+	// RestatementTolerance is the relative change of the adjustment
+	// factor of a date that triggers a full refetch.
+	RestatementTolerance float64
+
+	mutex  sync.Mutex
+	series map[string]*TimeSeriesAdjusted
+}
+
+// NewSeriesStore creates an empty store for the given interval.
+func NewSeriesStore(fetcher TimeSeriesAdjustedFetcher, interval TimeSeriesIntervalAdjusted) *SeriesStore {
+	return &SeriesStore{
+		fetcher:              fetcher,
+		interval:             interval,
+		RestatementTolerance: defaultRestatementTolerance,
+		series:               make(map[string]*TimeSeriesAdjusted),
+	}
+}
+
+// Get returns the stored series of the symbol without fetching.  The
+// returned series is replaced, not modified, by later updates.
+func (s *SeriesStore) Get(symbol string) (*TimeSeriesAdjusted, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ts, ok := s.series[symbol]
+	return ts, ok
+}
+
+// Set stores the series of the symbol, e.g. loaded from a cache, as
+// the known history.
+func (s *SeriesStore) Set(symbol string, ts *TimeSeriesAdjusted) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.series[symbol] = ts
+}
+
+// Symbols returns the stored symbols in alphabetical order.
+func (s *SeriesStore) Symbols() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	symbols := make([]string, 0, len(s.series))
+	for symbol := range s.series {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// Update fetches the latest data of the symbol and merges it into the
+// stored history.  The full history is fetched if the symbol isn't
+// stored yet.
+func (s *SeriesStore) Update(symbol string) (*SeriesUpdate, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	update := &SeriesUpdate{Symbol: symbol}
+	stored, ok := s.series[symbol]
+	if !ok || len(stored.getFilledData()) == 0 {
+		return update, s.fetchFull(update)
+	}
+
+	compact, err := s.fetcher.TimeSeriesAdjusted(symbol, s.interval, OutputSizeCompact)
+	if err != nil {
+		return nil, err
+	}
+	merged, restated, gap := s.merge(stored, compact, update)
+	if restated || gap {
+		update.Restated = restated
+		update.Added = 0
+		update.Updated = 0
+		return update, s.fetchFull(update)
+	}
+	s.series[symbol] = merged
+	return update, nil
+}
+
+// Refetch downloads the full history of the symbol again.
+func (s *SeriesStore) Refetch(symbol string) (*SeriesUpdate, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	update := &SeriesUpdate{Symbol: symbol}
+	return update, s.fetchFull(update)
+}
+
+func (s *SeriesStore) fetchFull(update *SeriesUpdate) error {
+	full, err := s.fetcher.TimeSeriesAdjusted(update.Symbol, s.interval, OutputSizeFull)
+	if err != nil {
+		return err
+	}
+	if len(full.getFilledData()) == 0 {
+		return fmt.Errorf("%s: no data in full history", update.Symbol)
+	}
+	update.FullFetch = true
+	s.series[update.Symbol] = full
+	return nil
+}
+
+// merge returns a copy of stored with the data of compact.  restated is
+// true if the adjustment factor of a known date changed, gap is true if
+// compact doesn't overlap with the stored dates, so data in between is
+// missing.
+func (s *SeriesStore) merge(stored, compact *TimeSeriesAdjusted, update *SeriesUpdate) (merged *TimeSeriesAdjusted, restated bool, gap bool) {
+	known := stored.getFilledData()
+	latest := compact.getFilledData()
+
+	var newestKnown string
+	for date := range known {
+		if date > newestKnown {
+			newestKnown = date
+		}
+	}
+	var oldestLatest string
+	for date := range latest {
+		if oldestLatest == "" || date < oldestLatest {
+			oldestLatest = date
+		}
+	}
+	if len(latest) > 0 && oldestLatest > newestKnown {
+		return nil, false, true
+	}
+
+	data := make(map[string]TimeSeriesAdjustedData, len(known)+len(latest))
+	for date, item := range known {
+		data[date] = item
+	}
+	for date, item := range latest {
+		previous, exists := known[date]
+		if !exists {
+			update.Added++
+		} else {
+			if s.isRestated(previous, item) {
+				return nil, true, false
+			}
+			if previous != item {
+				update.Updated++
+			}
+		}
+		data[date] = item
+	}
+
+	merged = &TimeSeriesAdjusted{Metadata: stored.Metadata}
+	if compact.Metadata.LastRefreshed > merged.Metadata.LastRefreshed {
+		merged.Metadata.LastRefreshed = compact.Metadata.LastRefreshed
+	}
+	switch stored.getFilledInterval() {
+	case IntervalWeekly:
+		merged.TimeSeriesWeekly = data
+	case IntervalMonthly:
+		merged.TimeSeriesMonthly = data
+	default:
+		merged.TimeSeriesDaily = data
+	}
+	return merged, false, false
+}
+
+// isRestated reports whether the adjustment factor of a date changed.
+// Comparing factors instead of adjusted closes ignores price updates of
+// the latest bar during the session.
+func (s *SeriesStore) isRestated(previous, latest TimeSeriesAdjustedData) bool {
+	if previous.Close == 0 || latest.Close == 0 {
+		return previous.AdjustedClose != latest.AdjustedClose
+	}
+	previousFactor := previous.AdjustedClose / previous.Close
+	latestFactor := latest.AdjustedClose / latest.Close
+	return math.Abs(latestFactor/previousFactor-1) > s.RestatementTolerance
+}
@@ -0,0 +1,122 @@
+package alphavantage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+// fakeAdjustedFetcher returns the prepared series by output size and
+// records the calls.
+type fakeAdjustedFetcher struct {
+	full    *TimeSeriesAdjusted
+	compact *TimeSeriesAdjusted
+	calls   []OutputSize
+}
+
+func (f *fakeAdjustedFetcher) TimeSeriesAdjusted(symbol string, interval TimeSeriesIntervalAdjusted, outputSize OutputSize) (*TimeSeriesAdjusted, error) {
+	f.calls = append(f.calls, outputSize)
+	if outputSize == OutputSizeFull {
+		if f.full == nil {
+			return nil, errors.New("no full series")
+		}
+		return f.full, nil
+	}
+	return f.compact, nil
+}
+
+func newDailyAdjusted(closes map[string][2]float64) *TimeSeriesAdjusted {
+	ts := &TimeSeriesAdjusted{
+		Metadata:        TimeSeriesMetadata{Symbol: "STOCK1"},
+		TimeSeriesDaily: make(map[string]TimeSeriesAdjustedData),
+	}
+	for date, c := range closes {
+		ts.TimeSeriesDaily[date] = TimeSeriesAdjustedData{Close: c[0], AdjustedClose: c[1], SplitCoefficient: 1}
+		if date > ts.Metadata.LastRefreshed {
+			ts.Metadata.LastRefreshed = date
+		}
+	}
+	return ts
+}
+
+func TestSeriesStoreUpdate(t *testing.T) {
+	fetcher := &fakeAdjustedFetcher{
+		full: newDailyAdjusted(map[string][2]float64{
+			"2024-02-20": {100, 50},
+			"2024-02-21": {102, 51},
+			"2024-02-22": {104, 52},
+		}),
+	}
+	store := NewSeriesStore(fetcher, TimeSeriesDailyAdjusted)
+
+	// first update fetches the full history
+	update, err := store.Update("STOCK1")
+	assert.NoError(t.Fatalf, err)
+	assert.True(t, update.FullFetch)
+	assert.EqualInt(t, 1, len(fetcher.calls))
+
+	// later updates merge the compact data, the latest bar changed
+	// during the session
+	fetcher.compact = newDailyAdjusted(map[string][2]float64{
+		"2024-02-21": {102, 51},
+		"2024-02-22": {106, 53},
+		"2024-02-23": {108, 54},
+	})
+	update, err = store.Update("STOCK1")
+	assert.NoError(t.Fatalf, err)
+	assert.False(t, update.FullFetch)
+	assert.EqualInt(t, 1, update.Added)
+	assert.EqualInt(t, 1, update.Updated)
+	assert.EqualStrings(t, string(OutputSizeCompact), string(fetcher.calls[1]))
+
+	ts, ok := store.Get("STOCK1")
+	assert.True(t, ok)
+	assert.EqualInt(t, 4, len(ts.TimeSeriesDaily))
+	assert.EqualFloat64(t, 106, ts.TimeSeriesDaily["2024-02-22"].Close)
+	assert.EqualStrings(t, "2024-02-23", ts.Metadata.LastRefreshed)
+	// the full history fetched before isn't modified
+	assert.EqualInt(t, 3, len(fetcher.full.TimeSeriesDaily))
+
+	// a 2:1 split halves all adjusted closes and restates the history
+	fetcher.full = newDailyAdjusted(map[string][2]float64{
+		"2024-02-20": {100, 25},
+		"2024-02-21": {102, 25.5},
+		"2024-02-22": {106, 26.5},
+		"2024-02-23": {108, 27},
+		"2024-02-26": {55, 55},
+	})
+	fetcher.compact = newDailyAdjusted(map[string][2]float64{
+		"2024-02-23": {108, 27},
+		"2024-02-26": {55, 55},
+	})
+	update, err = store.Update("STOCK1")
+	assert.NoError(t.Fatalf, err)
+	assert.True(t, update.Restated)
+	assert.True(t, update.FullFetch)
+	ts, _ = store.Get("STOCK1")
+	assert.EqualInt(t, 5, len(ts.TimeSeriesDaily))
+	assert.EqualFloat64(t, 25, ts.TimeSeriesDaily["2024-02-20"].AdjustedClose)
+}
+
+func TestSeriesStoreGap(t *testing.T) {
+	fetcher := &fakeAdjustedFetcher{
+		full: newDailyAdjusted(map[string][2]float64{"2024-02-20": {100, 100}, "2024-02-21": {101, 101}}),
+	}
+	store := NewSeriesStore(fetcher, TimeSeriesDailyAdjusted)
+	store.Set("STOCK1", newDailyAdjusted(map[string][2]float64{"2023-01-02": {90, 90}}))
+	assert.EqualStrings(t, "STOCK1", store.Symbols()[0])
+
+	// compact data doesn't reach back to the stored history
+	fetcher.compact = newDailyAdjusted(map[string][2]float64{"2024-02-21": {101, 101}})
+	update, err := store.Update("STOCK1")
+	assert.NoError(t.Fatalf, err)
+	assert.False(t, update.Restated)
+	assert.True(t, update.FullFetch)
+	ts, _ := store.Get("STOCK1")
+	assert.EqualInt(t, 2, len(ts.TimeSeriesDaily))
+
+	fetcher.full = nil
+	_, err = store.Refetch("STOCK1")
+	assert.ErrorIncludesMessage(t, "no full series", err)
+}
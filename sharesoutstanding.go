@@ -0,0 +1,63 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SharesOutstandingData represents the shares outstanding at a fiscal date.
+type SharesOutstandingData struct {
+	Date                     AVDate `json:"date"`
+	SharesOutstandingDiluted AVInt  `json:"shares_outstanding_diluted"`
+	SharesOutstandingBasic   AVInt  `json:"shares_outstanding_basic"`
+}
+
+// SharesOutstanding represents the quarterly shares outstanding history of a company.
+type SharesOutstanding struct {
+	Symbol string                  `json:"symbol"`
+	Status string                  `json:"status"`
+	Data   []SharesOutstandingData `json:"data"`
+}
+
+func toSharesOutstanding(buf []byte) (*SharesOutstanding, error) {
+	sharesOutstanding := &SharesOutstanding{}
+	if err := json.Unmarshal(buf, sharesOutstanding); err != nil {
+		return nil, err
+	}
+	return sharesOutstanding, nil
+}
+
+// SharesOutstanding fetches and returns the shares outstanding history for the specified company symbol.
+func (c *Client) SharesOutstanding(symbol string) (*SharesOutstanding, error) {
+	const function = "SHARES_OUTSTANDING"
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s", baseURL, function, symbol, c.apiKey)
+	body, err := c.makeHTTPRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	sharesOutstanding, err := toSharesOutstanding(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := c.checkResponse(function, body, sharesOutstanding); err != nil {
+		return nil, err
+	}
+	return sharesOutstanding, nil
+}
+
+// AsOf returns the most recent shares outstanding reported on or
+// before the given date (formatted as DateFormat), or nil if there is none.
+func (so *SharesOutstanding) AsOf(date string) *SharesOutstandingData {
+	var found *SharesOutstandingData
+	for i := range so.Data {
+		d := so.Data[i].Date.String()
+		if so.Data[i].Date.Value.IsZero() || d > date {
+			continue
+		}
+		if found == nil || d > found.Date.String() {
+			found = &so.Data[i]
+		}
+	}
+	return found
+}
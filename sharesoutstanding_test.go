@@ -0,0 +1,42 @@
+package alphavantage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToSharesOutstanding(t *testing.T) {
+	var buf = `
+{
+    "symbol": "STOCK1",
+    "status": "success",
+    "data": [
+        {
+            "date": "2025-06-30",
+            "shares_outstanding_diluted": "7466000000",
+            "shares_outstanding_basic": "7433000000"
+        },
+        {
+            "date": "2025-03-31",
+            "shares_outstanding_diluted": "7469000000",
+            "shares_outstanding_basic": "7434000000"
+        }
+    ]
+}
+`
+	sharesOutstanding, err := toSharesOutstanding([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "SHARES_OUTSTANDING", buf, sharesOutstanding)
+	assert.EqualStrings(t, "STOCK1", sharesOutstanding.Symbol)
+	assert.EqualInt(t, 2, len(sharesOutstanding.Data))
+	assert.EqualInt(t, 7466000000, sharesOutstanding.Data[0].SharesOutstandingDiluted.Value)
+	assert.EqualInt(t, 7433000000, sharesOutstanding.Data[0].SharesOutstandingBasic.Value)
+
+	asOf := sharesOutstanding.AsOf("2025-05-15")
+	if asOf == nil {
+		t.Fatal("shares outstanding as of 2025-05-15 are missing")
+	}
+	assert.EqualStrings(t, "2025-03-31", asOf.Date.String())
+	assert.True(t, sharesOutstanding.AsOf("2024-12-31") == nil)
+}
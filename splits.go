@@ -0,0 +1,57 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Split represents a single stock split.
+type Split struct {
+	EffectiveDate AVDate    `json:"effective_date"`
+	SplitFactor   AVFloat64 `json:"split_factor"`
+}
+
+// Splits represents the historical split events of a company.
+type Splits struct {
+	Symbol string  `json:"symbol"`
+	Data   []Split `json:"data"`
+}
+
+func toSplits(buf []byte) (*Splits, error) {
+	splits := &Splits{}
+	if err := json.Unmarshal(buf, splits); err != nil {
+		return nil, err
+	}
+	return splits, nil
+}
+
+// Splits fetches and returns the split history for the specified company symbol.
+func (c *Client) Splits(symbol string) (*Splits, error) {
+	const function = "SPLITS"
+	url := fmt.Sprintf("%s/query?function=%s&symbol=%s&apikey=%s", baseURL, function, symbol, c.apiKey)
+	body, err := c.makeHTTPRequest(url)
+	if err != nil {
+		return nil, err
+	}
+
+	splits, err := toSplits(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := c.checkResponse(function, body, splits); err != nil {
+		return nil, err
+	}
+	return splits, nil
+}
+
+// ByEffectiveDate returns the split factors indexed by effective date.
+func (s *Splits) ByEffectiveDate() map[string]float64 {
+	factors := make(map[string]float64)
+	for _, split := range s.Data {
+		if split.EffectiveDate.Value.IsZero() {
+			continue
+		}
+		factors[split.EffectiveDate.String()] = split.SplitFactor.Value
+	}
+	return factors
+}
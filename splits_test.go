@@ -0,0 +1,35 @@
+package alphavantage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToSplits(t *testing.T) {
+	var buf = `
+{
+    "symbol": "STOCK1",
+    "data": [
+        {
+            "effective_date": "2021-11-04",
+            "split_factor": "1.0460"
+        },
+        {
+            "effective_date": "1999-05-27",
+            "split_factor": "2.0000"
+        }
+    ]
+}
+`
+	splits, err := toSplits([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "SPLITS", buf, splits)
+	assert.EqualStrings(t, "STOCK1", splits.Symbol)
+	assert.EqualInt(t, 2, len(splits.Data))
+	assert.EqualStrings(t, "2021-11-04", splits.Data[0].EffectiveDate.String())
+	assert.EqualFloat64(t, 1.046, splits.Data[0].SplitFactor.Value)
+
+	byDate := splits.ByEffectiveDate()
+	assert.EqualFloat64(t, 2, byDate["1999-05-27"])
+}
@@ -0,0 +1,279 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/sklinkert/alphavantage"
+)
+
+const (
+	periodAnnual    = "annual"
+	periodQuarterly = "quarterly"
+)
+
+var (
+	companyOverviewTable = newRecordTable("company_overview", nil,
+		[]string{"Symbol"}, alphavantage.CompanyOverview{})
+	balanceSheetTable = newRecordTable("balance_sheet", []string{"symbol", "period"},
+		[]string{"symbol", "period", "fiscalDateEnding"}, alphavantage.BsAnnualReport{}, alphavantage.BsQuarterlyReport{})
+	incomeStatementTable = newRecordTable("income_statement", []string{"symbol", "period"},
+		[]string{"symbol", "period", "fiscalDateEnding"}, alphavantage.IsAnnualReport{}, alphavantage.IsQuarterlyReport{})
+	cashFlowTable = newRecordTable("cash_flow", []string{"symbol", "period"},
+		[]string{"symbol", "period", "fiscalDateEnding"}, alphavantage.CfAnnualReport{}, alphavantage.CfQuarterlyReport{})
+	annualEarningsTable = newRecordTable("earnings_annual", []string{"symbol"},
+		[]string{"symbol", "fiscalDateEnding"}, alphavantage.AnnualEarnings{})
+	quarterlyEarningsTable = newRecordTable("earnings_quarterly", []string{"symbol"},
+		[]string{"symbol", "fiscalDateEnding"}, alphavantage.QuarterlyEarnings{})
+)
+
+// orderByFiscalDate orders reports like the API, latest first
+const orderByFiscalDate = `ORDER BY "fiscalDateEnding" DESC`
+
+// SaveCompanyOverview stores the company overview.
+func (s *Store) SaveCompanyOverview(overview *alphavantage.CompanyOverview) error {
+	if overview.Symbol == "" {
+		return errors.New("company overview without symbol")
+	}
+	return s.inTx(func(tx *sql.Tx) error {
+		return companyOverviewTable.upsert(tx, nil, *overview)
+	})
+}
+
+// CompanyOverview reads the company overview of the symbol.
+func (s *Store) CompanyOverview(symbol string) (*alphavantage.CompanyOverview, error) {
+	var overview *alphavantage.CompanyOverview
+	err := companyOverviewTable.query(s.db, `WHERE "Symbol" = ?`, []interface{}{symbol}, func([]interface{}) interface{} {
+		overview = &alphavantage.CompanyOverview{}
+		return overview
+	})
+	if err != nil {
+		return nil, err
+	}
+	if overview == nil {
+		return nil, ErrNotFound
+	}
+	return overview, nil
+}
+
+// CompanyOverviews reads the company overviews of all symbols, ordered by
+// symbol, e.g. to screen them.
+func (s *Store) CompanyOverviews() ([]*alphavantage.CompanyOverview, error) {
+	var overviews []*alphavantage.CompanyOverview
+	err := companyOverviewTable.query(s.db, `ORDER BY "Symbol"`, nil, func([]interface{}) interface{} {
+		overview := &alphavantage.CompanyOverview{}
+		overviews = append(overviews, overview)
+		return overview
+	})
+	if err != nil {
+		return nil, err
+	}
+	return overviews, nil
+}
+
+// SaveBalanceSheet stores the annual and quarterly reports.
+func (s *Store) SaveBalanceSheet(balanceSheet *alphavantage.BalanceSheet) error {
+	if balanceSheet.Symbol == "" {
+		return errors.New("balance sheet without symbol")
+	}
+	return s.inTx(func(tx *sql.Tx) error {
+		for _, report := range balanceSheet.AnnualReports {
+			if err := balanceSheetTable.upsert(tx, []interface{}{balanceSheet.Symbol, periodAnnual}, report); err != nil {
+				return err
+			}
+		}
+		for _, report := range balanceSheet.QuarterlyReports {
+			if err := balanceSheetTable.upsert(tx, []interface{}{balanceSheet.Symbol, periodQuarterly}, report); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// BalanceSheet reads the reports of the symbol, latest first.
+func (s *Store) BalanceSheet(symbol string) (*alphavantage.BalanceSheet, error) {
+	balanceSheet := &alphavantage.BalanceSheet{Symbol: symbol}
+	var annual []*alphavantage.BsAnnualReport
+	var quarterly []*alphavantage.BsQuarterlyReport
+	err := balanceSheetTable.query(s.db, `WHERE "symbol" = ? `+orderByFiscalDate, []interface{}{symbol}, func(parents []interface{}) interface{} {
+		if isPeriod(parents[1], periodAnnual) {
+			annual = append(annual, &alphavantage.BsAnnualReport{})
+			return annual[len(annual)-1]
+		}
+		quarterly = append(quarterly, &alphavantage.BsQuarterlyReport{})
+		return quarterly[len(quarterly)-1]
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(annual)+len(quarterly) == 0 {
+		return nil, ErrNotFound
+	}
+	for _, report := range annual {
+		balanceSheet.AnnualReports = append(balanceSheet.AnnualReports, *report)
+	}
+	for _, report := range quarterly {
+		balanceSheet.QuarterlyReports = append(balanceSheet.QuarterlyReports, *report)
+	}
+	return balanceSheet, nil
+}
+
+// SaveIncomeStatement stores the annual and quarterly reports.
+func (s *Store) SaveIncomeStatement(incomeStatement *alphavantage.IncomeStatement) error {
+	if incomeStatement.Symbol == "" {
+		return errors.New("income statement without symbol")
+	}
+	return s.inTx(func(tx *sql.Tx) error {
+		for _, report := range incomeStatement.AnnualReports {
+			if err := incomeStatementTable.upsert(tx, []interface{}{incomeStatement.Symbol, periodAnnual}, report); err != nil {
+				return err
+			}
+		}
+		for _, report := range incomeStatement.QuarterlyReports {
+			if err := incomeStatementTable.upsert(tx, []interface{}{incomeStatement.Symbol, periodQuarterly}, report); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// IncomeStatement reads the reports of the symbol, latest first.
+func (s *Store) IncomeStatement(symbol string) (*alphavantage.IncomeStatement, error) {
+	incomeStatement := &alphavantage.IncomeStatement{Symbol: symbol}
+	var annual []*alphavantage.IsAnnualReport
+	var quarterly []*alphavantage.IsQuarterlyReport
+	err := incomeStatementTable.query(s.db, `WHERE "symbol" = ? `+orderByFiscalDate, []interface{}{symbol}, func(parents []interface{}) interface{} {
+		if isPeriod(parents[1], periodAnnual) {
+			annual = append(annual, &alphavantage.IsAnnualReport{})
+			return annual[len(annual)-1]
+		}
+		quarterly = append(quarterly, &alphavantage.IsQuarterlyReport{})
+		return quarterly[len(quarterly)-1]
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(annual)+len(quarterly) == 0 {
+		return nil, ErrNotFound
+	}
+	for _, report := range annual {
+		incomeStatement.AnnualReports = append(incomeStatement.AnnualReports, *report)
+	}
+	for _, report := range quarterly {
+		incomeStatement.QuarterlyReports = append(incomeStatement.QuarterlyReports, *report)
+	}
+	return incomeStatement, nil
+}
+
+// SaveCashFlow stores the annual and quarterly reports.
+func (s *Store) SaveCashFlow(cashFlow *alphavantage.CashFlow) error {
+	if cashFlow.Symbol == "" {
+		return errors.New("cash flow without symbol")
+	}
+	return s.inTx(func(tx *sql.Tx) error {
+		for _, report := range cashFlow.AnnualReports {
+			if err := cashFlowTable.upsert(tx, []interface{}{cashFlow.Symbol, periodAnnual}, report); err != nil {
+				return err
+			}
+		}
+		for _, report := range cashFlow.QuarterlyReports {
+			if err := cashFlowTable.upsert(tx, []interface{}{cashFlow.Symbol, periodQuarterly}, report); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CashFlow reads the reports of the symbol, latest first.
+func (s *Store) CashFlow(symbol string) (*alphavantage.CashFlow, error) {
+	cashFlow := &alphavantage.CashFlow{Symbol: symbol}
+	var annual []*alphavantage.CfAnnualReport
+	var quarterly []*alphavantage.CfQuarterlyReport
+	err := cashFlowTable.query(s.db, `WHERE "symbol" = ? `+orderByFiscalDate, []interface{}{symbol}, func(parents []interface{}) interface{} {
+		if isPeriod(parents[1], periodAnnual) {
+			annual = append(annual, &alphavantage.CfAnnualReport{})
+			return annual[len(annual)-1]
+		}
+		quarterly = append(quarterly, &alphavantage.CfQuarterlyReport{})
+		return quarterly[len(quarterly)-1]
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(annual)+len(quarterly) == 0 {
+		return nil, ErrNotFound
+	}
+	for _, report := range annual {
+		cashFlow.AnnualReports = append(cashFlow.AnnualReports, *report)
+	}
+	for _, report := range quarterly {
+		cashFlow.QuarterlyReports = append(cashFlow.QuarterlyReports, *report)
+	}
+	return cashFlow, nil
+}
+
+// SaveEarnings stores the annual and quarterly earnings.
+func (s *Store) SaveEarnings(earnings *alphavantage.Earnings) error {
+	if earnings.Symbol == "" {
+		return errors.New("earnings without symbol")
+	}
+	return s.inTx(func(tx *sql.Tx) error {
+		for _, item := range earnings.AnnualEarnings {
+			if err := annualEarningsTable.upsert(tx, []interface{}{earnings.Symbol}, item); err != nil {
+				return err
+			}
+		}
+		for _, item := range earnings.QuarterlyEarnings {
+			if err := quarterlyEarningsTable.upsert(tx, []interface{}{earnings.Symbol}, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Earnings reads the earnings of the symbol, latest first.
+func (s *Store) Earnings(symbol string) (*alphavantage.Earnings, error) {
+	earnings := &alphavantage.Earnings{Symbol: symbol}
+	var annual []*alphavantage.AnnualEarnings
+	err := annualEarningsTable.query(s.db, `WHERE "symbol" = ? `+orderByFiscalDate, []interface{}{symbol}, func([]interface{}) interface{} {
+		annual = append(annual, &alphavantage.AnnualEarnings{})
+		return annual[len(annual)-1]
+	})
+	if err != nil {
+		return nil, err
+	}
+	var quarterly []*alphavantage.QuarterlyEarnings
+	err = quarterlyEarningsTable.query(s.db, `WHERE "symbol" = ? `+orderByFiscalDate, []interface{}{symbol}, func([]interface{}) interface{} {
+		quarterly = append(quarterly, &alphavantage.QuarterlyEarnings{})
+		return quarterly[len(quarterly)-1]
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(annual)+len(quarterly) == 0 {
+		return nil, ErrNotFound
+	}
+	for _, item := range annual {
+		earnings.AnnualEarnings = append(earnings.AnnualEarnings, *item)
+	}
+	for _, item := range quarterly {
+		earnings.QuarterlyEarnings = append(earnings.QuarterlyEarnings, *item)
+	}
+	return earnings, nil
+}
+
+// isPeriod compares a period column value which drivers return as
+// string or []byte.
+func isPeriod(value interface{}, period string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == period
+	case []byte:
+		return string(v) == period
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+	"github.com/sklinkert/alphavantage"
+)
+
+func TestCompanyOverviewRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+	overview := &alphavantage.CompanyOverview{
+		Symbol:               "IBM",
+		Name:                 "International Business Machines",
+		Sector:               "TECHNOLOGY",
+		FiscalYearEnd:        "December",
+		MarketCapitalization: alphavantage.AVInt{Value: 176297582000},
+		PERatio:              alphavantage.AVFloat64{Value: 22.35},
+		DividendYield:        alphavantage.NewAVFloat64(0),
+	}
+	assert.NoError(t.Fatalf, store.SaveCompanyOverview(overview))
+
+	overview.PERatio = alphavantage.AVFloat64{Value: 21.9}
+	assert.NoError(t.Fatalf, store.SaveCompanyOverview(overview))
+
+	read, err := store.CompanyOverview("IBM")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "International Business Machines", read.Name)
+	assert.EqualStrings(t, "December", read.FiscalYearEnd)
+	assert.EqualInt(t, 176297582000, read.MarketCapitalization.Value)
+	assert.EqualFloat64(t, 21.9, read.PERatio.Value)
+	assert.True(t, read.PEGRatio.IsNull())
+	// a genuine 0 isn't "None"
+	assert.False(t, read.DividendYield.IsNull())
+
+	// "None" is stored as NULL
+	var pegRatio interface{}
+	err = store.DB().QueryRow(`SELECT "PEGRatio" FROM company_overview WHERE "Symbol" = 'IBM'`).Scan(&pegRatio)
+	assert.NoError(t.Fatalf, err)
+	assert.True(t, pegRatio == nil)
+
+	_, err = store.CompanyOverview("MSFT")
+	assert.EqualErrors(t, ErrNotFound, err)
+
+	assert.NoError(t.Fatalf, store.SaveCompanyOverview(&alphavantage.CompanyOverview{Symbol: "AAPL", Sector: "TECHNOLOGY"}))
+	overviews, err := store.CompanyOverviews()
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 2, len(overviews))
+	assert.EqualStrings(t, "AAPL", overviews[0].Symbol)
+	assert.EqualStrings(t, "IBM", overviews[1].Symbol)
+	assert.EqualFloat64(t, 21.9, overviews[1].PERatio.Value)
+}
+
+func TestStatementsRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+	balanceSheet := &alphavantage.BalanceSheet{
+		Symbol: "IBM",
+		AnnualReports: []alphavantage.BsAnnualReport{
+			{FiscalDateEnding: "2022-12-31", ReportedCurrency: "USD", TotalAssets: alphavantage.AVInt{Value: 127243000000}},
+			{FiscalDateEnding: "2023-12-31", ReportedCurrency: "USD", TotalAssets: alphavantage.AVInt{Value: 135241000000}},
+		},
+		QuarterlyReports: []alphavantage.BsQuarterlyReport{
+			{FiscalDateEnding: "2023-12-31", ReportedCurrency: "USD", TotalAssets: alphavantage.AVInt{Value: 135241000000}},
+		},
+	}
+	assert.NoError(t.Fatalf, store.SaveBalanceSheet(balanceSheet))
+	incomeStatement := &alphavantage.IncomeStatement{
+		Symbol: "IBM",
+		QuarterlyReports: []alphavantage.IsQuarterlyReport{
+			{FiscalDateEnding: "2023-12-31", TotalRevenue: alphavantage.AVInt{Value: 17381000000}},
+		},
+	}
+	assert.NoError(t.Fatalf, store.SaveIncomeStatement(incomeStatement))
+	cashFlow := &alphavantage.CashFlow{
+		Symbol: "IBM",
+		AnnualReports: []alphavantage.CfAnnualReport{
+			{FiscalDateEnding: "2023-12-31", OperatingCashflow: alphavantage.AVInt{Value: 13931000000}},
+		},
+	}
+	assert.NoError(t.Fatalf, store.SaveCashFlow(cashFlow))
+
+	readBalanceSheet, err := store.BalanceSheet("IBM")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 2, len(readBalanceSheet.AnnualReports))
+	assert.EqualInt(t, 1, len(readBalanceSheet.QuarterlyReports))
+	assert.EqualStrings(t, "2023-12-31", readBalanceSheet.AnnualReports[0].FiscalDateEnding)
+	assert.EqualInt(t, 135241000000, readBalanceSheet.AnnualReports[0].TotalAssets.Value)
+	assert.EqualStrings(t, "USD", readBalanceSheet.QuarterlyReports[0].ReportedCurrency)
+
+	readIncomeStatement, err := store.IncomeStatement("IBM")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 0, len(readIncomeStatement.AnnualReports))
+	assert.EqualInt(t, 17381000000, readIncomeStatement.QuarterlyReports[0].TotalRevenue.Value)
+
+	readCashFlow, err := store.CashFlow("IBM")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 13931000000, readCashFlow.AnnualReports[0].OperatingCashflow.Value)
+
+	_, err = store.CashFlow("MSFT")
+	assert.EqualErrors(t, ErrNotFound, err)
+}
+
+func TestEarningsRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+	earnings := &alphavantage.Earnings{
+		Symbol: "IBM",
+		AnnualEarnings: []alphavantage.AnnualEarnings{
+			{FiscalDateEnding: "2023-12-31", ReportedEPS: alphavantage.AVFloat64{Value: 9.61}},
+		},
+		QuarterlyEarnings: []alphavantage.QuarterlyEarnings{
+			{FiscalDateEnding: "2023-09-30", ReportedEPS: alphavantage.AVFloat64{Value: 2.2}, Surprise: alphavantage.AVFloat64{Value: 0.08}},
+			{FiscalDateEnding: "2023-12-31", ReportedEPS: alphavantage.AVFloat64{Value: 3.87}, SurprisePercentage: alphavantage.AVFloat64{Value: 5.1771}},
+		},
+	}
+	assert.NoError(t.Fatalf, store.SaveEarnings(earnings))
+
+	read, err := store.Earnings("IBM")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 1, len(read.AnnualEarnings))
+	assert.EqualFloat64(t, 9.61, read.AnnualEarnings[0].ReportedEPS.Value)
+	assert.EqualInt(t, 2, len(read.QuarterlyEarnings))
+	assert.EqualStrings(t, "2023-12-31", read.QuarterlyEarnings[0].FiscalDateEnding)
+	assert.EqualFloat64(t, 5.1771, read.QuarterlyEarnings[0].SurprisePercentage.Value)
+	assert.EqualFloat64(t, 0, read.QuarterlyEarnings[0].Surprise.Value)
+	assert.EqualFloat64(t, 0.08, read.QuarterlyEarnings[1].Surprise.Value)
+}
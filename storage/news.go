@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/sklinkert/alphavantage"
+)
+
+var (
+	newsFeedTable = newRecordTable("news_feed", nil,
+		[]string{"url"}, alphavantage.FeedItem{})
+	newsTopicsTable = newRecordTable("news_topics", []string{"url"},
+		[]string{"url", "topic"}, alphavantage.Topic{})
+	newsTickerSentimentTable = newRecordTable("news_ticker_sentiment", []string{"url"},
+		[]string{"url", "ticker"}, alphavantage.TickerSentiment{})
+)
+
+// SaveNewsSentiment stores the feed items with their authors, topics
+// and ticker sentiments.  Items are identified by their URL.
+func (s *Store) SaveNewsSentiment(news *alphavantage.NewsSentiment) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		for _, item := range news.Feed {
+			if item.URL == "" {
+				return fmt.Errorf("feed item without url: %s", item.Title)
+			}
+			if err := newsFeedTable.upsert(tx, nil, item); err != nil {
+				return err
+			}
+			// the lists of an item are replaced as a whole
+			for _, table := range []string{"news_authors", "news_topics", "news_ticker_sentiment"} {
+				if _, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE url = ?`, table), item.URL); err != nil {
+					return err
+				}
+			}
+			for i, author := range item.Authors {
+				if _, err := tx.Exec(`INSERT INTO news_authors (url, position, author) VALUES (?, ?, ?)`, item.URL, i, author); err != nil {
+					return err
+				}
+			}
+			for _, topic := range item.Topics {
+				if err := newsTopicsTable.upsert(tx, []interface{}{item.URL}, topic); err != nil {
+					return err
+				}
+			}
+			for _, sentiment := range item.TickerSentiment {
+				if err := newsTickerSentimentTable.upsert(tx, []interface{}{item.URL}, sentiment); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// NewsSentiment reads the stored feed items, latest first.  If ticker
+// isn't empty only items with a sentiment for the ticker are returned.
+func (s *Store) NewsSentiment(ticker string) (*alphavantage.NewsSentiment, error) {
+	where := `ORDER BY "time_published" DESC`
+	var args []interface{}
+	if ticker != "" {
+		where = `WHERE "url" IN (SELECT url FROM news_ticker_sentiment WHERE ticker = ?) ` + where
+		args = append(args, ticker)
+	}
+	var items []*alphavantage.FeedItem
+	err := newsFeedTable.query(s.db, where, args, func([]interface{}) interface{} {
+		items = append(items, &alphavantage.FeedItem{})
+		return items[len(items)-1]
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byURL := make(map[string]*alphavantage.FeedItem, len(items))
+	for _, item := range items {
+		byURL[item.URL] = item
+	}
+	if err := s.readNewsAuthors(byURL); err != nil {
+		return nil, err
+	}
+	err = newsTopicsTable.query(s.db, `ORDER BY "url", "relevance_score" DESC`, nil, func(parents []interface{}) interface{} {
+		item, ok := byURL[parentString(parents[0])]
+		if !ok {
+			return &alphavantage.Topic{}
+		}
+		item.Topics = append(item.Topics, alphavantage.Topic{})
+		return &item.Topics[len(item.Topics)-1]
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = newsTickerSentimentTable.query(s.db, `ORDER BY "url", "relevance_score" DESC`, nil, func(parents []interface{}) interface{} {
+		item, ok := byURL[parentString(parents[0])]
+		if !ok {
+			return &alphavantage.TickerSentiment{}
+		}
+		item.TickerSentiment = append(item.TickerSentiment, alphavantage.TickerSentiment{})
+		return &item.TickerSentiment[len(item.TickerSentiment)-1]
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	news := &alphavantage.NewsSentiment{Items: strconv.Itoa(len(items))}
+	for _, item := range items {
+		news.Feed = append(news.Feed, *item)
+	}
+	return news, nil
+}
+
+func (s *Store) readNewsAuthors(byURL map[string]*alphavantage.FeedItem) error {
+	rows, err := s.db.Query(`SELECT url, author FROM news_authors ORDER BY url, position`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var url, author string
+		if err := rows.Scan(&url, &author); err != nil {
+			return err
+		}
+		if item, ok := byURL[url]; ok {
+			item.Authors = append(item.Authors, author)
+		}
+	}
+	return rows.Err()
+}
+
+func parentString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+	"github.com/sklinkert/alphavantage"
+)
+
+func TestNewsSentimentRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+	news := &alphavantage.NewsSentiment{
+		Items: "2",
+		Feed: []alphavantage.FeedItem{
+			{
+				Title:                 "IBM beats estimates",
+				URL:                   "https://example.com/ibm",
+				TimePublished:         "20240125T101500",
+				Authors:               []string{"Jane Doe", "John Roe"},
+				Topics:                []alphavantage.Topic{{Topic: "Earnings", RelevanceScore: 0.999}},
+				OverallSentimentScore: alphavantage.AVFloat64{Value: 0.25},
+				OverallSentimentLabel: "Somewhat-Bullish",
+				TickerSentiment: []alphavantage.TickerSentiment{
+					{Ticker: "IBM", RelevanceScore: 0.9, TickerSentimentScore: 0.3, TickerSentimentLabel: "Somewhat-Bullish"},
+				},
+			},
+			{
+				Title:         "Markets wrap",
+				URL:           "https://example.com/wrap",
+				TimePublished: "20240124T200000",
+				TickerSentiment: []alphavantage.TickerSentiment{
+					{Ticker: "MSFT", RelevanceScore: 0.2, TickerSentimentScore: -0.1, TickerSentimentLabel: "Neutral"},
+				},
+			},
+		},
+	}
+	assert.NoError(t.Fatalf, store.SaveNewsSentiment(news))
+
+	// saving again replaces the lists of the items
+	news.Feed[0].Authors = []string{"Jane Doe"}
+	assert.NoError(t.Fatalf, store.SaveNewsSentiment(news))
+
+	read, err := store.NewsSentiment("")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "2", read.Items)
+	assert.EqualStrings(t, "https://example.com/ibm", read.Feed[0].URL)
+	assert.EqualInt(t, 1, len(read.Feed[0].Authors))
+	assert.EqualStrings(t, "Earnings", read.Feed[0].Topics[0].Topic)
+	assert.EqualFloat64(t, 0.999, read.Feed[0].Topics[0].RelevanceScore)
+	assert.EqualFloat64(t, 0.25, read.Feed[0].OverallSentimentScore.Value)
+	assert.EqualFloat64(t, 0.3, read.Feed[0].TickerSentiment[0].TickerSentimentScore)
+	assert.EqualFloat64(t, -0.1, read.Feed[1].TickerSentiment[0].TickerSentimentScore)
+
+	read, err = store.NewsSentiment("MSFT")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "1", read.Items)
+	assert.EqualStrings(t, "Markets wrap", read.Feed[0].Title)
+}
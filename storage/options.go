@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sklinkert/alphavantage"
+)
+
+var optionContractsTable = newRecordTable("option_contracts", nil,
+	[]string{"contractID", "date"}, alphavantage.OptionContract{})
+
+// SaveHistoricalOptions stores the option contracts of the chain.
+// Contracts are identified by their contract ID and date.
+func (s *Store) SaveHistoricalOptions(options *alphavantage.HistoricalOptionsData) error {
+	return s.inTx(func(tx *sql.Tx) error {
+		for _, contract := range options.Data {
+			if contract.ContractID == "" || contract.Date == "" {
+				return fmt.Errorf("option contract without contract id or date: %s", contract.Symbol)
+			}
+			if err := optionContractsTable.upsert(tx, nil, contract); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// HistoricalOptions reads the option chain of the symbol at the date.
+// If date is empty the latest stored chain is returned.
+func (s *Store) HistoricalOptions(symbol, date string) (*alphavantage.HistoricalOptionsData, error) {
+	if date == "" {
+		var latest sql.NullString
+		err := s.db.QueryRow(`SELECT MAX("date") FROM option_contracts WHERE "symbol" = ?`, symbol).Scan(&latest)
+		if err != nil {
+			return nil, err
+		}
+		if !latest.Valid {
+			return nil, ErrNotFound
+		}
+		date = latest.String
+	}
+	var contracts []*alphavantage.OptionContract
+	err := optionContractsTable.query(s.db, `WHERE "symbol" = ? AND "date" = ? ORDER BY "expiration", "strike", "type"`,
+		[]interface{}{symbol, date}, func([]interface{}) interface{} {
+			contracts = append(contracts, &alphavantage.OptionContract{})
+			return contracts[len(contracts)-1]
+		})
+	if err != nil {
+		return nil, err
+	}
+	if len(contracts) == 0 {
+		return nil, ErrNotFound
+	}
+	options := &alphavantage.HistoricalOptionsData{Symbol: symbol}
+	for _, contract := range contracts {
+		options.Data = append(options.Data, *contract)
+	}
+	return options, nil
+}
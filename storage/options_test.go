@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+	"github.com/sklinkert/alphavantage"
+)
+
+func TestHistoricalOptionsRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+	contract := func(id, date string, strike, bid float64) alphavantage.OptionContract {
+		return alphavantage.OptionContract{
+			ContractID: id, Symbol: "IBM", Expiration: "2024-02-16", Type: "call", Date: date,
+			Strike: alphavantage.AVFloat64{Value: strike}, Bid: alphavantage.AVFloat64{Value: bid},
+			OpenInterest: alphavantage.AVInt{Value: 120},
+		}
+	}
+	options := &alphavantage.HistoricalOptionsData{
+		Symbol: "IBM",
+		Data: []alphavantage.OptionContract{
+			contract("IBM240216C00170000", "2024-01-02", 170, 1.2),
+			contract("IBM240216C00160000", "2024-01-02", 160, 4.1),
+			contract("IBM240216C00160000", "2024-01-03", 160, 3.9),
+		},
+	}
+	assert.NoError(t.Fatalf, store.SaveHistoricalOptions(options))
+
+	read, err := store.HistoricalOptions("IBM", "2024-01-02")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 2, len(read.Data))
+	assert.EqualFloat64(t, 160, read.Data[0].Strike.Value)
+	assert.EqualFloat64(t, 4.1, read.Data[0].Bid.Value)
+	assert.EqualInt(t, 120, read.Data[0].OpenInterest.Value)
+	assert.EqualFloat64(t, 0, read.Data[0].Ask.Value)
+
+	latest, err := store.HistoricalOptions("IBM", "")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 1, len(latest.Data))
+	assert.EqualStrings(t, "2024-01-03", latest.Data[0].Date)
+
+	_, err = store.HistoricalOptions("MSFT", "")
+	assert.EqualErrors(t, ErrNotFound, err)
+}
@@ -0,0 +1,371 @@
+package storage
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sklinkert/alphavantage"
+)
+
+// Flat structs like CompanyOverview or the statement reports are
+// stored in record tables with a column per JSON field.  Values are
+// converted with the JSON (un)marshallers of the alphavantage package,
+// so "None" is stored as NULL and numeric fields get NUMERIC affinity.
+
+// recordTables are created by migrateInitialSchema, fields added to the
+// structs later are added as columns on startup.
+var recordTables = []*recordTable{
+	companyOverviewTable,
+	balanceSheetTable,
+	incomeStatementTable,
+	cashFlowTable,
+	annualEarningsTable,
+	quarterlyEarningsTable,
+	newsFeedTable,
+	newsTopicsTable,
+	newsTickerSentimentTable,
+	optionContractsTable,
+}
+
+// column is a JSON field of a struct
+type column struct {
+	name    string
+	index   int
+	numeric bool
+	// quoted is true if the JSON value of the field is a string
+	quoted bool
+}
+
+// recordTable stores structs of one or more types with the same fields.
+// parents are the key columns which aren't fields of the structs, e.g.
+// the symbol of a statement report.
+type recordTable struct {
+	name       string
+	parents    []string
+	primaryKey []string
+	columns    []column
+}
+
+var (
+	numericTypes = map[reflect.Type]bool{
+		reflect.TypeOf(alphavantage.AVFloat64{}): true,
+		reflect.TypeOf(alphavantage.AVInt{}):     true,
+		reflect.TypeOf(alphavantage.AVPercent{}): true,
+	}
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	columnsCache  sync.Map
+)
+
+func newRecordTable(name string, parents []string, primaryKey []string, samples ...interface{}) *recordTable {
+	table := &recordTable{name: name, parents: parents, primaryKey: primaryKey}
+	seen := make(map[string]bool)
+	for _, parent := range parents {
+		seen[strings.ToLower(parent)] = true
+	}
+	for _, sample := range samples {
+		for _, col := range columnsOf(reflect.TypeOf(sample)) {
+			if seen[strings.ToLower(col.name)] {
+				continue
+			}
+			seen[strings.ToLower(col.name)] = true
+			table.columns = append(table.columns, col)
+		}
+	}
+	return table
+}
+
+// columnsOf returns the columns of the JSON fields of the struct type.
+// Slices, maps and nested structs are skipped, they are stored in
+// separate tables.
+func columnsOf(t reflect.Type) []column {
+	if cached, ok := columnsCache.Load(t); ok {
+		return cached.([]column)
+	}
+	var columns []column
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		if name == "" {
+			name = field.Name
+		}
+		col := column{name: name, index: i}
+		switch field.Type.Kind() {
+		case reflect.String:
+			col.quoted = true
+		case reflect.Float32, reflect.Float64,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			col.numeric = true
+			for _, option := range parts[1:] {
+				col.quoted = col.quoted || option == "string"
+			}
+		case reflect.Struct:
+			if !field.Type.Implements(marshalerType) {
+				continue
+			}
+			zero, err := json.Marshal(reflect.Zero(field.Type).Interface())
+			if err != nil {
+				continue
+			}
+			col.quoted = len(zero) > 0 && zero[0] == '"'
+			col.numeric = numericTypes[field.Type]
+		default:
+			continue
+		}
+		columns = append(columns, col)
+	}
+	columnsCache.Store(t, columns)
+	return columns
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (t *recordTable) columnNames() []string {
+	names := append([]string{}, t.parents...)
+	for _, col := range t.columns {
+		names = append(names, col.name)
+	}
+	return names
+}
+
+func (t *recordTable) columnDefinition(col column) string {
+	if col.numeric {
+		return quoteIdentifier(col.name) + " NUMERIC"
+	}
+	return quoteIdentifier(col.name) + " TEXT"
+}
+
+func (t *recordTable) createStatement() string {
+	var definitions []string
+	for _, parent := range t.parents {
+		definitions = append(definitions, quoteIdentifier(parent)+" TEXT NOT NULL")
+	}
+	for _, col := range t.columns {
+		definitions = append(definitions, t.columnDefinition(col))
+	}
+	keys := make([]string, len(t.primaryKey))
+	for i, key := range t.primaryKey {
+		keys[i] = quoteIdentifier(key)
+	}
+	definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(keys, ", ")))
+	return fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)", quoteIdentifier(t.name), strings.Join(definitions, ",\n\t"))
+}
+
+// addMissingColumns adds the columns of fields which don't exist in the
+// table yet.
+func (t *recordTable) addMissingColumns(tx *sql.Tx) error {
+	rows, err := tx.Query(fmt.Sprintf("PRAGMA table_info(%s)", quoteIdentifier(t.name)))
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			columnType string
+			notNull    int
+			dflt       sql.NullString
+			primaryKey int
+		)
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &dflt, &primaryKey); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[strings.ToLower(name)] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, col := range t.columns {
+		if existing[strings.ToLower(col.name)] {
+			continue
+		}
+		if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", quoteIdentifier(t.name), t.columnDefinition(col))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upsert inserts the record or updates the row with the same primary key.
+func (t *recordTable) upsert(tx *sql.Tx, parents []interface{}, record interface{}) error {
+	values, err := t.encode(record)
+	if err != nil {
+		return err
+	}
+	names := t.columnNames()
+	quoted := make([]string, len(names))
+	placeholders := make([]string, len(names))
+	var updates []string
+	isKey := make(map[string]bool)
+	for _, key := range t.primaryKey {
+		isKey[key] = true
+	}
+	for i, name := range names {
+		quoted[i] = quoteIdentifier(name)
+		placeholders[i] = "?"
+		if !isKey[name] {
+			updates = append(updates, fmt.Sprintf("%s = excluded.%s", quoted[i], quoted[i]))
+		}
+	}
+	keys := make([]string, len(t.primaryKey))
+	for i, key := range t.primaryKey {
+		keys[i] = quoteIdentifier(key)
+	}
+	statement := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO ",
+		quoteIdentifier(t.name), strings.Join(quoted, ", "), strings.Join(placeholders, ", "), strings.Join(keys, ", "))
+	if len(updates) == 0 {
+		statement += "NOTHING"
+	} else {
+		statement += "UPDATE SET " + strings.Join(updates, ", ")
+	}
+	args := append(append([]interface{}{}, parents...), values...)
+	_, err = tx.Exec(statement, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", t.name, err)
+	}
+	return nil
+}
+
+// encode returns the column values of the record in the order of the
+// table columns.  Columns the record doesn't have are NULL.
+func (t *recordTable) encode(record interface{}) ([]interface{}, error) {
+	v := reflect.ValueOf(record)
+	byName := make(map[string]column)
+	for _, col := range columnsOf(v.Type()) {
+		byName[col.name] = col
+	}
+	values := make([]interface{}, len(t.columns))
+	for i, tableCol := range t.columns {
+		col, ok := byName[tableCol.name]
+		if !ok {
+			continue
+		}
+		value, err := encodeValue(v.Field(col.index), col)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.name, col.name, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func encodeValue(field reflect.Value, col column) (interface{}, error) {
+	buf, err := json.Marshal(field.Interface())
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	switch v := value.(type) {
+	case string:
+		if v == "None" {
+			return nil, nil
+		}
+		if col.numeric {
+			return strings.TrimSuffix(v, "%"), nil
+		}
+		return v, nil
+	case json.Number:
+		return string(v), nil
+	}
+	return value, nil
+}
+
+// query reads the rows selected by where into records created by newRecord.
+func (t *recordTable) query(tx queryer, where string, args []interface{}, newRecord func(row []interface{}) interface{}) error {
+	names := t.columnNames()
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quoteIdentifier(name)
+	}
+	rows, err := tx.Query(fmt.Sprintf("SELECT %s FROM %s %s", strings.Join(quoted, ", "), quoteIdentifier(t.name), where), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		values := make([]interface{}, len(names))
+		pointers := make([]interface{}, len(names))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+		record := newRecord(values[:len(t.parents)])
+		if err := t.decode(values[len(t.parents):], record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// decode sets the fields of record (a pointer to a struct) from the
+// column values.
+func (t *recordTable) decode(values []interface{}, record interface{}) error {
+	object := make(map[string]json.RawMessage, len(t.columns))
+	for i, col := range t.columns {
+		raw, err := decodeValue(values[i], col)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.name, col.name, err)
+		}
+		object[col.name] = raw
+	}
+	buf, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(buf, record); err != nil {
+		return fmt.Errorf("%s: %w", t.name, err)
+	}
+	return nil
+}
+
+func decodeValue(value interface{}, col column) (json.RawMessage, error) {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		if col.quoted {
+			return json.RawMessage(`"None"`), nil
+		}
+		return json.RawMessage("null"), nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return nil, fmt.Errorf("unexpected value type: %T", v)
+	}
+	if col.quoted {
+		return json.Marshal(s)
+	}
+	return json.RawMessage(s), nil
+}
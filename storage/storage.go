@@ -0,0 +1,205 @@
+// Package storage persists the datasets fetched with the alphavantage
+// package in a normalised SQLite schema, so they can be queried with
+// SQL and read back into the structs of the alphavantage package.
+//
+// The database is accessed with the pure-Go driver modernc.org/sqlite,
+// no cgo is required:
+//
+//	store, err := storage.Open("alphavantage.db")
+//
+// Other SQLite drivers can be used with New.
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// DriverName is the database/sql driver used by Open.
+const DriverName = "sqlite"
+
+// ErrNotFound is returned by the readers if nothing is stored for the
+// requested key.
+var ErrNotFound = errors.New("storage: not found")
+
+// Store writes and reads alphavantage datasets.  Writes are upserts,
+// i.e. storing a dataset again updates the existing rows.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the SQLite database at path with DriverName and migrates
+// it to the latest schema.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open(DriverName, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	store, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// New creates a store on an open SQLite database and migrates it to the
+// latest schema.
+func New(db *sql.DB) (*Store, error) {
+	store := &Store{db: db}
+	if err := store.migrate(); err != nil {
+		return nil, fmt.Errorf("failed to migrate schema: %w", err)
+	}
+	return store, nil
+}
+
+// DB returns the underlying database, e.g. to run custom queries.
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// migration changes the schema from the previous version
+type migration func(tx *sql.Tx) error
+
+// migrations are applied in order, the version of a migration is its
+// index + 1.  Released migrations must not be changed.
+var migrations = []migration{
+	migrateInitialSchema,
+	migrateTimeSeriesMetadata,
+}
+
+// SchemaVersion returns the version of the latest applied migration.
+func (s *Store) SchemaVersion() (int, error) {
+	var version sql.NullInt64
+	err := s.db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
+
+func (s *Store) migrate() error {
+	_, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL
+	)`)
+	if err != nil {
+		return err
+	}
+	current, err := s.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	for i := current; i < len(migrations); i++ {
+		if err := s.inTx(func(tx *sql.Tx) error {
+			if err := migrations[i](tx); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`,
+				i+1, time.Now().UTC().Format(time.RFC3339))
+			return err
+		}); err != nil {
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+	}
+	// fields added to the structs after a release are added as columns
+	return s.inTx(func(tx *sql.Tx) error {
+		for _, table := range recordTables {
+			if err := table.addMissingColumns(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func migrateInitialSchema(tx *sql.Tx) error {
+	statements := []string{
+		`CREATE TABLE time_series_metadata (
+			symbol         TEXT NOT NULL,
+			adjusted       INTEGER NOT NULL,
+			information    TEXT,
+			last_refreshed TEXT,
+			time_zone      TEXT,
+			PRIMARY KEY (symbol, adjusted)
+		)`,
+		`CREATE TABLE time_series (
+			symbol   TEXT NOT NULL,
+			interval TEXT NOT NULL,
+			date     TEXT NOT NULL,
+			open     REAL,
+			high     REAL,
+			low      REAL,
+			close    REAL,
+			volume   INTEGER,
+			PRIMARY KEY (symbol, interval, date)
+		)`,
+		`CREATE TABLE time_series_adjusted (
+			symbol            TEXT NOT NULL,
+			interval          TEXT NOT NULL,
+			date              TEXT NOT NULL,
+			open              REAL,
+			high              REAL,
+			low               REAL,
+			close             REAL,
+			adjusted_close    REAL,
+			volume            INTEGER,
+			dividend_amount   REAL,
+			split_coefficient REAL,
+			PRIMARY KEY (symbol, interval, date)
+		)`,
+		`CREATE TABLE news_authors (
+			url      TEXT NOT NULL,
+			position INTEGER NOT NULL,
+			author   TEXT NOT NULL,
+			PRIMARY KEY (url, position)
+		)`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	for _, table := range recordTables {
+		if _, err := tx.Exec(table.createStatement()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateTimeSeriesMetadata adds the output size and the time zone of
+// daily series, whose metadata has it under another key.
+func migrateTimeSeriesMetadata(tx *sql.Tx) error {
+	for _, statement := range []string{
+		`ALTER TABLE time_series_metadata ADD COLUMN output_size TEXT`,
+		`ALTER TABLE time_series_metadata ADD COLUMN daily_time_zone TEXT`,
+	} {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inTx runs fn in a transaction which is committed if fn succeeds.
+func (s *Store) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
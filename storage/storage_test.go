@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+// newTestStore opens a store in a temporary database file.
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	assert.NoError(t.Fatalf, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestNewMigratesSchema(t *testing.T) {
+	store := newTestStore(t)
+
+	version, err := store.SchemaVersion()
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, len(migrations), version)
+
+	// migrating again is a no-op
+	_, err = New(store.DB())
+	assert.NoError(t.Fatalf, err)
+	var count int
+	err = store.DB().QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&count)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, len(migrations), count)
+}
+
+func TestNewAddsMissingColumns(t *testing.T) {
+	store := newTestStore(t)
+
+	_, err := store.DB().Exec(`ALTER TABLE company_overview DROP COLUMN "EBITDA"`)
+	assert.NoError(t.Fatalf, err)
+	_, err = New(store.DB())
+	assert.NoError(t.Fatalf, err)
+
+	var count int
+	err = store.DB().QueryRow(`SELECT COUNT(*) FROM pragma_table_info('company_overview') WHERE name = 'EBITDA'`).Scan(&count)
+	assert.NoError(t.Fatalf, err)
+	assert.EqualInt(t, 1, count)
+}
@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sklinkert/alphavantage"
+)
+
+const (
+	intervalDaily   = "daily"
+	intervalWeekly  = "weekly"
+	intervalMonthly = "monthly"
+)
+
+// SaveTimeSeries stores all data points of the time series.
+func (s *Store) SaveTimeSeries(ts *alphavantage.TimeSeries) error {
+	symbol := ts.Metadata.Symbol
+	if symbol == "" {
+		return errors.New("time series without symbol")
+	}
+	return s.inTx(func(tx *sql.Tx) error {
+		if err := saveTimeSeriesMetadata(tx, ts.Metadata, false); err != nil {
+			return err
+		}
+		stmt, err := tx.Prepare(`INSERT INTO time_series (symbol, interval, date, open, high, low, close, volume)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (symbol, interval, date) DO UPDATE SET
+				open = excluded.open, high = excluded.high, low = excluded.low,
+				close = excluded.close, volume = excluded.volume`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for interval, data := range map[string]map[string]alphavantage.TimeSeriesData{
+			intervalDaily:   ts.TimeSeriesDaily,
+			intervalWeekly:  ts.TimeSeriesWeekly,
+			intervalMonthly: ts.TimeSeriesMonthly,
+		} {
+			for date, item := range data {
+				if _, err := stmt.Exec(symbol, interval, date, item.Open, item.High, item.Low, item.Close, int64(item.Volume)); err != nil {
+					return fmt.Errorf("time_series: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// SaveTimeSeriesAdjusted stores all data points of the adjusted time series.
+func (s *Store) SaveTimeSeriesAdjusted(ts *alphavantage.TimeSeriesAdjusted) error {
+	symbol := ts.Metadata.Symbol
+	if symbol == "" {
+		return errors.New("time series without symbol")
+	}
+	return s.inTx(func(tx *sql.Tx) error {
+		if err := saveTimeSeriesMetadata(tx, ts.Metadata, true); err != nil {
+			return err
+		}
+		stmt, err := tx.Prepare(`INSERT INTO time_series_adjusted (symbol, interval, date, open, high, low, close,
+				adjusted_close, volume, dividend_amount, split_coefficient)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (symbol, interval, date) DO UPDATE SET
+				open = excluded.open, high = excluded.high, low = excluded.low,
+				close = excluded.close, adjusted_close = excluded.adjusted_close,
+				volume = excluded.volume, dividend_amount = excluded.dividend_amount,
+				split_coefficient = excluded.split_coefficient`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for interval, data := range map[string]map[string]alphavantage.TimeSeriesAdjustedData{
+			intervalDaily:   ts.TimeSeriesDaily,
+			intervalWeekly:  ts.TimeSeriesWeekly,
+			intervalMonthly: ts.TimeSeriesMonthly,
+		} {
+			for date, item := range data {
+				if _, err := stmt.Exec(symbol, interval, date, item.Open, item.High, item.Low, item.Close,
+					item.AdjustedClose, int64(item.Volume), item.DividendAmount, item.SplitCoefficient); err != nil {
+					return fmt.Errorf("time_series_adjusted: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// saveTimeSeriesMetadata keeps the fields of earlier saves which are
+// empty, e.g. the daily time zone when the weekly series is saved.
+func saveTimeSeriesMetadata(tx *sql.Tx, metadata alphavantage.TimeSeriesMetadata, adjusted bool) error {
+	_, err := tx.Exec(`INSERT INTO time_series_metadata (symbol, adjusted, information, last_refreshed,
+			output_size, time_zone, daily_time_zone)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (symbol, adjusted) DO UPDATE SET
+			information = excluded.information,
+			last_refreshed = MAX(COALESCE(last_refreshed, ''), excluded.last_refreshed),
+			output_size = COALESCE(NULLIF(excluded.output_size, ''), output_size),
+			time_zone = COALESCE(NULLIF(excluded.time_zone, ''), time_zone),
+			daily_time_zone = COALESCE(NULLIF(excluded.daily_time_zone, ''), daily_time_zone)`,
+		metadata.Symbol, adjusted, metadata.Information, metadata.LastRefreshed,
+		metadata.OutputSize, metadata.TimeZone, metadata.DailyTimeZone)
+	if err != nil {
+		return fmt.Errorf("time_series_metadata: %w", err)
+	}
+	return nil
+}
+
+func readTimeSeriesMetadata(db *sql.DB, symbol string, adjusted bool) (alphavantage.TimeSeriesMetadata, error) {
+	metadata := alphavantage.TimeSeriesMetadata{Symbol: symbol}
+	var outputSize, timeZone, dailyTimeZone sql.NullString
+	err := db.QueryRow(`SELECT information, last_refreshed, output_size, time_zone, daily_time_zone
+		FROM time_series_metadata WHERE symbol = ? AND adjusted = ?`, symbol, adjusted).
+		Scan(&metadata.Information, &metadata.LastRefreshed, &outputSize, &timeZone, &dailyTimeZone)
+	if errors.Is(err, sql.ErrNoRows) {
+		return metadata, ErrNotFound
+	}
+	metadata.OutputSize = outputSize.String
+	metadata.TimeZone = timeZone.String
+	metadata.DailyTimeZone = dailyTimeZone.String
+	return metadata, err
+}
+
+// TimeSeries reads all stored intervals of the symbol's time series.
+func (s *Store) TimeSeries(symbol string) (*alphavantage.TimeSeries, error) {
+	metadata, err := readTimeSeriesMetadata(s.db, symbol, false)
+	if err != nil {
+		return nil, err
+	}
+	ts := &alphavantage.TimeSeries{
+		Metadata:          metadata,
+		TimeSeriesDaily:   make(map[string]alphavantage.TimeSeriesData),
+		TimeSeriesWeekly:  make(map[string]alphavantage.TimeSeriesData),
+		TimeSeriesMonthly: make(map[string]alphavantage.TimeSeriesData),
+	}
+	rows, err := s.db.Query(`SELECT interval, date, open, high, low, close, volume FROM time_series
+		WHERE symbol = ?`, symbol)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			interval, date string
+			item           alphavantage.TimeSeriesData
+			volume         int64
+		)
+		if err := rows.Scan(&interval, &date, &item.Open, &item.High, &item.Low, &item.Close, &volume); err != nil {
+			return nil, err
+		}
+		item.Volume = uint64(volume)
+		switch interval {
+		case intervalDaily:
+			ts.TimeSeriesDaily[date] = item
+		case intervalWeekly:
+			ts.TimeSeriesWeekly[date] = item
+		case intervalMonthly:
+			ts.TimeSeriesMonthly[date] = item
+		}
+	}
+	return ts, rows.Err()
+}
+
+// TimeSeriesAdjusted reads all stored intervals of the symbol's
+// adjusted time series.
+func (s *Store) TimeSeriesAdjusted(symbol string) (*alphavantage.TimeSeriesAdjusted, error) {
+	metadata, err := readTimeSeriesMetadata(s.db, symbol, true)
+	if err != nil {
+		return nil, err
+	}
+	ts := &alphavantage.TimeSeriesAdjusted{
+		Metadata:          metadata,
+		TimeSeriesDaily:   make(map[string]alphavantage.TimeSeriesAdjustedData),
+		TimeSeriesWeekly:  make(map[string]alphavantage.TimeSeriesAdjustedData),
+		TimeSeriesMonthly: make(map[string]alphavantage.TimeSeriesAdjustedData),
+	}
+	rows, err := s.db.Query(`SELECT interval, date, open, high, low, close, adjusted_close, volume,
+			dividend_amount, split_coefficient
+		FROM time_series_adjusted WHERE symbol = ?`, symbol)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			interval, date string
+			item           alphavantage.TimeSeriesAdjustedData
+			volume         int64
+		)
+		if err := rows.Scan(&interval, &date, &item.Open, &item.High, &item.Low, &item.Close,
+			&item.AdjustedClose, &volume, &item.DividendAmount, &item.SplitCoefficient); err != nil {
+			return nil, err
+		}
+		item.Volume = uint64(volume)
+		switch interval {
+		case intervalDaily:
+			ts.TimeSeriesDaily[date] = item
+		case intervalWeekly:
+			ts.TimeSeriesWeekly[date] = item
+		case intervalMonthly:
+			ts.TimeSeriesMonthly[date] = item
+		}
+	}
+	return ts, rows.Err()
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+	"github.com/sklinkert/alphavantage"
+)
+
+func TestTimeSeriesRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+	ts := &alphavantage.TimeSeries{
+		Metadata: alphavantage.TimeSeriesMetadata{Symbol: "IBM", LastRefreshed: "2024-01-03", TimeZone: "US/Eastern"},
+		TimeSeriesDaily: map[string]alphavantage.TimeSeriesData{
+			"2024-01-02": {Open: 162.83, High: 163.29, Low: 160.5, Close: 161.5, Volume: 3825045},
+			"2024-01-03": {Open: 161, High: 161.73, Low: 160.08, Close: 160.1, Volume: 4086065},
+		},
+		TimeSeriesWeekly: map[string]alphavantage.TimeSeriesData{
+			"2024-01-05": {Open: 162.83, High: 163.29, Low: 158.67, Close: 159.16, Volume: 17066519},
+		},
+	}
+	assert.NoError(t.Fatalf, store.SaveTimeSeries(ts))
+
+	// the second save updates a data point and keeps the latest refresh
+	ts.Metadata.LastRefreshed = "2024-01-02"
+	ts.TimeSeriesDaily = map[string]alphavantage.TimeSeriesData{
+		"2024-01-03": {Open: 161, High: 161.73, Low: 160.08, Close: 160.2, Volume: 4086066},
+	}
+	assert.NoError(t.Fatalf, store.SaveTimeSeries(ts))
+
+	read, err := store.TimeSeries("IBM")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "2024-01-03", read.Metadata.LastRefreshed)
+	assert.EqualStrings(t, "US/Eastern", read.Metadata.TimeZone)
+	assert.EqualInt(t, 2, len(read.TimeSeriesDaily))
+	assert.EqualInt(t, 1, len(read.TimeSeriesWeekly))
+	assert.EqualInt(t, 0, len(read.TimeSeriesMonthly))
+	assert.EqualFloat64(t, 162.83, read.TimeSeriesDaily["2024-01-02"].Open)
+	assert.EqualFloat64(t, 160.2, read.TimeSeriesDaily["2024-01-03"].Close)
+	assert.EqualInt(t, 4086066, int(read.TimeSeriesDaily["2024-01-03"].Volume))
+	assert.EqualFloat64(t, 159.16, read.TimeSeriesWeekly["2024-01-05"].Close)
+}
+
+func TestTimeSeriesDailyMetadataRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+	daily := &alphavantage.TimeSeries{
+		Metadata: alphavantage.TimeSeriesMetadata{Symbol: "IBM", LastRefreshed: "2024-01-03",
+			OutputSize: "Compact", DailyTimeZone: "US/Eastern"},
+		TimeSeriesDaily: map[string]alphavantage.TimeSeriesData{
+			"2024-01-03": {Open: 161, High: 161.73, Low: 160.08, Close: 160.1, Volume: 4086065},
+		},
+	}
+	assert.NoError(t.Fatalf, store.SaveTimeSeries(daily))
+
+	read, err := store.TimeSeries("IBM")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "Compact", read.Metadata.OutputSize)
+	assert.EqualStrings(t, "US/Eastern", read.Metadata.DailyTimeZone)
+	assert.EqualStrings(t, "", read.Metadata.TimeZone)
+
+	// a weekly series of the same symbol keeps the daily fields
+	weekly := &alphavantage.TimeSeries{
+		Metadata: alphavantage.TimeSeriesMetadata{Symbol: "IBM", LastRefreshed: "2024-01-05", TimeZone: "US/Eastern"},
+		TimeSeriesWeekly: map[string]alphavantage.TimeSeriesData{
+			"2024-01-05": {Open: 162.83, High: 163.29, Low: 158.67, Close: 159.16, Volume: 17066519},
+		},
+	}
+	assert.NoError(t.Fatalf, store.SaveTimeSeries(weekly))
+
+	read, err = store.TimeSeries("IBM")
+	assert.NoError(t.Fatalf, err)
+	assert.EqualStrings(t, "Compact", read.Metadata.OutputSize)
+	assert.EqualStrings(t, "US/Eastern", read.Metadata.DailyTimeZone)
+	assert.EqualStrings(t, "US/Eastern", read.Metadata.TimeZone)
+}
+
+func TestTimeSeriesAdjustedRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+	ts := &alphavantage.TimeSeriesAdjusted{
+		Metadata: alphavantage.TimeSeriesMetadata{Symbol: "IBM", LastRefreshed: "2024-01-03"},
+		TimeSeriesMonthly: map[string]alphavantage.TimeSeriesAdjustedData{
+			"2023-11-30": {Open: 154, High: 158.6, Low: 153.88, Close: 158.56, AdjustedClose: 155.2,
+				Volume: 91472580, DividendAmount: 1.66, SplitCoefficient: 1},
+		},
+	}
+	assert.NoError(t.Fatalf, store.SaveTimeSeriesAdjusted(ts))
+
+	read, err := store.TimeSeriesAdjusted("IBM")
+	assert.NoError(t.Fatalf, err)
+	item := read.TimeSeriesMonthly["2023-11-30"]
+	assert.EqualFloat64(t, 155.2, item.AdjustedClose)
+	assert.EqualFloat64(t, 1.66, item.DividendAmount)
+	assert.EqualFloat64(t, 1, item.SplitCoefficient)
+	assert.EqualInt(t, 91472580, int(item.Volume))
+
+	// adjusted and raw series are stored separately
+	_, err = store.TimeSeries("IBM")
+	assert.EqualErrors(t, ErrNotFound, err)
+}
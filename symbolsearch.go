@@ -0,0 +1,58 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// SymbolMatch represents a single match of a symbol search.
+type SymbolMatch struct {
+	Symbol      string    `json:"1. symbol"`
+	Name        string    `json:"2. name"`
+	Type        string    `json:"3. type"`
+	Region      string    `json:"4. region"`
+	MarketOpen  string    `json:"5. marketOpen"`  // "09:30"
+	MarketClose string    `json:"6. marketClose"` // "16:00"
+	TimeZone    string    `json:"7. timezone"`    // "UTC-04"
+	Currency    string    `json:"8. currency"`
+	MatchScore  AVFloat64 `json:"9. matchScore"`
+}
+
+// SymbolSearch represents the response from the SYMBOL_SEARCH API endpoint.
+type SymbolSearch struct {
+	BestMatches []SymbolMatch `json:"bestMatches"`
+}
+
+func toSymbolSearch(buf []byte) (*SymbolSearch, error) {
+	search := &SymbolSearch{}
+	if err := json.Unmarshal(buf, search); err != nil {
+		return nil, err
+	}
+	// best match first
+	sort.SliceStable(search.BestMatches, func(i, j int) bool {
+		return search.BestMatches[i].MatchScore.Value > search.BestMatches[j].MatchScore.Value
+	})
+	return search, nil
+}
+
+// SymbolSearch fetches the symbols and names best matching the given keywords.
+// The matches are ranked by match score, best match first.
+func (c *Client) SymbolSearch(keywords string) (*SymbolSearch, error) {
+	const function = "SYMBOL_SEARCH"
+	keywords = url.QueryEscape(keywords)
+	endpoint := fmt.Sprintf("%s/query?function=%s&keywords=%s&apikey=%s", baseURL, function, keywords, c.apiKey)
+	body, err := c.makeHTTPRequest(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	search, err := toSymbolSearch(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := c.checkResponse(function, body, search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
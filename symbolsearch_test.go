@@ -0,0 +1,53 @@
+package alphavantage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToSymbolSearch(t *testing.T) {
+	var buf = `
+{
+    "bestMatches": [
+        {
+            "1. symbol": "STOCK1.LON",
+            "2. name": "Stock1 PLC",
+            "3. type": "Equity",
+            "4. region": "United Kingdom",
+            "5. marketOpen": "08:00",
+            "6. marketClose": "16:30",
+            "7. timezone": "UTC+01",
+            "8. currency": "GBX",
+            "9. matchScore": "0.7273"
+        },
+        {
+            "1. symbol": "STOCK1",
+            "2. name": "Stock1 Inc",
+            "3. type": "Equity",
+            "4. region": "United States",
+            "5. marketOpen": "09:30",
+            "6. marketClose": "16:00",
+            "7. timezone": "UTC-04",
+            "8. currency": "USD",
+            "9. matchScore": "1.0000"
+        }
+    ]
+}
+`
+	search, err := toSymbolSearch([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "SYMBOL_SEARCH", buf, search)
+	assert.EqualInt(t, 2, len(search.BestMatches))
+
+	best := search.BestMatches[0]
+	assert.EqualStrings(t, "STOCK1", best.Symbol)
+	assert.EqualStrings(t, "United States", best.Region)
+	assert.EqualStrings(t, "09:30", best.MarketOpen)
+	assert.EqualStrings(t, "16:00", best.MarketClose)
+	assert.EqualStrings(t, "USD", best.Currency)
+	assert.EqualFloat64(t, 1.0, best.MatchScore.Value)
+
+	assert.EqualStrings(t, "STOCK1.LON", search.BestMatches[1].Symbol)
+	assert.EqualFloat64(t, 0.7273, search.BestMatches[1].MatchScore.Value)
+}
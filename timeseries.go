@@ -15,20 +15,32 @@ type TimeSeries struct {
 	TimeSeriesMonthly map[string]TimeSeriesData `json:"Monthly Time Series"`
 }
 
-// TimeSeriesMetadata is the metadata subset of TimeSeries
+// TimeSeriesMetadata is the metadata subset of TimeSeries.  Daily
+// responses have an output size and number the time zone 5, weekly and
+// monthly ones 4, see Zone.
 type TimeSeriesMetadata struct {
 	Information   string `json:"1. Information"`
-	Symbol        string `json:"2. Symbol"`
+	Symbol        string `json:"2. Symbol" validate:"required"`
 	LastRefreshed string `json:"3. Last Refreshed"`
-	TimeZone      string `json:"4. Time Zone"`
+	OutputSize    string `json:"4. Output Size,omitempty"` // "Compact" or "Full size"
+	TimeZone      string `json:"4. Time Zone,omitempty"`
+	DailyTimeZone string `json:"5. Time Zone,omitempty"`
+}
+
+// Zone returns the time zone of any interval, e.g. "US/Eastern".
+func (m TimeSeriesMetadata) Zone() string {
+	if m.TimeZone != "" {
+		return m.TimeZone
+	}
+	return m.DailyTimeZone
 }
 
 // TimeSeriesData is a subset of TimeSeries
 type TimeSeriesData struct {
-	Open   float64 `json:"1. open,string"`
-	High   float64 `json:"2. high,string"`
-	Low    float64 `json:"3. low,string"`
-	Close  float64 `json:"4. close,string"`
+	Open   float64 `json:"1. open,string" validate:"required"`
+	High   float64 `json:"2. high,string" validate:"required"`
+	Low    float64 `json:"3. low,string" validate:"required"`
+	Close  float64 `json:"4. close,string" validate:"required"`
 	Volume uint64  `json:"5. volume,string"`
 }
 
@@ -42,11 +54,11 @@ type TimeSeriesAdjusted struct {
 
 // TimeSeriesAdjustedData - like TimeSeries, but inclused dividends and adjusted close
 type TimeSeriesAdjustedData struct {
-	Open             float64 `json:"1. open,string"`
-	High             float64 `json:"2. high,string"`
-	Low              float64 `json:"3. low,string"`
-	Close            float64 `json:"4. close,string"`
-	AdjustedClose    float64 `json:"5. adjusted close,string"`
+	Open             float64 `json:"1. open,string" validate:"required"`
+	High             float64 `json:"2. high,string" validate:"required"`
+	Low              float64 `json:"3. low,string" validate:"required"`
+	Close            float64 `json:"4. close,string" validate:"required"`
+	AdjustedClose    float64 `json:"5. adjusted close,string" validate:"required"`
 	Volume           uint64  `json:"6. volume,string"`
 	DividendAmount   float64 `json:"7. dividend amount,string"`
 	SplitCoefficient float64 `json:"8. split coefficient,string"`
@@ -80,6 +92,9 @@ func (c *Client) TimeSeriesAdjusted(symbol string, interval TimeSeriesIntervalAd
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	if err := c.checkResponse(string(interval), body, timeSeries); err != nil {
+		return nil, err
+	}
 
 	return timeSeries, nil
 }
@@ -96,6 +111,9 @@ func (c *Client) TimeSeries(symbol string, interval TimeSeriesInterval, outputSi
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	if err := c.checkResponse(string(interval), body, timeSeries); err != nil {
+		return nil, err
+	}
 
 	return timeSeries, nil
 }
@@ -106,10 +124,48 @@ func (ts *TimeSeries) getFilledData() map[string]TimeSeriesData {
 		return ts.TimeSeriesDaily
 	} else if len(ts.TimeSeriesWeekly) > 0 {
 		return ts.TimeSeriesWeekly
+	} else if len(ts.TimeSeriesMonthly) > 0 {
+		return ts.TimeSeriesMonthly
+	}
+	return nil
+}
+
+// getFilledInterval returns the interval of the filled data subset
+func (ts *TimeSeries) getFilledInterval() Interval {
+	if len(ts.TimeSeriesDaily) > 0 {
+		return IntervalDaily
+	} else if len(ts.TimeSeriesWeekly) > 0 {
+		return IntervalWeekly
+	} else if len(ts.TimeSeriesMonthly) > 0 {
+		return IntervalMonthly
+	}
+	return ""
+}
+
+// getFilledData returns the data subset for the filled interval
+func (ts *TimeSeriesAdjusted) getFilledData() map[string]TimeSeriesAdjustedData {
+	if len(ts.TimeSeriesDaily) > 0 {
+		return ts.TimeSeriesDaily
+	} else if len(ts.TimeSeriesWeekly) > 0 {
+		return ts.TimeSeriesWeekly
+	} else if len(ts.TimeSeriesMonthly) > 0 {
+		return ts.TimeSeriesMonthly
 	}
 	return nil
 }
 
+// getFilledInterval returns the interval of the filled data subset
+func (ts *TimeSeriesAdjusted) getFilledInterval() Interval {
+	if len(ts.TimeSeriesDaily) > 0 {
+		return IntervalDaily
+	} else if len(ts.TimeSeriesWeekly) > 0 {
+		return IntervalWeekly
+	} else if len(ts.TimeSeriesMonthly) > 0 {
+		return IntervalMonthly
+	}
+	return ""
+}
+
 // Len returns the number of data items
 func (ts *TimeSeries) Len() int {
 	fd := ts.getFilledData()
@@ -146,3 +202,16 @@ func (ts *TimeSeries) ByDate(date time.Time) *TimeSeriesData {
 	}
 	return &item
 }
+
+// ByTradingDate returns the dataset for the given date like ByDate, but
+// tells a closed market (ErrMarketClosed) apart from missing data.
+func (ts *TimeSeries) ByTradingDate(calendar *TradingCalendar, date time.Time) (*TimeSeriesData, error) {
+	if !calendar.IsTradingDay(date) {
+		return nil, ErrMarketClosed
+	}
+	item := ts.ByDate(calendar.date(date))
+	if item == nil {
+		return nil, fmt.Errorf("no data for trading day %s", calendar.date(date).Format(DateFormat))
+	}
+	return item, nil
+}
@@ -35,7 +35,9 @@ func TestToTimeSeries(t *testing.T) {
 `
 	timeSeries, err := toTimeSeries([]byte(buf))
 	assert.NoError(t, err)
+	assertStrict(t, "TIME_SERIES_WEEKLY", buf, timeSeries)
 	assert.EqualStrings(t, "STOCK1", timeSeries.Metadata.Symbol)
+	assert.EqualStrings(t, "US/Eastern", timeSeries.Metadata.Zone())
 	assert.EqualInt(t, 2, len(timeSeries.TimeSeriesWeekly))
 	assert.EqualInt(t, 2, timeSeries.Len())
 
@@ -83,7 +85,10 @@ func TestToTimeSeriesAdjusted(t *testing.T) {
 `
 	timeSeries, err := toTimeSeriesAdjusted([]byte(buf))
 	assert.NoError(t, err)
+	assertStrict(t, "TIME_SERIES_DAILY_ADJUSTED", buf, timeSeries)
 	assert.EqualStrings(t, "STOCK2", timeSeries.Metadata.Symbol)
+	assert.EqualStrings(t, "Full size", timeSeries.Metadata.OutputSize)
+	assert.EqualStrings(t, "US/Eastern", timeSeries.Metadata.Zone())
 	assert.EqualInt(t, 2, len(timeSeries.TimeSeriesDaily))
 
 	ta1, exists := timeSeries.TimeSeriesDaily["2020-02-11"]
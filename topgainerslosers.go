@@ -0,0 +1,52 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TickerMover represents a ticker listed as gainer, loser or most
+// actively traded.
+type TickerMover struct {
+	Ticker           string    `json:"ticker"`
+	Price            AVFloat64 `json:"price"`
+	ChangeAmount     AVFloat64 `json:"change_amount"`
+	ChangePercentage AVPercent `json:"change_percentage"`
+	Volume           AVInt     `json:"volume"`
+}
+
+// TopGainersLosers represents the response from the TOP_GAINERS_LOSERS API endpoint.
+type TopGainersLosers struct {
+	Metadata           string        `json:"metadata"`
+	LastUpdated        string        `json:"last_updated"` // "2024-05-03 16:15:59 US/Eastern"
+	TopGainers         []TickerMover `json:"top_gainers"`
+	TopLosers          []TickerMover `json:"top_losers"`
+	MostActivelyTraded []TickerMover `json:"most_actively_traded"`
+}
+
+func toTopGainersLosers(buf []byte) (*TopGainersLosers, error) {
+	topGainersLosers := &TopGainersLosers{}
+	if err := json.Unmarshal(buf, topGainersLosers); err != nil {
+		return nil, err
+	}
+	return topGainersLosers, nil
+}
+
+// TopGainersLosers fetches the top 20 gainers, losers and most actively
+// traded tickers of the US market.
+func (c *Client) TopGainersLosers() (*TopGainersLosers, error) {
+	const function = "TOP_GAINERS_LOSERS"
+	url := fmt.Sprintf("%s/query?function=%s&apikey=%s", baseURL, function, c.apiKey)
+	body, err := c.makeHTTPRequest(url)
+	if err != nil {
+		return nil, err
+	}
+	topGainersLosers, err := toTopGainersLosers(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := c.checkResponse(function, body, topGainersLosers); err != nil {
+		return nil, err
+	}
+	return topGainersLosers, nil
+}
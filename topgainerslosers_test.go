@@ -0,0 +1,68 @@
+package alphavantage
+
+import (
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToTopGainersLosers(t *testing.T) {
+	var buf = `
+{
+    "metadata": "Top gainers, losers, and most actively traded US tickers",
+    "last_updated": "2024-05-03 16:15:59 US/Eastern",
+    "top_gainers": [
+        {
+            "ticker": "STOCK1",
+            "price": "0.0251",
+            "change_amount": "0.0153",
+            "change_percentage": "156.1224%",
+            "volume": "1088380"
+        }
+    ],
+    "top_losers": [
+        {
+            "ticker": "STOCK2",
+            "price": "1.05",
+            "change_amount": "-1.45",
+            "change_percentage": "-58.0%",
+            "volume": "5411890"
+        }
+    ],
+    "most_actively_traded": [
+        {
+            "ticker": "STOCK3",
+            "price": "183.38",
+            "change_amount": "10.35",
+            "change_percentage": "5.9816%",
+            "volume": "163224109"
+        },
+        {
+            "ticker": "STOCK4",
+            "price": "5.12",
+            "change_amount": "0.0",
+            "change_percentage": "0.0%",
+            "volume": "99000000"
+        }
+    ]
+}
+`
+	movers, err := toTopGainersLosers([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "TOP_GAINERS_LOSERS", buf, movers)
+	assert.EqualStrings(t, "2024-05-03 16:15:59 US/Eastern", movers.LastUpdated)
+
+	assert.EqualInt(t, 1, len(movers.TopGainers))
+	gainer := movers.TopGainers[0]
+	assert.EqualStrings(t, "STOCK1", gainer.Ticker)
+	assert.EqualFloat64(t, 0.0251, gainer.Price.Value)
+	assert.EqualFloat64(t, 156.1224, gainer.ChangePercentage.Value)
+	assert.EqualInt(t, 1088380, gainer.Volume.Value)
+
+	assert.EqualInt(t, 1, len(movers.TopLosers))
+	assert.EqualFloat64(t, -58.0, movers.TopLosers[0].ChangePercentage.Value)
+	assert.EqualFloat64(t, -1.45, movers.TopLosers[0].ChangeAmount.Value)
+
+	assert.EqualInt(t, 2, len(movers.MostActivelyTraded))
+	assert.EqualInt(t, 163224109, movers.MostActivelyTraded[0].Volume.Value)
+}
@@ -0,0 +1,266 @@
+package alphavantage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrMarketClosed is returned for dates without a trading session.
+var ErrMarketClosed = errors.New("market closed")
+
+const (
+	tradingCalendarLocation = "America/New_York"
+	sessionOpenMinutes      = 9*60 + 30
+	sessionCloseMinutes     = 16 * 60
+	earlyCloseMinutes       = 13 * 60
+)
+
+// specialClosures are unscheduled full day closures of the NYSE
+// which don't follow the holiday rules.
+var specialClosures = map[string]string{
+	"1994-04-27": "National Day of Mourning for Richard Nixon",
+	"2001-09-11": "September 11 attacks",
+	"2001-09-12": "September 11 attacks",
+	"2001-09-13": "September 11 attacks",
+	"2001-09-14": "September 11 attacks",
+	"2004-06-11": "National Day of Mourning for Ronald Reagan",
+	"2007-01-02": "National Day of Mourning for Gerald Ford",
+	"2012-10-29": "Hurricane Sandy",
+	"2012-10-30": "Hurricane Sandy",
+	"2018-12-05": "National Day of Mourning for George H.W. Bush",
+	"2025-01-09": "National Day of Mourning for Jimmy Carter",
+}
+
+// TradingCalendar is the calendar of the regular trading sessions of
+// NYSE and NASDAQ.  Holidays and early closes (13:00) are derived from
+// the exchange rules, session times are in America/New_York.
+type TradingCalendar struct {
+	location *time.Location
+}
+
+// NewTradingCalendar creates a trading calendar for the US exchanges.
+func NewTradingCalendar() (*TradingCalendar, error) {
+	location, err := time.LoadLocation(tradingCalendarLocation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load location %s: %w", tradingCalendarLocation, err)
+	}
+	return &TradingCalendar{location: location}, nil
+}
+
+// Location returns the time zone of the exchange.
+func (tc *TradingCalendar) Location() *time.Location {
+	return tc.location
+}
+
+// date returns midnight of the exchange date of t
+func (tc *TradingCalendar) date(t time.Time) time.Time {
+	t = t.In(tc.location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, tc.location)
+}
+
+// Holiday returns the name of the holiday if the exchange is closed on
+// the date of t for a reason other than the weekend.
+func (tc *TradingCalendar) Holiday(t time.Time) (string, bool) {
+	day := tc.date(t)
+	if name, ok := specialClosures[day.Format(DateFormat)]; ok {
+		return name, true
+	}
+	for _, holiday := range tc.holidays(day.Year()) {
+		if holiday.date.Equal(day) {
+			return holiday.name, true
+		}
+	}
+	return "", false
+}
+
+// IsTradingDay reports whether there is a trading session on the date of t.
+func (tc *TradingCalendar) IsTradingDay(t time.Time) bool {
+	day := tc.date(t)
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return false
+	}
+	_, holiday := tc.Holiday(day)
+	return !holiday
+}
+
+// IsEarlyClose reports whether the session on the date of t closes at 13:00.
+func (tc *TradingCalendar) IsEarlyClose(t time.Time) bool {
+	day := tc.date(t)
+	if !tc.IsTradingDay(day) {
+		return false
+	}
+	switch {
+	case day.Month() == time.July && day.Day() == 3:
+		// only Monday to Thursday, on Friday July 4 is observed
+		return day.Weekday() != time.Friday
+	case day.Month() == time.December && day.Day() == 24:
+		return true
+	case day.Month() == time.November && day.Weekday() == time.Friday:
+		thanksgiving := nthWeekday(day.Year(), time.November, time.Thursday, 4, tc.location)
+		return day.Equal(thanksgiving.AddDate(0, 0, 1))
+	}
+	return false
+}
+
+// Session returns the open and close of the session on the date of t.
+// ok is false if there is no session on that date.
+func (tc *TradingCalendar) Session(t time.Time) (opens time.Time, closes time.Time, ok bool) {
+	day := tc.date(t)
+	if !tc.IsTradingDay(day) {
+		return time.Time{}, time.Time{}, false
+	}
+	closeMinutes := sessionCloseMinutes
+	if tc.IsEarlyClose(day) {
+		closeMinutes = earlyCloseMinutes
+	}
+	opens = time.Date(day.Year(), day.Month(), day.Day(), 0, sessionOpenMinutes, 0, 0, tc.location)
+	closes = time.Date(day.Year(), day.Month(), day.Day(), 0, closeMinutes, 0, 0, tc.location)
+	return opens, closes, true
+}
+
+// IsOpen reports whether t is within a regular trading session.
+func (tc *TradingCalendar) IsOpen(t time.Time) bool {
+	opens, closes, ok := tc.Session(t)
+	return ok && !t.Before(opens) && t.Before(closes)
+}
+
+// PreviousTradingDay returns the last trading day before the date of t.
+func (tc *TradingCalendar) PreviousTradingDay(t time.Time) time.Time {
+	day := tc.date(t).AddDate(0, 0, -1)
+	for !tc.IsTradingDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// NextTradingDay returns the first trading day after the date of t.
+func (tc *TradingCalendar) NextTradingDay(t time.Time) time.Time {
+	day := tc.date(t).AddDate(0, 0, 1)
+	for !tc.IsTradingDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// LastTradingDay returns the date of t if it's a trading day and the
+// previous trading day otherwise.
+func (tc *TradingCalendar) LastTradingDay(t time.Time) time.Time {
+	if tc.IsTradingDay(t) {
+		return tc.date(t)
+	}
+	return tc.PreviousTradingDay(t)
+}
+
+// NextSessionOpen returns the first session open after t.
+func (tc *TradingCalendar) NextSessionOpen(t time.Time) time.Time {
+	if opens, _, ok := tc.Session(t); ok && t.Before(opens) {
+		return opens
+	}
+	opens, _, _ := tc.Session(tc.NextTradingDay(t))
+	return opens
+}
+
+// NextSessionClose returns the first session close after t.
+func (tc *TradingCalendar) NextSessionClose(t time.Time) time.Time {
+	if _, closes, ok := tc.Session(t); ok && t.Before(closes) {
+		return closes
+	}
+	_, closes, _ := tc.Session(tc.NextTradingDay(t))
+	return closes
+}
+
+// ExpirationDelay returns how long data of the interval fetched at now
+// stays current.  While the market is open intraday data expires with
+// the interval, otherwise it's current until the first new bar after
+// the next session open.  Daily and longer bars change with each
+// session close.
+func (tc *TradingCalendar) ExpirationDelay(interval Interval, now time.Time) time.Duration {
+	switch interval {
+	case IntervalDaily, IntervalWeekly, IntervalMonthly:
+		return tc.NextSessionClose(now).Sub(now)
+	}
+	delay := IntervalToExpirationDelay(interval)
+	if tc.IsOpen(now) {
+		return delay
+	}
+	return tc.NextSessionOpen(now).Sub(now) + delay
+}
+
+type tradingHoliday struct {
+	name string
+	date time.Time
+}
+
+// holidays returns the observed NYSE holidays of the year.
+func (tc *TradingCalendar) holidays(year int) []tradingHoliday {
+	loc := tc.location
+	fixed := func(month time.Month, day int) time.Time {
+		return observed(time.Date(year, month, day, 0, 0, 0, 0, loc))
+	}
+	holidays := []tradingHoliday{
+		{"Washington's Birthday", nthWeekday(year, time.February, time.Monday, 3, loc)},
+		{"Good Friday", easter(year, loc).AddDate(0, 0, -2)},
+		{"Memorial Day", lastWeekday(year, time.May, time.Monday, loc)},
+		{"Independence Day", fixed(time.July, 4)},
+		{"Labor Day", nthWeekday(year, time.September, time.Monday, 1, loc)},
+		{"Thanksgiving Day", nthWeekday(year, time.November, time.Thursday, 4, loc)},
+		{"Christmas Day", fixed(time.December, 25)},
+	}
+	// New Year's Day on a Saturday is not observed on the Friday before
+	// since that's the end of the accounting year
+	if newYear := time.Date(year, time.January, 1, 0, 0, 0, 0, loc); newYear.Weekday() != time.Saturday {
+		holidays = append(holidays, tradingHoliday{"New Year's Day", observed(newYear)})
+	}
+	if year >= 1998 {
+		holidays = append(holidays, tradingHoliday{"Martin Luther King, Jr. Day", nthWeekday(year, time.January, time.Monday, 3, loc)})
+	}
+	if year >= 2022 {
+		holidays = append(holidays, tradingHoliday{"Juneteenth National Independence Day", fixed(time.June, 19)})
+	}
+	return holidays
+}
+
+// observed moves holidays on a Saturday to Friday and on a Sunday to Monday
+func observed(date time.Time) time.Time {
+	switch date.Weekday() {
+	case time.Saturday:
+		return date.AddDate(0, 0, -1)
+	case time.Sunday:
+		return date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// nthWeekday returns the nth weekday of the month, e.g. the 3rd Monday
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int, loc *time.Location) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+(n-1)*7)
+}
+
+// lastWeekday returns the last weekday of the month, e.g. the last Monday
+func lastWeekday(year int, month time.Month, weekday time.Weekday, loc *time.Location) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, loc)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.AddDate(0, 0, -offset)
+}
+
+// easter returns Easter Sunday of the year (anonymous Gregorian algorithm)
+func easter(year int, loc *time.Location) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,152 @@
+package alphavantage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AMekss/assert"
+)
+
+func newTestTradingCalendar(t *testing.T) *TradingCalendar {
+	calendar, err := NewTradingCalendar()
+	assert.NoError(t.Fatalf, err)
+	return calendar
+}
+
+// calendarDate parses the date at midnight exchange time
+func calendarDate(t *testing.T, calendar *TradingCalendar, value string) time.Time {
+	date, err := time.ParseInLocation(DateFormat, value, calendar.Location())
+	assert.NoError(t.Fatalf, err)
+	return date
+}
+
+func TestTradingCalendarHolidays(t *testing.T) {
+	calendar := newTestTradingCalendar(t)
+	// published NYSE holidays
+	holidays := []string{
+		"2024-01-01", "2024-01-15", "2024-02-19", "2024-03-29", "2024-05-27",
+		"2024-06-19", "2024-07-04", "2024-09-02", "2024-11-28", "2024-12-25",
+		"2025-01-01", "2025-01-09", "2025-01-20", "2025-02-17", "2025-04-18",
+		"2025-05-26", "2025-06-19", "2025-07-04", "2025-09-01", "2025-11-27",
+		"2025-12-25",
+		"2026-01-01", "2026-01-19", "2026-02-16", "2026-04-03", "2026-05-25",
+		"2026-06-19", "2026-07-03", "2026-09-07", "2026-11-26", "2026-12-25",
+		"2021-12-24", "2022-01-17", "2022-06-20", "2023-01-02",
+	}
+	for _, date := range holidays {
+		day := calendarDate(t, calendar, date)
+		if calendar.IsTradingDay(day) {
+			t.Errorf("Expected %s to be a holiday", date)
+		}
+	}
+	name, ok := calendar.Holiday(calendarDate(t, calendar, "2024-03-29"))
+	assert.True(t, ok)
+	assert.EqualStrings(t, "Good Friday", name)
+
+	// New Year's Day on a Saturday isn't observed on Friday
+	tradingDays := []string{"2021-12-31", "2024-07-05", "2025-01-10", "2026-07-02"}
+	for _, date := range tradingDays {
+		if !calendar.IsTradingDay(calendarDate(t, calendar, date)) {
+			t.Errorf("Expected %s to be a trading day", date)
+		}
+	}
+	assert.False(t, calendar.IsTradingDay(calendarDate(t, calendar, "2024-06-15")))
+}
+
+func TestTradingCalendarSpecialClosures(t *testing.T) {
+	calendar := newTestTradingCalendar(t)
+	closures := []string{
+		"1994-04-27", "2001-09-11", "2001-09-12", "2001-09-13", "2001-09-14",
+		"2004-06-11", "2007-01-02", "2012-10-29", "2012-10-30", "2018-12-05",
+		"2025-01-09",
+	}
+	for _, date := range closures {
+		if calendar.IsTradingDay(calendarDate(t, calendar, date)) {
+			t.Errorf("Expected %s to be closed", date)
+		}
+	}
+	name, ok := calendar.Holiday(calendarDate(t, calendar, "2004-06-11"))
+	assert.True(t, ok)
+	assert.EqualStrings(t, "National Day of Mourning for Ronald Reagan", name)
+
+	// the market reopened on Monday after September 11
+	reopened := calendar.NextTradingDay(calendarDate(t, calendar, "2001-09-10"))
+	assert.EqualStrings(t, "2001-09-17", reopened.Format(DateFormat))
+
+	timeSeries := &TimeSeries{TimeSeriesDaily: map[string]TimeSeriesData{
+		"2001-09-10": {Close: 1},
+		"2001-09-17": {Close: 2},
+	}}
+	for _, date := range []string{"2001-09-11", "2004-06-11", "2007-01-02"} {
+		_, err := timeSeries.ByTradingDate(calendar, calendarDate(t, calendar, date))
+		assert.EqualErrors(t, ErrMarketClosed, err)
+	}
+}
+
+func TestTradingCalendarEarlyClose(t *testing.T) {
+	calendar := newTestTradingCalendar(t)
+	for _, date := range []string{"2023-07-03", "2024-07-03", "2024-11-29", "2024-12-24", "2025-07-03", "2025-11-28", "2025-12-24", "2026-11-27"} {
+		if !calendar.IsEarlyClose(calendarDate(t, calendar, date)) {
+			t.Errorf("Expected %s to be an early close", date)
+		}
+	}
+	for _, date := range []string{"2026-07-02", "2024-07-02", "2023-12-22"} {
+		if calendar.IsEarlyClose(calendarDate(t, calendar, date)) {
+			t.Errorf("Expected %s to be a full session", date)
+		}
+	}
+
+	opens, closes, ok := calendar.Session(calendarDate(t, calendar, "2024-11-29"))
+	assert.True(t, ok)
+	assert.EqualTime(t, time.Date(2024, 11, 29, 9, 30, 0, 0, calendar.Location()), opens)
+	assert.EqualTime(t, time.Date(2024, 11, 29, 13, 0, 0, 0, calendar.Location()), closes)
+}
+
+func TestTradingCalendarNavigation(t *testing.T) {
+	calendar := newTestTradingCalendar(t)
+	ny := calendar.Location()
+
+	// Tuesday after Memorial Day
+	assert.EqualTime(t, time.Date(2024, 5, 24, 0, 0, 0, 0, ny), calendar.PreviousTradingDay(time.Date(2024, 5, 28, 12, 0, 0, 0, ny)))
+	assert.EqualTime(t, time.Date(2024, 5, 28, 0, 0, 0, 0, ny), calendar.NextTradingDay(time.Date(2024, 5, 24, 12, 0, 0, 0, ny)))
+	assert.EqualTime(t, time.Date(2024, 5, 24, 0, 0, 0, 0, ny), calendar.LastTradingDay(time.Date(2024, 5, 26, 12, 0, 0, 0, ny)))
+
+	// before, during and after the session
+	assert.EqualTime(t, time.Date(2024, 5, 24, 9, 30, 0, 0, ny), calendar.NextSessionOpen(time.Date(2024, 5, 24, 8, 0, 0, 0, ny)))
+	assert.EqualTime(t, time.Date(2024, 5, 28, 9, 30, 0, 0, ny), calendar.NextSessionOpen(time.Date(2024, 5, 24, 10, 0, 0, 0, ny)))
+	assert.True(t, calendar.IsOpen(time.Date(2024, 5, 24, 10, 0, 0, 0, ny)))
+	assert.False(t, calendar.IsOpen(time.Date(2024, 5, 24, 16, 0, 0, 0, ny)))
+	// UTC input is converted to exchange time
+	assert.True(t, calendar.IsOpen(time.Date(2024, 5, 24, 14, 0, 0, 0, time.UTC)))
+}
+
+func TestTradingCalendarExpirationDelay(t *testing.T) {
+	calendar := newTestTradingCalendar(t)
+	ny := calendar.Location()
+
+	// intraday while open
+	assert.EqualInt(t, int(5*time.Minute), int(calendar.ExpirationDelay(Interval5Min, time.Date(2024, 5, 24, 10, 0, 0, 0, ny))))
+	// intraday on Friday evening: until the first bar after Tuesday's open
+	friday := time.Date(2024, 5, 24, 20, 0, 0, 0, ny)
+	want := time.Date(2024, 5, 28, 9, 35, 0, 0, ny).Sub(friday)
+	assert.EqualInt(t, int(want), int(calendar.ExpirationDelay(Interval5Min, friday)))
+	// daily: until the next close
+	want = time.Date(2024, 5, 28, 16, 0, 0, 0, ny).Sub(friday)
+	assert.EqualInt(t, int(want), int(calendar.ExpirationDelay(IntervalDaily, friday)))
+}
+
+func TestTimeSeriesByTradingDate(t *testing.T) {
+	calendar := newTestTradingCalendar(t)
+	timeSeries, err := toTimeSeries([]byte(localIndicatorTimeSeries))
+	assert.NoError(t.Fatalf, err)
+
+	data, err := timeSeries.ByTradingDate(calendar, calendarDate(t, calendar, "2024-02-26"))
+	assert.NoError(t.Fatalf, err)
+	assert.EqualFloat64(t, 97.19, data.Close)
+
+	_, err = timeSeries.ByTradingDate(calendar, calendarDate(t, calendar, "2024-02-25"))
+	assert.EqualErrors(t, ErrMarketClosed, err)
+
+	_, err = timeSeries.ByTradingDate(calendar, calendarDate(t, calendar, "2024-02-27"))
+	assert.ErrorIncludesMessage(t, "no data for trading day 2024-02-27", err)
+}
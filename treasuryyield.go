@@ -0,0 +1,80 @@
+package alphavantage
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Maturities of TreasuryYield
+const (
+	TreasuryMaturity3Month = "3month"
+	TreasuryMaturity2Year  = "2year"
+	TreasuryMaturity5Year  = "5year"
+	TreasuryMaturity7Year  = "7year"
+	TreasuryMaturity10Year = "10year"
+	TreasuryMaturity30Year = "30year"
+)
+
+// TreasuryYieldData is the yield of a day, week or month in percent,
+// "None" for days without trading.
+type TreasuryYieldData struct {
+	Date  string    `json:"date" validate:"required"` // "2024-01-01"
+	Value AVFloat64 `json:"value"`
+}
+
+// TreasuryYield represents the response from the TREASURY_YIELD API
+// endpoint, the latest yield first.
+type TreasuryYield struct {
+	Name     string              `json:"name"`
+	Interval string              `json:"interval"`
+	Unit     string              `json:"unit"`
+	Data     []TreasuryYieldData `json:"data"`
+}
+
+func toTreasuryYield(buf []byte) (*TreasuryYield, error) {
+	treasuryYield := &TreasuryYield{}
+	if err := json.Unmarshal(buf, treasuryYield); err != nil {
+		return nil, err
+	}
+	return treasuryYield, nil
+}
+
+// TreasuryYield fetches the US treasury yield of the maturity, e.g.
+// TreasuryMaturity10Year, with the interval "daily", "weekly" or
+// "monthly".
+func (c *Client) TreasuryYield(interval, maturity string) (*TreasuryYield, error) {
+	const function = "TREASURY_YIELD"
+	url := fmt.Sprintf("%s/query?function=%s&interval=%s&maturity=%s&apikey=%s", baseURL, function, interval, maturity, c.apiKey)
+	body, err := c.makeHTTPRequest(url)
+	if err != nil {
+		return nil, err
+	}
+	treasuryYield, err := toTreasuryYield(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := c.checkResponse(function, body, treasuryYield); err != nil {
+		return nil, err
+	}
+	return treasuryYield, nil
+}
+
+// Latest returns the latest yield which isn't "None".
+func (ty *TreasuryYield) Latest() (TreasuryYieldData, bool) {
+	for _, data := range ty.Data {
+		if !data.Value.IsNull() {
+			return data, true
+		}
+	}
+	return TreasuryYieldData{}, false
+}
+
+// RiskFreeRate returns the latest yield as a fraction, e.g. 0.0425 for
+// 4.25%, for the CAPM of DCFAssumptions.
+func (ty *TreasuryYield) RiskFreeRate() (float64, error) {
+	latest, ok := ty.Latest()
+	if !ok {
+		return 0, fmt.Errorf("treasury yield: %w", ErrNoneValue)
+	}
+	return latest.Value.Float64() / 100, nil
+}
@@ -0,0 +1,50 @@
+package alphavantage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func TestToTreasuryYield(t *testing.T) {
+	var buf = `
+{
+    "name": "10-Year Treasury Constant Maturity Rate",
+    "interval": "daily",
+    "unit": "percent",
+    "data": [
+        {
+            "date": "2024-01-15",
+            "value": "."
+        },
+        {
+            "date": "2024-01-12",
+            "value": "3.96"
+        },
+        {
+            "date": "2024-01-11",
+            "value": "3.98"
+        }
+    ]
+}
+`
+	treasuryYield, err := toTreasuryYield([]byte(buf))
+	assert.NoError(t.Fatalf, err)
+	assertStrict(t, "TREASURY_YIELD", buf, treasuryYield)
+	assert.EqualStrings(t, "daily", treasuryYield.Interval)
+	assert.EqualStrings(t, "percent", treasuryYield.Unit)
+	assert.EqualInt(t, 3, len(treasuryYield.Data))
+	assert.True(t, treasuryYield.Data[0].Value.IsNull())
+
+	latest, ok := treasuryYield.Latest()
+	assert.True(t, ok)
+	assert.EqualStrings(t, "2024-01-12", latest.Date)
+
+	rate, err := treasuryYield.RiskFreeRate()
+	assert.NoError(t, err)
+	assert.EqualFloat64Tol(t, 0.0396, rate, 1e-12)
+
+	_, err = (&TreasuryYield{}).RiskFreeRate()
+	assert.True(t, errors.Is(err, ErrNoneValue))
+}
@@ -0,0 +1,230 @@
+package alphavantage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Errors of the computations over Financials.  They are wrapped with the
+// period and line item, use errors.Is to check them.
+var (
+	// ErrMissingPeriod is returned if a statement doesn't report a period
+	// which is needed, e.g. one of the last four quarters of a TTM sum.
+	ErrMissingPeriod = errors.New("missing period")
+	// ErrNoneValue is returned if a line item which is needed is "None".
+	ErrNoneValue = errors.New("value is None")
+	// ErrUnknownLineItem is returned for line items which the statements
+	// don't have.
+	ErrUnknownLineItem = errors.New("unknown line item")
+	// ErrZeroBase is returned for growth from 0, or CAGR from or to values
+	// which aren't positive.
+	ErrZeroBase = errors.New("undefined growth base")
+	// ErrDivisionByZero is returned for ratios and scores with a
+	// denominator of 0.
+	ErrDivisionByZero = errors.New("division by zero")
+)
+
+var statementTypes = map[Statement]reflect.Type{
+	StatementBalanceSheet:    reflect.TypeOf(BsAnnualReport{}),
+	StatementIncomeStatement: reflect.TypeOf(IsAnnualReport{}),
+	StatementCashFlow:        reflect.TypeOf(CfAnnualReport{}),
+}
+
+// flowStatements have line items which are summed over periods
+var flowStatements = []Statement{StatementIncomeStatement, StatementCashFlow}
+
+// allStatements are searched for line items in the order of LineItem
+var allStatements = []Statement{StatementIncomeStatement, StatementBalanceSheet, StatementCashFlow}
+
+// hasLineItem reports whether reports of the statement have the line item.
+func hasLineItem(statement Statement, name string) bool {
+	t := statementTypes[statement]
+	for i := 0; i < t.NumField(); i++ {
+		if jsonName(t.Field(i)) == name && t.Field(i).Type == reflect.TypeOf(AVInt{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// report returns the report of the statement, nil if it's missing.
+func (fp FinancialPeriod) report(statement Statement) interface{} {
+	switch statement {
+	case StatementBalanceSheet:
+		if fp.BalanceSheet != nil {
+			return fp.BalanceSheet
+		}
+	case StatementIncomeStatement:
+		if fp.IncomeStatement != nil {
+			return fp.IncomeStatement
+		}
+	case StatementCashFlow:
+		if fp.CashFlow != nil {
+			return fp.CashFlow
+		}
+	}
+	return nil
+}
+
+// periodName returns e.g. "FY2024Q1"
+func periodName(fiscalYear, fiscalQuarter int) string {
+	return Period{FiscalYear: fiscalYear, FiscalQuarter: fiscalQuarter}.String()
+}
+
+// Value returns the line item of the fiscal year and quarter, quarter 0
+// for the fiscal year, from the first of the statements which has it.
+// Missing periods and "None" are errors.
+func (f *Financials) Value(item string, fiscalYear, fiscalQuarter int) (float64, error) {
+	return f.value(item, fiscalYear, fiscalQuarter, allStatements)
+}
+
+func (f *Financials) value(item string, fiscalYear, fiscalQuarter int, statements []Statement) (float64, error) {
+	statement, ok := Statement(""), false
+	for _, s := range statements {
+		if hasLineItem(s, item) {
+			statement, ok = s, true
+			break
+		}
+	}
+	if !ok {
+		return 0, fmt.Errorf("%q: %w", item, ErrUnknownLineItem)
+	}
+	name := periodName(fiscalYear, fiscalQuarter)
+	fp, ok := f.Get(fiscalYear, fiscalQuarter)
+	if !ok {
+		return 0, fmt.Errorf("%s: %w", name, ErrMissingPeriod)
+	}
+	report := fp.report(statement)
+	if report == nil {
+		return 0, fmt.Errorf("%s %s: %w", name, statement, ErrMissingPeriod)
+	}
+	value, _ := lineItem(report, item)
+	if value.IsNull() {
+		return 0, fmt.Errorf("%s %s: %w", name, item, ErrNoneValue)
+	}
+	return value.Float64(), nil
+}
+
+// addQuarters returns the fiscal quarter n quarters after the quarter,
+// before it for negative n.
+func addQuarters(fiscalYear, fiscalQuarter, n int) (int, int) {
+	quarters := fiscalYear*4 + fiscalQuarter - 1 + n
+	return quarters / 4, quarters%4 + 1
+}
+
+// TTM returns the trailing twelve months sum of an income statement or
+// cash flow line item, e.g. "totalRevenue", over the fiscal quarter and
+// the three quarters before it.  All four quarters have to be reported.
+func (f *Financials) TTM(item string, fiscalYear, fiscalQuarter int) (float64, error) {
+	if fiscalQuarter < 1 || fiscalQuarter > 4 {
+		return 0, fmt.Errorf("invalid fiscal quarter: %d", fiscalQuarter)
+	}
+	var sum float64
+	for n := 0; n > -4; n-- {
+		year, quarter := addQuarters(fiscalYear, fiscalQuarter, n)
+		value, err := f.value(item, year, quarter, flowStatements)
+		if err != nil {
+			return 0, fmt.Errorf("TTM: %w", err)
+		}
+		sum += value
+	}
+	return sum, nil
+}
+
+// LatestTTM returns the TTM sum up to the latest reported quarter.
+func (f *Financials) LatestTTM(item string) (float64, Period, error) {
+	if len(f.Quarterly) == 0 {
+		return 0, Period{}, fmt.Errorf("TTM: no quarters: %w", ErrMissingPeriod)
+	}
+	latest := f.Quarterly[len(f.Quarterly)-1].Period
+	sum, err := f.TTM(item, latest.FiscalYear, latest.FiscalQuarter)
+	return sum, latest, err
+}
+
+// PointInTime returns a balance sheet line item, e.g. "totalAssets", at
+// the end of the fiscal year and quarter, quarter 0 for the fiscal year.
+func (f *Financials) PointInTime(item string, fiscalYear, fiscalQuarter int) (float64, error) {
+	return f.value(item, fiscalYear, fiscalQuarter, []Statement{StatementBalanceSheet})
+}
+
+// Growth returns the relative change (current - previous) / |previous|,
+// e.g. 0.1 for 10%.
+func Growth(current, previous float64) (float64, error) {
+	if previous == 0 {
+		return 0, ErrZeroBase
+	}
+	return (current - previous) / math.Abs(previous), nil
+}
+
+// YoY returns the growth of the line item of the fiscal year and quarter
+// over the same period a year before, quarter 0 for fiscal years.
+func (f *Financials) YoY(item string, fiscalYear, fiscalQuarter int) (float64, error) {
+	return f.growth(item, fiscalYear, fiscalQuarter, fiscalYear-1, fiscalQuarter)
+}
+
+// QoQ returns the growth of the line item of the fiscal quarter over the
+// quarter before.
+func (f *Financials) QoQ(item string, fiscalYear, fiscalQuarter int) (float64, error) {
+	if fiscalQuarter < 1 || fiscalQuarter > 4 {
+		return 0, fmt.Errorf("invalid fiscal quarter: %d", fiscalQuarter)
+	}
+	year, quarter := addQuarters(fiscalYear, fiscalQuarter, -1)
+	return f.growth(item, fiscalYear, fiscalQuarter, year, quarter)
+}
+
+func (f *Financials) growth(item string, fiscalYear, fiscalQuarter, previousYear, previousQuarter int) (float64, error) {
+	current, err := f.Value(item, fiscalYear, fiscalQuarter)
+	if err != nil {
+		return 0, err
+	}
+	previous, err := f.Value(item, previousYear, previousQuarter)
+	if err != nil {
+		return 0, err
+	}
+	growth, err := Growth(current, previous)
+	if err != nil {
+		return 0, fmt.Errorf("%s %s: %w", periodName(previousYear, previousQuarter), item, err)
+	}
+	return growth, nil
+}
+
+// TTMYoY returns the growth of the TTM sum up to the fiscal quarter over
+// the TTM sum a year before.  It needs eight quarters.
+func (f *Financials) TTMYoY(item string, fiscalYear, fiscalQuarter int) (float64, error) {
+	current, err := f.TTM(item, fiscalYear, fiscalQuarter)
+	if err != nil {
+		return 0, err
+	}
+	previous, err := f.TTM(item, fiscalYear-1, fiscalQuarter)
+	if err != nil {
+		return 0, err
+	}
+	growth, err := Growth(current, previous)
+	if err != nil {
+		return 0, fmt.Errorf("TTM %s %s: %w", periodName(fiscalYear-1, fiscalQuarter), item, err)
+	}
+	return growth, nil
+}
+
+// CAGR returns the compound annual growth rate of the line item from the
+// fiscal year first to last, (last / first)^(1 / years) - 1.  Both values
+// have to be positive.
+func (f *Financials) CAGR(item string, first, last int) (float64, error) {
+	if last <= first {
+		return 0, fmt.Errorf("invalid fiscal years: %d to %d", first, last)
+	}
+	start, err := f.Value(item, first, 0)
+	if err != nil {
+		return 0, err
+	}
+	end, err := f.Value(item, last, 0)
+	if err != nil {
+		return 0, err
+	}
+	if start <= 0 || end <= 0 {
+		return 0, fmt.Errorf("CAGR %s FY%d to FY%d: %w", item, first, last, ErrZeroBase)
+	}
+	return math.Pow(end/start, 1/float64(last-first)) - 1, nil
+}
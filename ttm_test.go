@@ -0,0 +1,138 @@
+package alphavantage
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/AMekss/assert"
+)
+
+func newTestFinancials(t *testing.T) *Financials {
+	revenues := map[string]AVInt{
+		"2024-03-31": NewAVInt(130),
+		"2023-12-31": NewAVInt(140),
+		"2023-09-30": NewAVInt(120),
+		"2023-06-30": NewAVInt(110),
+		"2023-03-31": NewAVInt(100),
+		"2022-12-31": NewAVInt(105),
+		"2022-09-30": {}, // None
+		"2022-06-30": NewAVInt(90),
+	}
+	incomeStatement := &IncomeStatement{
+		AnnualReports: []IsAnnualReport{
+			{FiscalDateEnding: "2023-12-31", TotalRevenue: NewAVInt(470), NetIncome: NewAVInt(40)},
+			{FiscalDateEnding: "2022-12-31", TotalRevenue: NewAVInt(400), NetIncome: NewAVInt(0)},
+			{FiscalDateEnding: "2021-12-31", TotalRevenue: NewAVInt(300), NetIncome: NewAVInt(-10)},
+		},
+	}
+	for date, revenue := range revenues {
+		incomeStatement.QuarterlyReports = append(incomeStatement.QuarterlyReports, IsQuarterlyReport{FiscalDateEnding: date, TotalRevenue: revenue})
+	}
+	balanceSheet := &BalanceSheet{
+		QuarterlyReports: []BsQuarterlyReport{
+			{FiscalDateEnding: "2024-03-31", TotalAssets: NewAVInt(1000)},
+			{FiscalDateEnding: "2023-12-31", TotalAssets: NewAVInt(900)},
+		},
+	}
+	financials, err := NewFinancials("STOCK1", CalendarYear, balanceSheet, incomeStatement, nil, nil)
+	assert.NoError(t.Fatalf, err)
+	return financials
+}
+
+func TestFinancialsTTM(t *testing.T) {
+	financials := newTestFinancials(t)
+
+	ttm, err := financials.TTM("totalRevenue", 2024, 1)
+	assert.NoError(t, err)
+	assert.EqualFloat64(t, 500, ttm)
+
+	ttm, period, err := financials.LatestTTM("totalRevenue")
+	assert.NoError(t, err)
+	assert.EqualFloat64(t, 500, ttm)
+	assert.EqualStrings(t, "FY2024Q1", period.String())
+
+	_, err = financials.TTM("totalRevenue", 2023, 2)
+	assert.True(t, errors.Is(err, ErrNoneValue))
+	assert.ErrorIncludesMessage(t, "TTM: FY2022Q3 totalRevenue: value is None", err)
+
+	_, err = financials.TTM("totalRevenue", 2022, 2)
+	assert.True(t, errors.Is(err, ErrMissingPeriod))
+	assert.ErrorIncludesMessage(t, "FY2022Q1", err)
+
+	// cash flow statement wasn't fetched
+	_, err = financials.TTM("operatingCashflow", 2024, 1)
+	assert.ErrorIncludesMessage(t, "FY2024Q1 cashFlow: missing period", err)
+
+	_, err = financials.TTM("totalAssets", 2024, 1)
+	assert.True(t, errors.Is(err, ErrUnknownLineItem))
+
+	_, err = financials.TTM("totalRevenue", 2024, 0)
+	assert.ErrorIncludesMessage(t, "invalid fiscal quarter: 0", err)
+}
+
+func TestFinancialsPointInTime(t *testing.T) {
+	financials := newTestFinancials(t)
+
+	assets, err := financials.PointInTime("totalAssets", 2024, 1)
+	assert.NoError(t, err)
+	assert.EqualFloat64(t, 1000, assets)
+
+	_, err = financials.PointInTime("totalAssets", 2023, 3)
+	assert.ErrorIncludesMessage(t, "FY2023Q3 balanceSheet: missing period", err)
+
+	_, err = financials.PointInTime("totalRevenue", 2024, 1)
+	assert.True(t, errors.Is(err, ErrUnknownLineItem))
+}
+
+func TestFinancialsGrowth(t *testing.T) {
+	financials := newTestFinancials(t)
+
+	growth, err := financials.YoY("totalRevenue", 2024, 1)
+	assert.NoError(t, err)
+	assert.EqualFloat64Tol(t, 0.3, growth, 1e-12)
+
+	growth, err = financials.QoQ("totalRevenue", 2024, 1)
+	assert.NoError(t, err)
+	assert.EqualFloat64Tol(t, -10.0/140, growth, 1e-12)
+
+	growth, err = financials.YoY("totalRevenue", 2023, 0)
+	assert.NoError(t, err)
+	assert.EqualFloat64Tol(t, 0.175, growth, 1e-12)
+
+	// from a loss
+	growth, err = financials.YoY("netIncome", 2022, 0)
+	assert.NoError(t, err)
+	assert.EqualFloat64Tol(t, 1, growth, 1e-12)
+
+	_, err = financials.YoY("netIncome", 2023, 0)
+	assert.True(t, errors.Is(err, ErrZeroBase))
+
+	_, err = financials.YoY("totalRevenue", 2023, 3)
+	assert.True(t, errors.Is(err, ErrNoneValue))
+
+	cagr, err := financials.CAGR("totalRevenue", 2021, 2023)
+	assert.NoError(t, err)
+	assert.EqualFloat64Tol(t, math.Sqrt(470.0/300)-1, cagr, 1e-12)
+
+	_, err = financials.CAGR("netIncome", 2021, 2023)
+	assert.True(t, errors.Is(err, ErrZeroBase))
+
+	_, err = financials.CAGR("totalRevenue", 2020, 2023)
+	assert.True(t, errors.Is(err, ErrMissingPeriod))
+
+	_, err = financials.TTMYoY("totalRevenue", 2024, 1)
+	assert.True(t, errors.Is(err, ErrNoneValue))
+}
+
+func TestAddQuarters(t *testing.T) {
+	year, quarter := addQuarters(2024, 1, -1)
+	assert.EqualInt(t, 2023, year)
+	assert.EqualInt(t, 4, quarter)
+	year, quarter = addQuarters(2023, 4, 1)
+	assert.EqualInt(t, 2024, year)
+	assert.EqualInt(t, 1, quarter)
+	year, quarter = addQuarters(2024, 2, -5)
+	assert.EqualInt(t, 2023, year)
+	assert.EqualInt(t, 1, quarter)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Interval represents the possible interval types
@@ -38,28 +39,34 @@ type AnalyticsInterval string
 type SeriesType string
 
 // AVFloat64 represents a custom float type to handle "None" value.
+// Like sql.NullFloat64, Valid is false for "None".  A non-zero Value is
+// valid even if Valid isn't set, so only a genuine 0 needs Valid.
 type AVFloat64 struct {
 	Value float64
+	Valid bool
 }
 
-// UnmarshalJSON custom unmarshaller to handle "None" value.
+// UnmarshalJSON custom unmarshaller to handle "None" value, "." of the
+// economic indicators is "None" too.
 func (cf *AVFloat64) UnmarshalJSON(data []byte) error {
 	var value interface{}
 	if err := json.Unmarshal(data, &value); err != nil {
 		return err
 	}
 	switch v := value.(type) {
+	case nil:
+		*cf = AVFloat64{}
 	case float64:
-		cf.Value = v
+		*cf = AVFloat64{Value: v, Valid: true}
 	case string:
-		if v == "None" || v == "-" {
-			cf.Value = 0.0
+		if v == "None" || v == "-" || v == "." {
+			*cf = AVFloat64{}
 		} else {
 			f, err := strconv.ParseFloat(v, 64)
 			if err != nil {
 				return fmt.Errorf("unexpected string value: %s: %v", v, err)
 			}
-			cf.Value = f
+			*cf = AVFloat64{Value: f, Valid: true}
 		}
 	default:
 		return fmt.Errorf("unexpected value type: %T", v)
@@ -69,15 +76,25 @@ func (cf *AVFloat64) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON method to handle custom JSON marshaling
 func (cf AVFloat64) MarshalJSON() ([]byte, error) {
-	if cf.Value == 0 {
+	if cf.IsNull() {
 		return []byte("\"None\""), nil
 	}
 	return json.Marshal(strconv.FormatFloat(cf.Value, 'f', -1, 64))
 }
 
+// MarshalCSV custom marshaller to handle CSV marshaling.
+func (cf AVFloat64) MarshalCSV() ([]byte, error) {
+	if cf.IsNull() {
+		return []byte("None"), nil
+	}
+	return strconv.AppendFloat(nil, cf.Value, 'f', -1, 64), nil
+}
+
 // AVPercent represents a custom float type to handle percentage values.
+// Valid is false for "None" like in AVFloat64.
 type AVPercent struct {
 	Value float64
+	Valid bool
 }
 
 // UnmarshalJSON custom unmarshaller to handle "%" value.
@@ -87,18 +104,20 @@ func (cf *AVPercent) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	switch v := value.(type) {
+	case nil:
+		*cf = AVPercent{}
 	case float64:
-		cf.Value = v
+		*cf = AVPercent{Value: v, Valid: true}
 	case string:
 		if v == "None" || v == "-" {
-			cf.Value = 0.0
+			*cf = AVPercent{}
 		} else {
 			_v := strings.ReplaceAll(v, "%", "")
 			f, err := strconv.ParseFloat(_v, 64)
 			if err != nil {
 				return fmt.Errorf("unexpected string value: %s: %v", v, err)
 			}
-			cf.Value = f
+			*cf = AVPercent{Value: f, Valid: true}
 		}
 	default:
 		return fmt.Errorf("unexpected value type: %T", v)
@@ -108,15 +127,25 @@ func (cf *AVPercent) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON method to handle custom JSON marshaling
 func (cf AVPercent) MarshalJSON() ([]byte, error) {
-	if cf.Value == 0 {
+	if cf.IsNull() {
 		return []byte("\"None\""), nil
 	}
 	return json.Marshal(strconv.FormatFloat(cf.Value, 'f', 4, 64) + "%")
 }
 
-// AVInt represents a custom int type to handle "None" value.
+// MarshalCSV custom marshaller to handle CSV marshaling.
+func (cf AVPercent) MarshalCSV() ([]byte, error) {
+	if cf.IsNull() {
+		return []byte("None"), nil
+	}
+	return append(strconv.AppendFloat(nil, cf.Value, 'f', -1, 64), '%'), nil
+}
+
+// AVInt represents a custom int type to handle "None" value.  Valid is
+// false for "None" like in AVFloat64.
 type AVInt struct {
 	Value int
+	Valid bool
 }
 
 // UnmarshalJSON custom unmarshaller to handle "None" value.
@@ -126,11 +155,13 @@ func (cf *AVInt) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	switch v := value.(type) {
-	case int:
-		cf.Value = v
+	case nil:
+		*cf = AVInt{}
+	case float64:
+		*cf = AVInt{Value: int(v), Valid: true}
 	case string:
 		if v == "None" || v == "-" {
-			cf.Value = 0
+			*cf = AVInt{}
 		} else {
 			i, err := strconv.Atoi(v)
 			if err != nil {
@@ -138,10 +169,9 @@ func (cf *AVInt) UnmarshalJSON(data []byte) error {
 				if err != nil {
 					return fmt.Errorf("unexpected string value: %s: %v", v, err)
 				}
-				cf.Value = int(f)
-			} else {
-				cf.Value = i
+				i = int(f)
 			}
+			*cf = AVInt{Value: i, Valid: true}
 		}
 	default:
 		return fmt.Errorf("unexpected value type: %T", v)
@@ -151,7 +181,7 @@ func (cf *AVInt) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON method to handle custom JSON marshaling
 func (cf AVInt) MarshalJSON() ([]byte, error) {
-	if cf.Value == 0 {
+	if cf.IsNull() {
 		return []byte("\"None\""), nil
 	}
 	return json.Marshal(strconv.Itoa(cf.Value))
@@ -159,12 +189,58 @@ func (cf AVInt) MarshalJSON() ([]byte, error) {
 
 // MarshalCSV custom marshaller to handle CSV marshaling.
 func (cf AVInt) MarshalCSV() ([]byte, error) {
-	if cf.Value == 0 {
-		return []byte("\"None\""), nil
+	if cf.IsNull() {
+		return []byte("None"), nil
 	}
 	return strconv.AppendInt(nil, int64(cf.Value), 10), nil
 }
 
+// AVDate represents a custom date type to handle "None" value.
+type AVDate struct {
+	Value time.Time
+}
+
+// UnmarshalJSON custom unmarshaller to handle "None" value.
+func (cd *AVDate) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+		cd.Value = time.Time{}
+	case string:
+		if v == "None" || v == "-" || v == "" {
+			cd.Value = time.Time{}
+		} else {
+			t, err := time.Parse(DateFormat, v)
+			if err != nil {
+				return fmt.Errorf("unexpected string value: %s: %v", v, err)
+			}
+			cd.Value = t
+		}
+	default:
+		return fmt.Errorf("unexpected value type: %T", v)
+	}
+	return nil
+}
+
+// MarshalJSON method to handle custom JSON marshaling
+func (cd AVDate) MarshalJSON() ([]byte, error) {
+	if cd.Value.IsZero() {
+		return []byte("\"None\""), nil
+	}
+	return json.Marshal(cd.Value.Format(DateFormat))
+}
+
+// String returns the date in DateFormat or "None".
+func (cd AVDate) String() string {
+	if cd.Value.IsZero() {
+		return "None"
+	}
+	return cd.Value.Format(DateFormat)
+}
+
 const (
 	// Interval1Min represents the 1 minute interval.
 	Interval1Min = Interval("1min")
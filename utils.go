@@ -67,271 +67,119 @@ func GetPrevYear(dateString string) (int, int, error) {
 }
 
 // GetCashFlowMostRecentQuarterlyReport retrieves the most recent quarterly report from a CashFlow struct.
+//
+// Deprecated: use NewQuarterlyIndex, which is fiscal year aware.
 func GetCashFlowMostRecentQuarterlyReport(cf *CashFlow) (*CfQuarterlyReport, error) {
-	var mostRecent CfQuarterlyReport
-	mostRecentDate := time.Time{}
-	for _, report := range cf.QuarterlyReports {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.After(mostRecentDate) {
-			mostRecent = report
-			mostRecentDate = reportDate
-		}
-	}
-	return &mostRecent, nil
+	return mostRecentReport(cf.QuarterlyReports)
 }
 
 // GetCashFlowMostRecentAnnualReport retrieves the most recent annual report from a CashFlow struct.
+//
+// Deprecated: use NewAnnualIndex, which is fiscal year aware.
 func GetCashFlowMostRecentAnnualReport(cf *CashFlow) (*CfAnnualReport, error) {
-	var mostRecent CfAnnualReport
-	mostRecentDate := time.Time{}
-	for _, report := range cf.AnnualReports {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.After(mostRecentDate) {
-			mostRecent = report
-			mostRecentDate = reportDate
-		}
-	}
-	return &mostRecent, nil
+	return mostRecentReport(cf.AnnualReports)
 }
 
 // GetCashFlowHistoricalQuarterlyReport retrieves a specific quarterly report from a CashFlow struct.
+// The report has to end on the last day of the calendar quarter 0 to 3, e.g. 2023-06-30 for 2023 and 1.
+//
+// Deprecated: use NewQuarterlyIndex, which is fiscal year aware.
 func GetCashFlowHistoricalQuarterlyReport(cf *CashFlow, year int, quarter int) (*CfQuarterlyReport, error) {
-	if quarter < 0 || quarter > 3 {
-		return nil, errors.New("Quarter must be between 0 and 3")
-	}
-	targetDates := []string{
-		fmt.Sprintf("%d-03-31", year),
-		fmt.Sprintf("%d-06-30", year),
-		fmt.Sprintf("%d-09-30", year),
-		fmt.Sprintf("%d-12-31", year),
-	}
-	for _, report := range cf.QuarterlyReports {
-		if report.FiscalDateEnding == targetDates[quarter] {
-			return &report, nil
-		}
-	}
-	return nil, errors.New("Not found")
+	return calendarQuarterReport(cf.QuarterlyReports, year, quarter)
 }
 
 // GetCashFlowHistoricalAnnualReport retrieves a specific annual report from a CashFlow struct.
+//
+// Deprecated: use NewAnnualIndex, which is fiscal year aware.
 func GetCashFlowHistoricalAnnualReport(cf *CashFlow, year int) (*CfAnnualReport, error) {
-	for _, report := range cf.AnnualReports {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.Year() == year {
-			return &report, nil
-		}
-	}
-	return nil, errors.New("Not found")
+	return calendarYearReport(cf.AnnualReports, year)
 }
 
 // GetBalanceSheetMostRecentQuarterlyReport retrieves the most recent quarterly report from a BalanceSheet struct.
+//
+// Deprecated: use NewQuarterlyIndex, which is fiscal year aware.
 func GetBalanceSheetMostRecentQuarterlyReport(bs *BalanceSheet) (*BsQuarterlyReport, error) {
-	var mostRecent BsQuarterlyReport
-	mostRecentDate := time.Time{}
-	for _, report := range bs.QuarterlyReports {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.After(mostRecentDate) {
-			mostRecent = report
-			mostRecentDate = reportDate
-		}
-	}
-	return &mostRecent, nil
+	return mostRecentReport(bs.QuarterlyReports)
 }
 
 // GetBalanceSheetMostRecentAnnualReport retrieves the most recent annual report from a BalanceSheet struct.
+//
+// Deprecated: use NewAnnualIndex, which is fiscal year aware.
 func GetBalanceSheetMostRecentAnnualReport(bs *BalanceSheet) (*BsAnnualReport, error) {
-	var mostRecent BsAnnualReport
-	mostRecentDate := time.Time{}
-	for _, report := range bs.AnnualReports {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.After(mostRecentDate) {
-			mostRecent = report
-			mostRecentDate = reportDate
-		}
-	}
-	return &mostRecent, nil
+	return mostRecentReport(bs.AnnualReports)
 }
 
 // GetBalanceSheetHistoricalQuarterlyReport retrieves a specific quarterly report from a BalanceSheet struct.
+// The report has to end on the last day of the calendar quarter 0 to 3, e.g. 2023-06-30 for 2023 and 1.
+//
+// Deprecated: use NewQuarterlyIndex, which is fiscal year aware.
 func GetBalanceSheetHistoricalQuarterlyReport(bs *BalanceSheet, year int, quarter int) (*BsQuarterlyReport, error) {
-	if quarter < 0 || quarter > 3 {
-		return nil, errors.New("Quarter must be between 0 and 3")
-	}
-	targetDates := []string{
-		fmt.Sprintf("%d-03-31", year),
-		fmt.Sprintf("%d-06-30", year),
-		fmt.Sprintf("%d-09-30", year),
-		fmt.Sprintf("%d-12-31", year),
-	}
-	for _, report := range bs.QuarterlyReports {
-		if report.FiscalDateEnding == targetDates[quarter] {
-			return &report, nil
-		}
-	}
-	return nil, errors.New("Not found")
+	return calendarQuarterReport(bs.QuarterlyReports, year, quarter)
 }
 
 // GetBalanceSheetHistoricalAnnualReport retrieves a specific annual report from a BalanceSheet struct.
+//
+// Deprecated: use NewAnnualIndex, which is fiscal year aware.
 func GetBalanceSheetHistoricalAnnualReport(bs *BalanceSheet, year int) (*BsAnnualReport, error) {
-	for _, report := range bs.AnnualReports {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.Year() == year {
-			return &report, nil
-		}
-	}
-	return nil, errors.New("Not found")
+	return calendarYearReport(bs.AnnualReports, year)
 }
 
 // GetIncomeStatementMostRecentQuarterlyReport retrieves the most recent quarterly report from an IncomeStatement struct.
+//
+// Deprecated: use NewQuarterlyIndex, which is fiscal year aware.
 func GetIncomeStatementMostRecentQuarterlyReport(is *IncomeStatement) (*IsQuarterlyReport, error) {
-	var mostRecent IsQuarterlyReport
-	mostRecentDate := time.Time{}
-	for _, report := range is.QuarterlyReports {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.After(mostRecentDate) {
-			mostRecent = report
-			mostRecentDate = reportDate
-		}
-	}
-	return &mostRecent, nil
+	return mostRecentReport(is.QuarterlyReports)
 }
 
 // GetIncomeStatementMostRecentAnnualReport retrieves the most recent annual report from an IncomeStatement struct.
+//
+// Deprecated: use NewAnnualIndex, which is fiscal year aware.
 func GetIncomeStatementMostRecentAnnualReport(is *IncomeStatement) (*IsAnnualReport, error) {
-	var mostRecent IsAnnualReport
-	mostRecentDate := time.Time{}
-	for _, report := range is.AnnualReports {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.After(mostRecentDate) {
-			mostRecent = report
-			mostRecentDate = reportDate
-		}
-	}
-	return &mostRecent, nil
+	return mostRecentReport(is.AnnualReports)
 }
 
 // GetIncomeStatementHistoricalQuarterlyReport retrieves a specific quarterly report from an IncomeStatement struct.
+// The report has to end on the last day of the calendar quarter 0 to 3, e.g. 2023-06-30 for 2023 and 1.
+//
+// Deprecated: use NewQuarterlyIndex, which is fiscal year aware.
 func GetIncomeStatementHistoricalQuarterlyReport(is *IncomeStatement, year int, quarter int) (*IsQuarterlyReport, error) {
-	if quarter < 0 || quarter > 3 {
-		return nil, errors.New("Quarter must be between 0 and 3")
-	}
-	targetDates := []string{
-		fmt.Sprintf("%d-03-31", year),
-		fmt.Sprintf("%d-06-30", year),
-		fmt.Sprintf("%d-09-30", year),
-		fmt.Sprintf("%d-12-31", year),
-	}
-	for _, report := range is.QuarterlyReports {
-		if report.FiscalDateEnding == targetDates[quarter] {
-			return &report, nil
-		}
-	}
-	return nil, errors.New("Not found")
+	return calendarQuarterReport(is.QuarterlyReports, year, quarter)
 }
 
 // GetIncomeStatementHistoricalAnnualReport retrieves a specific annual report from an IncomeStatement struct.
+//
+// Deprecated: use NewAnnualIndex, which is fiscal year aware.
 func GetIncomeStatementHistoricalAnnualReport(is *IncomeStatement, year int) (*IsAnnualReport, error) {
-	for _, report := range is.AnnualReports {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.Year() == year {
-			return &report, nil
-		}
-	}
-	return nil, errors.New("Not found")
+	return calendarYearReport(is.AnnualReports, year)
 }
 
 // GetEarningsMostRecentQuarterlyReport retrieves the most recent quarterly report from an Earnings struct.
+//
+// Deprecated: use NewQuarterlyIndex, which is fiscal year aware.
 func GetEarningsMostRecentQuarterlyReport(e *Earnings) (*QuarterlyEarnings, error) {
-	var mostRecent QuarterlyEarnings
-	mostRecentDate := time.Time{}
-	for _, report := range e.QuarterlyEarnings {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.After(mostRecentDate) {
-			mostRecent = report
-			mostRecentDate = reportDate
-		}
-	}
-	return &mostRecent, nil
+	return mostRecentReport(e.QuarterlyEarnings)
 }
 
 // GetEarningsMostRecentAnnualReport retrieves the most recent annual report from an Earnings struct.
+//
+// Deprecated: use NewAnnualIndex, which is fiscal year aware.
 func GetEarningsMostRecentAnnualReport(e *Earnings) (*AnnualEarnings, error) {
-	var mostRecent AnnualEarnings
-	mostRecentDate := time.Time{}
-	for _, report := range e.AnnualEarnings {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.After(mostRecentDate) {
-			mostRecent = report
-			mostRecentDate = reportDate
-		}
-	}
-	return &mostRecent, nil
+	return mostRecentReport(e.AnnualEarnings)
 }
 
 // GetEarningsHistoricalQuarterlyReport retrieves a specific quarterly report from an Earnings struct.
+// The report has to end on the last day of the calendar quarter 0 to 3, e.g. 2023-06-30 for 2023 and 1.
+//
+// Deprecated: use NewQuarterlyIndex, which is fiscal year aware.
 func GetEarningsHistoricalQuarterlyReport(e *Earnings, year int, quarter int) (*QuarterlyEarnings, error) {
-	if quarter < 0 || quarter > 3 {
-		return nil, errors.New("Quarter must be between 0 and 3")
-	}
-	targetDates := []string{
-		fmt.Sprintf("%d-03-31", year),
-		fmt.Sprintf("%d-06-30", year),
-		fmt.Sprintf("%d-09-30", year),
-		fmt.Sprintf("%d-12-31", year),
-	}
-	for _, report := range e.QuarterlyEarnings {
-		if report.FiscalDateEnding == targetDates[quarter] {
-			return &report, nil
-		}
-	}
-	return nil, errors.New("Not found")
+	return calendarQuarterReport(e.QuarterlyEarnings, year, quarter)
 }
 
 // GetEarningsHistoricalAnnualReport retrieves a specific annual report from an Earnings struct.
+//
+// Deprecated: use NewAnnualIndex, which is fiscal year aware.
 func GetEarningsHistoricalAnnualReport(e *Earnings, year int) (*AnnualEarnings, error) {
-	for _, report := range e.AnnualEarnings {
-		reportDate, err := time.Parse("2006-01-02", report.FiscalDateEnding)
-		if err != nil {
-			return nil, fmt.Errorf("Parse error: %v", err)
-		}
-		if reportDate.Year() == year {
-			return &report, nil
-		}
-	}
-	return nil, errors.New("Not found")
+	return calendarYearReport(e.AnnualEarnings, year)
 }
 
 // GetTimeSeriesMonthlyData retrieves the monthly data from a TimeSeries struct for a specific year and quarter.